@@ -0,0 +1,521 @@
+package cairo
+
+import (
+	"math"
+	"sort"
+)
+
+//defaultTolerance is the libcairo default tolerance.
+const defaultTolerance = 0.1
+
+//maximum depth of recursion when flattening a Bézier spline.
+const maxFlattenDepth = 16
+
+func cross(p, q Point) float64 {
+	return p.X*q.Y - p.Y*q.X
+}
+
+//distance of p from the line segment a-b.
+func segDist(p, a, b Point) float64 {
+	d := b.Sub(a)
+	l := d.Dot(d)
+	if l == 0 {
+		return p.Sub(a).Mag()
+	}
+	t := p.Sub(a).Dot(d) / l
+	switch {
+	case t < 0:
+		t = 0
+	case t > 1:
+		t = 1
+	}
+	return p.Sub(a.Add(d.Mul(t))).Mag()
+}
+
+//flattenCurve calls line with each point of a piecewise-linear approximation,
+//accurate to tolerance, of the cubic Bézier spline p0, p1, p2, p3,
+//excluding p0.
+func flattenCurve(p0, p1, p2, p3 Point, tolerance float64, depth int, line func(Point)) {
+	if depth >= maxFlattenDepth ||
+		math.Max(segDist(p1, p0, p3), segDist(p2, p0, p3)) <= tolerance {
+		line(p3)
+		return
+	}
+	//de Casteljau subdivision at t = 1/2
+	p01, p12, p23 := p0.Add(p1).Div(2), p1.Add(p2).Div(2), p2.Add(p3).Div(2)
+	p012, p123 := p01.Add(p12).Div(2), p12.Add(p23).Div(2)
+	mid := p012.Add(p123).Div(2)
+	flattenCurve(p0, p01, p012, mid, tolerance, depth+1, line)
+	flattenCurve(mid, p123, p23, p3, tolerance, depth+1, line)
+}
+
+//Flatten returns a copy of p where every PathCurveTo has been replaced
+//by a series of PathLineTo that approximate the curve to within tolerance.
+//
+//If tolerance is not positive, the libcairo default of 0.1 is used.
+//
+//Flatten is the Path equivalent of Context.CopyPathFlat.
+func (p Path) Flatten(tolerance float64) Path {
+	if tolerance <= 0 {
+		tolerance = defaultTolerance
+	}
+	var out Path
+	var cp, start Point
+	has := false
+	for _, pe := range p {
+		pts := pe.pts()
+		switch pe.Type() {
+		case PathMoveTo:
+			cp, start, has = pts[0], pts[0], true
+			out.MoveTo(cp)
+		case PathLineTo:
+			if !has {
+				start, has = pts[0], true
+			}
+			cp = pts[0]
+			out.LineTo(cp)
+		case PathCurveTo:
+			if !has {
+				cp, start, has = pts[0], pts[0], true
+				out.MoveTo(cp)
+			}
+			flattenCurve(cp, pts[0], pts[1], pts[2], tolerance, 0, out.LineTo)
+			cp = pts[2]
+		case PathClosePath:
+			out.ClosePath()
+			cp = start
+		}
+	}
+	return out
+}
+
+//rings splits a flattened path into its implicitly closed sub-paths
+//as they would be filled.
+//Sub-paths that cannot enclose any area are dropped.
+func (p Path) rings(tolerance float64) (rings [][]Point, err error) {
+	if !p.valid() {
+		return nil, ErrInvalidPathData
+	}
+	var cur []Point
+	flush := func() {
+		//remove duplicated consecutive points
+		var r []Point
+		for _, pt := range cur {
+			if len(r) == 0 || !r[len(r)-1].Eq(pt) {
+				r = append(r, pt)
+			}
+		}
+		if len(r) > 1 && r[0].Eq(r[len(r)-1]) {
+			r = r[:len(r)-1]
+		}
+		if len(r) >= 3 {
+			rings = append(rings, r)
+		}
+		cur = nil
+	}
+	var start Point
+	for _, pe := range p.Flatten(tolerance) {
+		switch pe.Type() {
+		case PathMoveTo:
+			flush()
+			start = pe.pts()[0]
+			cur = append(cur, start)
+		case PathLineTo:
+			if len(cur) == 0 {
+				start = pe.pts()[0]
+			}
+			cur = append(cur, pe.pts()[0])
+		case PathClosePath:
+			flush()
+			cur = append(cur, start)
+		}
+	}
+	flush()
+	return rings, nil
+}
+
+type segment struct {
+	a, b    Point
+	operand int
+}
+
+//boolOp is a set operation on the regions enclosed by two paths.
+type boolOp int
+
+const (
+	boolUnion boolOp = iota
+	boolIntersect
+	boolDifference
+	boolXor
+)
+
+func (o boolOp) in(a, b bool) bool {
+	switch o {
+	case boolUnion:
+		return a || b
+	case boolIntersect:
+		return a && b
+	case boolDifference:
+		return a && !b
+	case boolXor:
+		return a != b
+	}
+	return false
+}
+
+func (f FillRule) inside(winding int) bool {
+	if f == FillRuleEvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+//clipper holds the state of a single boolean operation.
+type clipper struct {
+	eps   float64 //positional tolerance
+	segs  []segment
+	verts map[[2]int64][]Point //known vertices, bucketed by position
+}
+
+//snap returns a known vertex within eps of p, if there is one.
+//Otherwise p becomes a known vertex.
+func (c *clipper) snap(p Point) Point {
+	size := 4 * c.eps
+	x, y := int64(math.Floor(p.X/size)), int64(math.Floor(p.Y/size))
+	for i := x - 1; i <= x+1; i++ {
+		for j := y - 1; j <= y+1; j++ {
+			for _, v := range c.verts[[2]int64{i, j}] {
+				if v.Near(p, c.eps) {
+					return v
+				}
+			}
+		}
+	}
+	k := [2]int64{x, y}
+	c.verts[k] = append(c.verts[k], p)
+	return p
+}
+
+func newClipper(rings [2][][]Point) *clipper {
+	scale := 1.
+	for _, rs := range rings {
+		for _, r := range rs {
+			for _, p := range r {
+				scale = math.Max(scale, math.Max(math.Abs(p.X), math.Abs(p.Y)))
+			}
+		}
+	}
+	c := &clipper{
+		eps:   1e-9 * scale,
+		verts: map[[2]int64][]Point{},
+	}
+	for op, rs := range rings {
+		for _, r := range rs {
+			for i := range r {
+				a, b := c.snap(r[i]), c.snap(r[(i+1)%len(r)])
+				if !a.Eq(b) {
+					c.segs = append(c.segs, segment{a, b, op})
+				}
+			}
+		}
+	}
+	return c
+}
+
+//split returns every segment split at all points where it intersects,
+//touches, or overlaps any other segment.
+//Duplicate segments are removed.
+func (c *clipper) split() [][2]Point {
+	cuts := make([][]Point, len(c.segs))
+	for i := range c.segs {
+		for j := i + 1; j < len(c.segs); j++ {
+			c.intersect(i, j, cuts)
+		}
+	}
+
+	var out [][2]Point
+	seen := map[[2]Point]bool{}
+	add := func(a, b Point) {
+		if a.Eq(b) {
+			return
+		}
+		k := [2]Point{a, b}
+		if b.X < a.X || (b.X == a.X && b.Y < a.Y) {
+			k = [2]Point{b, a}
+		}
+		if !seen[k] {
+			seen[k] = true
+			out = append(out, [2]Point{a, b})
+		}
+	}
+	for i, s := range c.segs {
+		d := s.b.Sub(s.a)
+		cs := cuts[i]
+		sort.Slice(cs, func(i, j int) bool {
+			return cs[i].Sub(s.a).Dot(d) < cs[j].Sub(s.a).Dot(d)
+		})
+		last := s.a
+		for _, p := range cs {
+			add(last, p)
+			last = p
+		}
+		add(last, s.b)
+	}
+	return out
+}
+
+//param reports where p falls along s, where 0 is s.a and 1 is s.b.
+func param(p Point, s segment) float64 {
+	d := s.b.Sub(s.a)
+	return p.Sub(s.a).Dot(d) / d.Dot(d)
+}
+
+//interior reports whether p is strictly between the endpoints of s.
+func (c *clipper) interior(p Point, s segment) bool {
+	if p.Near(s.a, c.eps) || p.Near(s.b, c.eps) {
+		return false
+	}
+	t := param(p, s)
+	return t > 0 && t < 1
+}
+
+func (c *clipper) intersect(i, j int, cuts [][]Point) {
+	s, t := c.segs[i], c.segs[j]
+	d1, d2 := s.b.Sub(s.a), t.b.Sub(t.a)
+	cut := func(p Point) {
+		p = c.snap(p)
+		if c.interior(p, s) {
+			cuts[i] = append(cuts[i], p)
+		}
+		if c.interior(p, t) {
+			cuts[j] = append(cuts[j], p)
+		}
+	}
+
+	denom := cross(d1, d2)
+	if math.Abs(denom) <= c.eps*d1.Mag()*d2.Mag() {
+		//parallel: only of interest if collinear
+		if math.Abs(cross(t.a.Sub(s.a), d1)) > c.eps*d1.Mag() {
+			return
+		}
+		for _, p := range []Point{t.a, t.b} {
+			if c.interior(p, s) {
+				cuts[i] = append(cuts[i], p)
+			}
+		}
+		for _, p := range []Point{s.a, s.b} {
+			if c.interior(p, t) {
+				cuts[j] = append(cuts[j], p)
+			}
+		}
+		return
+	}
+
+	w := t.a.Sub(s.a)
+	u, v := cross(w, d2)/denom, cross(w, d1)/denom
+	const ε = 1e-12
+	if u < -ε || u > 1+ε || v < -ε || v > 1+ε {
+		return
+	}
+	//prefer existing endpoints to computed points
+	switch {
+	case segDist(s.a, t.a, t.b) <= c.eps:
+		cut(s.a)
+	case segDist(s.b, t.a, t.b) <= c.eps:
+		cut(s.b)
+	case segDist(t.a, s.a, s.b) <= c.eps:
+		cut(t.a)
+	case segDist(t.b, s.a, s.b) <= c.eps:
+		cut(t.b)
+	default:
+		cut(s.a.Add(d1.Mul(u)))
+	}
+}
+
+//windings reports the winding number of operand just to the left and just
+//to the right of the segment a-b, which must not cross any segment.
+func (c *clipper) windings(a, b Point, operand int) (left, right int) {
+	d := b.Sub(a)
+	m := a.Add(d.Div(2))
+	n := Pt(-d.Y, d.X).Norm()
+
+	//Cast a ray from the midpoint, perpendicular to a-b, towards the left.
+	//Any segment that overlaps a-b only touches the ray at its origin,
+	//so those are handled separately.
+	var overlap int
+	for _, s := range c.segs {
+		if s.operand != operand {
+			continue
+		}
+		sd := s.b.Sub(s.a)
+		if segDist(m, s.a, s.b) <= c.eps && math.Abs(cross(sd, d)) <= c.eps*sd.Mag()*d.Mag() {
+			if sd.Dot(d) > 0 {
+				overlap++
+			} else {
+				overlap--
+			}
+			continue
+		}
+		//rotate into the frame where the ray is the positive x-axis.
+		va, vb := s.a.Sub(m), s.b.Sub(m)
+		ax, ay := va.Dot(n), cross(n, va)
+		bx, by := vb.Dot(n), cross(n, vb)
+		isLeft := (bx-ax)*(0-ay) - (0-ax)*(by-ay)
+		if ay <= 0 {
+			if by > 0 && isLeft > 0 {
+				left++
+			}
+		} else if by <= 0 && isLeft < 0 {
+			left--
+		}
+	}
+	return left, left - overlap
+}
+
+//run performs the boolean operation and returns the boundary of the result
+//as closed rings, each with the filled region to its left.
+func (c *clipper) run(op boolOp, rule FillRule) [][]Point {
+	type edge struct {
+		a, b Point
+		used bool
+	}
+	var edges []*edge
+	for _, s := range c.split() {
+		a, b := s[0], s[1]
+		la, ra := c.windings(a, b, 0)
+		lb, rb := c.windings(a, b, 1)
+		l := op.in(rule.inside(la), rule.inside(lb))
+		r := op.in(rule.inside(ra), rule.inside(rb))
+		switch {
+		case l && !r:
+			edges = append(edges, &edge{a: a, b: b})
+		case r && !l:
+			edges = append(edges, &edge{a: b, b: a})
+		}
+	}
+
+	out := map[Point][]*edge{}
+	for _, e := range edges {
+		out[e.a] = append(out[e.a], e)
+	}
+
+	var rings [][]Point
+	for _, e := range edges {
+		if e.used {
+			continue
+		}
+		e.used = true
+		ring := []Point{e.a}
+		for cur := e; !cur.b.Eq(e.a); {
+			ring = append(ring, cur.b)
+			//take the leftmost turn to keep touching rings apart
+			in := cur.b.Sub(cur.a)
+			var next *edge
+			best := math.Inf(-1)
+			for _, o := range out[cur.b] {
+				if o.used {
+					continue
+				}
+				v := o.b.Sub(o.a)
+				if θ := math.Atan2(cross(in, v), in.Dot(v)); θ > best {
+					best, next = θ, o
+				}
+			}
+			if next == nil {
+				break
+			}
+			next.used = true
+			cur = next
+		}
+		if ring = c.simplify(ring); ring != nil {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+//simplify removes redundant vertices from ring.
+//If the resulting ring is degenerate, nil is returned.
+func (c *clipper) simplify(ring []Point) []Point {
+	for changed := true; changed && len(ring) >= 3; {
+		changed = false
+		for i := 0; i < len(ring) && len(ring) >= 3; i++ {
+			prev := ring[(i+len(ring)-1)%len(ring)]
+			next := ring[(i+1)%len(ring)]
+			if segDist(ring[i], prev, next) <= c.eps || prev.Eq(ring[i]) {
+				ring = append(ring[:i], ring[i+1:]...)
+				changed = true
+				i--
+			}
+		}
+	}
+	if len(ring) < 3 {
+		return nil
+	}
+	var area float64
+	for i, p := range ring {
+		area += cross(p, ring[(i+1)%len(ring)])
+	}
+	if math.Abs(area) <= c.eps {
+		return nil
+	}
+	return ring
+}
+
+func (p Path) boolean(q Path, op boolOp, rule FillRule, tolerance float64) (Path, error) {
+	var rings [2][][]Point
+	var err error
+	if rings[0], err = p.rings(tolerance); err != nil {
+		return nil, err
+	}
+	if rings[1], err = q.rings(tolerance); err != nil {
+		return nil, err
+	}
+
+	var out Path
+	for _, r := range newClipper(rings).run(op, rule) {
+		out.MoveTo(r[0])
+		for _, pt := range r[1:] {
+			out.LineTo(pt)
+		}
+		out.ClosePath()
+	}
+	return out, nil
+}
+
+//Union returns a path enclosing every point enclosed by p or q.
+//
+//Both paths are interpreted as they would be filled with the fill rule rule,
+//with each sub-path implicitly closed.
+//Curves are flattened to within tolerance before the operation is performed.
+//If tolerance is not positive, the libcairo default of 0.1 is used.
+//
+//The result contains only PathMoveTo, PathLineTo, and PathClosePath elements,
+//has no self-intersections and no redundant vertices,
+//and is filled the same with either fill rule.
+//
+//If either path contains invalid data, ErrInvalidPathData is returned.
+func (p Path) Union(q Path, rule FillRule, tolerance float64) (Path, error) {
+	return p.boolean(q, boolUnion, rule, tolerance)
+}
+
+//Intersect returns a path enclosing every point enclosed by both p and q.
+//
+//See Union for how p and q are interpreted and the properties of the result.
+func (p Path) Intersect(q Path, rule FillRule, tolerance float64) (Path, error) {
+	return p.boolean(q, boolIntersect, rule, tolerance)
+}
+
+//Difference returns a path enclosing every point enclosed by p but not by q.
+//
+//See Union for how p and q are interpreted and the properties of the result.
+func (p Path) Difference(q Path, rule FillRule, tolerance float64) (Path, error) {
+	return p.boolean(q, boolDifference, rule, tolerance)
+}
+
+//Xor returns a path enclosing every point enclosed by exactly one of p and q.
+//
+//See Union for how p and q are interpreted and the properties of the result.
+func (p Path) Xor(q Path, rule FillRule, tolerance float64) (Path, error) {
+	return p.boolean(q, boolXor, rule, tolerance)
+}
@@ -0,0 +1,120 @@
+package cairo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func square(r Rectangle) (p Path) {
+	x0y0, x0y1, x1y1, x1y0 := r.Verts()
+	p.MoveTo(x0y0)
+	p.LineTo(x1y0)
+	p.LineTo(x1y1)
+	p.LineTo(x0y1)
+	p.ClosePath()
+	return p
+}
+
+//area returns the filled area of a path produced by a boolean operation.
+func area(p Path) (a float64) {
+	var start, last Point
+	for _, pe := range p {
+		switch pe.Type() {
+		case PathMoveTo:
+			start, last = pe.pts()[0], pe.pts()[0]
+		case PathLineTo:
+			a += cross(last, pe.pts()[0])
+			last = pe.pts()[0]
+		case PathClosePath:
+			a += cross(last, start)
+		}
+	}
+	return a / 2
+}
+
+func TestBoolean(t *testing.T) {
+	a := square(RectWH(0, 0, 10, 10))
+	b := square(RectWH(5, 5, 10, 10))
+	//same square as a, but wound in the other direction, with a hole
+	c := square(Rect(10, 0, 0, 10))
+	c = append(c, square(RectWH(2, 2, 2, 2))...)
+
+	for _, tc := range []struct {
+		name string
+		op   func(Path, Path, FillRule, float64) (Path, error)
+		p, q Path
+		rule FillRule
+		area float64
+	}{
+		{"union", Path.Union, a, b, FillRuleWinding, 175},
+		{"intersect", Path.Intersect, a, b, FillRuleWinding, 25},
+		{"difference", Path.Difference, a, b, FillRuleWinding, 75},
+		{"xor", Path.Xor, a, b, FillRuleWinding, 150},
+		{"self", Path.Union, a, a, FillRuleWinding, 100},
+		{"disjoint", Path.Intersect, a, square(RectWH(20, 20, 1, 1)), FillRuleWinding, 0},
+		{"winding", Path.Union, c, nil, FillRuleWinding, 100},
+		{"evenodd", Path.Union, c, nil, FillRuleEvenOdd, 96},
+		{"shared edge", Path.Union, a, square(RectWH(10, 0, 10, 10)), FillRuleWinding, 200},
+	} {
+		r, err := tc.op(tc.p, tc.q, tc.rule, 0)
+		if err != nil {
+			t.Errorf("%s: %s", tc.name, err)
+			continue
+		}
+		if got := area(r); math.Abs(got-tc.area) > 1e-9 {
+			t.Errorf("%s: area %g, want %g\n%v", tc.name, got, tc.area, r)
+		}
+	}
+
+	//the union of two squares sharing an edge is a single rectangle
+	r, _ := a.Union(square(RectWH(10, 0, 10, 10)), FillRuleWinding, 0)
+	if len(r) != 5 {
+		t.Errorf("shared edge: want a single rectangle, got %v", r)
+	}
+
+	//a circle flattened within tolerance
+	var circ Path
+	k := 4 * (math.Sqrt2 - 1) / 3
+	circ.MoveTo(Pt(1, 0))
+	circ.CurveTo(Pt(1, k), Pt(k, 1), Pt(0, 1))
+	circ.CurveTo(Pt(-k, 1), Pt(-1, k), Pt(-1, 0))
+	circ.CurveTo(Pt(-1, -k), Pt(-k, -1), Pt(0, -1))
+	circ.CurveTo(Pt(k, -1), Pt(1, -k), Pt(1, 0))
+	circ.ClosePath()
+	r, err := circ.Intersect(square(Rect(0, -2, 2, 2)), FillRuleWinding, 1e-4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := area(r); math.Abs(got-math.Pi/2) > 1e-3 {
+		t.Errorf("half circle: area %g, want %g", got, math.Pi/2)
+	}
+
+	if _, err := a.Union(Path{pathElement{dtype: PathLineTo}}, FillRuleWinding, 0); err != ErrInvalidPathData {
+		t.Errorf("invalid path: got %v, want ErrInvalidPathData", err)
+	}
+}
+
+func ExamplePath_Difference() {
+	var frame, hole Path
+	frame.MoveTo(Pt(0, 0))
+	frame.LineTo(Pt(4, 0))
+	frame.LineTo(Pt(4, 4))
+	frame.LineTo(Pt(0, 4))
+	frame.ClosePath()
+
+	hole.MoveTo(Pt(1, 1))
+	hole.LineTo(Pt(3, 1))
+	hole.LineTo(Pt(3, 3))
+	hole.LineTo(Pt(1, 3))
+	hole.ClosePath()
+
+	p, err := frame.Difference(hole, FillRuleWinding, 0)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	//p has two sub-paths: the outer square and the hole wound in reverse.
+	fmt.Println(len(p))
+	// Output: 10
+}
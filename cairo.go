@@ -65,7 +65,7 @@ func New(target Surface) (*Context, error) {
 		s: target,
 	}
 	runtime.SetFinalizer(c, (*Context).Close)
-	return c, c.Err()
+	return c, c.err("New")
 }
 
 //Close destroys c.
@@ -76,7 +76,7 @@ func (c *Context) Close() error {
 		return nil
 	}
 	runtime.SetFinalizer(c, nil)
-	err := c.Err()
+	err := c.err("Context.Close")
 	C.cairo_destroy(c.c)
 	c.c = nil
 	c.s = nil
@@ -87,10 +87,15 @@ func (c *Context) Close() error {
 //
 //Originally cairo_status.
 func (c *Context) Err() error {
+	return c.err("")
+}
+
+//err reports the current error state of c as reported by op.
+func (c *Context) err(op string) error {
 	if c.c == nil {
 		return ErrInvalidLibcairoHandle
 	}
-	return toerr(C.cairo_status(c.c))
+	return toerr(C.cairo_status(c.c), op, "context", c.c)
 }
 
 //Save makes a copy of the current drawing state on an internal stack
@@ -114,7 +119,7 @@ func (c *Context) Save() *Context {
 //Originally cairo_restore.
 func (c *Context) Restore() error {
 	C.cairo_restore(c.c)
-	return c.Err()
+	return c.err("Context.Restore")
 }
 
 //SaveRestore saves the current drawing state, runs f on c, and then restores
@@ -123,7 +128,7 @@ func (c *Context) SaveRestore(f func(*Context) error) (err error) {
 	c.Save()
 	defer func() {
 		e := c.Restore()
-		if err != nil {
+		if err == nil {
 			err = e
 		}
 	}()
@@ -201,7 +206,7 @@ func (c *Context) PushGroupWithContent(content Content) *Context {
 //Originally cairo_pop_group.
 func (c *Context) PopGroup() (Pattern, error) {
 	p := C.cairo_pop_group(c.c)
-	if err := c.Err(); err != nil {
+	if err := c.err("Context.PopGroup"); err != nil {
 		return nil, err
 	}
 	return cPattern(p)
@@ -229,7 +234,7 @@ func (c *Context) PopGroup() (Pattern, error) {
 //Originally cairo_pop_group_to_source.
 func (c *Context) PopGroupToSource() error {
 	C.cairo_pop_group_to_source(c.c)
-	return c.Err()
+	return c.err("Context.PopGroupToSource")
 }
 
 //GroupTarget returns the current destination surface for c.
@@ -292,7 +297,7 @@ func (c *Context) SetSourceSurface(s Surface, originDisplacement Point) error {
 	sr := s.XtensionRaw()
 	x, y := originDisplacement.c()
 	C.cairo_set_source_surface(c.c, sr, x, y)
-	return c.Err()
+	return c.err("Context.SetSourceSurface")
 }
 
 //Source returns the current source pattern for c.
@@ -315,7 +320,7 @@ func (c *Context) Source() (Pattern, error) {
 //FontOptions.SetAntialiasMode.
 //
 //Originally cairo_set_antialias.
-func (c *Context) SetAntialiasMode(a antialias) *Context {
+func (c *Context) SetAntialiasMode(a Antialias) *Context {
 	C.cairo_set_antialias(c.c, a.c())
 	return c
 }
@@ -323,8 +328,8 @@ func (c *Context) SetAntialiasMode(a antialias) *Context {
 //AntialiasMode reports the current shape antialiasing mode.
 //
 //Originally cairo_get_antialias.
-func (c *Context) AntialiasMode() antialias {
-	return antialias(C.cairo_get_antialias(c.c))
+func (c *Context) AntialiasMode() Antialias {
+	return Antialias(C.cairo_get_antialias(c.c))
 }
 
 //SetDash sets the dash pattern to be used by Stroke.
@@ -428,7 +433,7 @@ func (c *Context) Dashes() (offset float64, dashes []float64) {
 //The fill rule affects Fill and Clip.
 //
 //Originally cairo_set_fill_rule.
-func (c *Context) SetFillRule(f fillRule) *Context {
+func (c *Context) SetFillRule(f FillRule) *Context {
 	C.cairo_set_fill_rule(c.c, f.c())
 	return c
 }
@@ -436,14 +441,14 @@ func (c *Context) SetFillRule(f fillRule) *Context {
 //FillRule reports the current fill rule.
 //
 //Originally cairo_get_fill_rule.
-func (c *Context) FillRule() fillRule {
-	return fillRule(C.cairo_get_fill_rule(c.c))
+func (c *Context) FillRule() FillRule {
+	return FillRule(C.cairo_get_fill_rule(c.c))
 }
 
 //SetLineCap sets the line cap style.
 //
 //Originally cairo_set_line_cap
-func (c *Context) SetLineCap(lc lineCap) *Context {
+func (c *Context) SetLineCap(lc LineCap) *Context {
 	C.cairo_set_line_cap(c.c, lc.c())
 	return c
 }
@@ -451,14 +456,14 @@ func (c *Context) SetLineCap(lc lineCap) *Context {
 //LineCap reports the current line cap.
 //
 //Originally cairo_get_line_cap.
-func (c *Context) LineCap() lineCap {
-	return lineCap(C.cairo_get_line_cap(c.c))
+func (c *Context) LineCap() LineCap {
+	return LineCap(C.cairo_get_line_cap(c.c))
 }
 
 //SetLineJoin sets the line join style.
 //
 //Originally cairo_set_line_join
-func (c *Context) SetLineJoin(l lineJoin) *Context {
+func (c *Context) SetLineJoin(l LineJoin) *Context {
 	C.cairo_set_line_join(c.c, l.c())
 	return c
 }
@@ -466,8 +471,8 @@ func (c *Context) SetLineJoin(l lineJoin) *Context {
 //LineJoin reports the current line join style.
 //
 //Originally cairo_get_line_join.
-func (c *Context) LineJoin() lineJoin {
-	return lineJoin(C.cairo_get_line_join(c.c))
+func (c *Context) LineJoin() LineJoin {
+	return LineJoin(C.cairo_get_line_join(c.c))
 }
 
 //SetLineWidth sets the current line width.
@@ -527,7 +532,7 @@ func (c *Context) MiterLimit() float64 {
 //SetOperator sets the compositing operator used for all drawing operations.
 //
 //Originally cairo_set_operator.
-func (c *Context) SetOperator(op operator) *Context {
+func (c *Context) SetOperator(op Operator) *Context {
 	C.cairo_set_operator(c.c, op.c())
 	return c
 }
@@ -535,8 +540,8 @@ func (c *Context) SetOperator(op operator) *Context {
 //Operator reports the current compositing operator.
 //
 //Originally cairo_get_operator.
-func (c *Context) Operator() operator {
-	return operator(C.cairo_get_operator(c.c))
+func (c *Context) Operator() Operator {
+	return Operator(C.cairo_get_operator(c.c))
 }
 
 //SetTolerance sets the tolerance, in device units, when converting paths into
@@ -636,7 +641,7 @@ func (c *Context) ResetClip() *Context {
 //Originally cairo_copy_clip_rectangle_list.
 func (c *Context) ClipRectangles() (list []Rectangle, err error) {
 	rects := C.cairo_copy_clip_rectangle_list(c.c)
-	if err := toerr(rects.status); err != nil {
+	if err := toerr(rects.status, "Context.ClipRectangles", "context", c.c); err != nil {
 		return nil, err
 	}
 
@@ -845,7 +850,7 @@ func (c *Context) ShowPage() *Context {
 func (c *Context) CopyPath() (Path, error) {
 	p := C.cairo_copy_path(c.c)
 	defer C.cairo_path_destroy(p)
-	return cPath(p)
+	return cPath(p, "Context.CopyPath", "context", c.c)
 }
 
 //CopyPathFlat returns a linearized copy of the current path.
@@ -860,7 +865,7 @@ func (c *Context) CopyPath() (Path, error) {
 func (c *Context) CopyPathFlat() (Path, error) {
 	p := C.cairo_copy_path_flat(c.c)
 	defer C.cairo_path_destroy(p)
-	return cPath(p)
+	return cPath(p, "Context.CopyPathFlat", "context", c.c)
 }
 
 //AppendPath appends path onto the current path of c.
@@ -873,7 +878,7 @@ func (c *Context) AppendPath(path Path) error {
 	}
 	C.cairo_append_path(c.c, p)
 	C.cairo_path_destroy(p)
-	return c.Err()
+	return c.err("Context.AppendPath")
 }
 
 //CurrentPoint reports the current point of the current path.
@@ -1208,7 +1213,7 @@ func (c *Context) TextPath(s string) *Context {
 //SelectFont is part of the "toy" text API.
 //
 //Originally cairo_select_font_face.
-func (c *Context) SelectFont(family string, slant slant, weight weight) *Context {
+func (c *Context) SelectFont(family string, slant Slant, weight Weight) *Context {
 	f := C.CString(family)
 	C.cairo_select_font_face(c.c, f, slant.c(), weight.c())
 	C.free(unsafe.Pointer(f))
@@ -1299,7 +1304,7 @@ func (c *Context) ScaledFont() (*ScaledFont, error) {
 	f := C.cairo_get_scaled_font(c.c)
 	f = C.cairo_scaled_font_reference(f)
 	sf := cNewScaledFont(f)
-	return sf, c.Err()
+	return sf, c.err("Context.ScaledFont")
 }
 
 //ShowText draws a shape generated from s, rendered according to the current
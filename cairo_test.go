@@ -1,6 +1,7 @@
 package cairo
 
 import (
+	"fmt"
 	"image/png"
 	"log"
 	"os"
@@ -29,7 +30,7 @@ func Example_helloWorld() {
 		MoveTo(Pt(10, 50)).
 		ShowText("Hello, world")
 
-	img, err := surface.ToImage()
+	img, err := surface.ToImage(ZP)
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -68,3 +69,41 @@ func ExampleContext_Circle_drawEllipse() {
 		return nil
 	})
 }
+
+//Measure and shape text without a Context, then draw it so that
+//the text is searchable in the output.
+func ExampleScaledFont_TextToGlyphs() {
+	font := NewToyFont("serif", SlantNormal, WeightNormal)
+	defer font.Close()
+
+	sf, err := NewScaledFont(font, NewScaleMatrix(Pt(12, 12)), NewIdentityMatrix(), nil)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer sf.Close()
+
+	const text = "Hello, world"
+	baseline := sf.Extents().Ascent
+	glyphs, clusters, flags, err := sf.TextToGlyphs(Pt(10, baseline), text)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	surface, err := NewImageSurface(FormatARGB32, 240, 80)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer surface.Close()
+	cr, err := New(surface)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer cr.Close()
+
+	cr.
+		SetScaledFont(sf).
+		ShowTextGlyphs(text, glyphs, clusters, flags)
+
+	fmt.Println(len(glyphs), "glyphs in", len(clusters), "clusters")
+	// Output: 12 glyphs in 12 clusters
+}
@@ -0,0 +1,192 @@
+//Package cairotest compares drawings against golden images in tests.
+//
+//Each test draws on a Context for an ImageSurface of a fixed size,
+//and the result is compared with a PNG checked in to the package's testdata
+//directory:
+//	func TestDrawing(t *testing.T) {
+//		var s cairotest.Suite
+//		s.Modes = cairotest.Direct | cairotest.Recording
+//		s.Add("circle", 64, 64, func(c *cairo.Context) error {
+//			c.Circle(cairo.Circ(32, 32, 30)).SetSourceColor(cairo.Blue).Fill()
+//			return c.Err()
+//		})
+//		s.Run(t)
+//	}
+//
+//Golden images are created, or regenerated after an intended change,
+//by running the tests with the -cairotest.update flag:
+//	go test -cairotest.update
+//
+//When a drawing does not match its golden image, the drawing and an image
+//highlighting the differences are written next to the golden image.
+//For the test above, in Direct mode, those are
+//	testdata/circle.direct.got.png
+//	testdata/circle.direct.diff.png
+//They are removed when the test next passes.
+//
+//Antialiasing and font rendering differ slightly between versions and builds
+//of libcairo, so tests that are run against more than one usually need
+//a Tolerance.
+package cairotest
+
+import (
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//Update is whether Check regenerates golden images before comparing with
+//them.
+//It is set by the -cairotest.update flag, and may also be set by tests
+//that register their own flag for it.
+var Update bool
+
+func init() {
+	flag.BoolVar(&Update, "cairotest.update", false, "regenerate cairotest golden images")
+}
+
+//Test is a drawing to compare with a golden image.
+type Test struct {
+	//Name is the name of the test and of its golden image, Name.png.
+	//It must be a valid file name.
+	Name string
+	//Width and Height are the size of the surface drawn on, in pixels.
+	Width, Height int
+	//Draw draws the test.
+	Draw func(*cairo.Context) error
+}
+
+//Config specifies how tests are run.
+type Config struct {
+	//Dir is the directory containing the golden images.
+	//If empty, "testdata" is used.
+	Dir string
+	//Tolerance is the difference from the golden images allowed.
+	Tolerance Tolerance
+	//Modes are the ways each test is rendered.
+	//Each is compared with the same golden image.
+	//If zero, Direct is used.
+	Modes Mode
+}
+
+func (c Config) dir() string {
+	if c.Dir == "" {
+		return "testdata"
+	}
+	return c.Dir
+}
+
+//Suite is a set of tests that share a Config.
+type Suite struct {
+	Config
+	tests []Test
+}
+
+//Add registers a test named name that draws on a width×height surface
+//with draw.
+func (s *Suite) Add(name string, width, height int, draw func(*cairo.Context) error) {
+	s.tests = append(s.tests, Test{
+		Name:   name,
+		Width:  width,
+		Height: height,
+		Draw:   draw,
+	})
+}
+
+//Run runs each test in s, in the order they were added,
+//as a subtest of t.
+func (s *Suite) Run(t *testing.T) {
+	for _, test := range s.tests {
+		test := test
+		t.Run(test.Name, func(t *testing.T) {
+			Check(t, s.Config, test)
+		})
+	}
+}
+
+//Check renders test in each mode in c and reports any differences from its
+//golden image to t.
+//
+//If Update is set, the golden image is first rendered in Direct
+//mode and written.
+func Check(t testing.TB, c Config, test Test) {
+	path := filepath.Join(c.dir(), test.Name+".png")
+
+	if Update {
+		got, err := Direct.render(test)
+		if err != nil {
+			t.Fatalf("%s: %v", Direct, err)
+		}
+		if err := writePNG(path, got); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := readPNG(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("no golden image %s: run go test -cairotest.update to create it", path)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range c.Modes.modes() {
+		got, err := m.render(test)
+		if err != nil {
+			t.Errorf("%s: %v", m, err)
+			continue
+		}
+
+		base := filepath.Join(c.dir(), test.Name+"."+m.String())
+		gotPath, diffPath := base+".got.png", base+".diff.png"
+
+		d, err := Compare(want, got, c.Tolerance)
+		if err != nil {
+			t.Errorf("%s: %v", m, err)
+			continue
+		}
+		if d.Pixels <= c.Tolerance.Pixels {
+			os.Remove(gotPath)
+			os.Remove(diffPath)
+			continue
+		}
+
+		if err := writePNG(gotPath, got); err != nil {
+			t.Error(err)
+		}
+		if err := writePNG(diffPath, d.Image); err != nil {
+			t.Error(err)
+		}
+		t.Errorf("%s: %d pixels differ from %s, by up to %d in a channel and %.1f ΔE; see %s",
+			m, d.Pixels, path, d.MaxChannel, d.MaxDeltaE, diffPath)
+	}
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,147 @@
+package cairotest
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//Tolerance is the difference between two images that is allowed.
+type Tolerance struct {
+	//Channel is the largest difference allowed in any channel of a pixel,
+	//in 8 bit units.
+	Channel uint8
+	//DeltaE, if positive, is the largest perceptual difference allowed
+	//in a pixel, as the CIE76 ΔE of the pixels composited on black and on
+	//white.
+	//A ΔE of about 2.3 is just noticeable.
+	//
+	//A pixel only differs if it exceeds both Channel and DeltaE,
+	//so that small changes to antialiasing do not fail a test,
+	//even if they are larger than Channel.
+	DeltaE float64
+	//Pixels is the number of pixels allowed to differ.
+	Pixels int
+}
+
+//Diff describes the difference between two images.
+type Diff struct {
+	//Pixels is the number of pixels that differ.
+	Pixels int
+	//MaxChannel is the largest difference in any channel of any pixel,
+	//in 8 bit units.
+	MaxChannel uint8
+	//MaxDeltaE is the largest perceptual difference of any pixel.
+	MaxDeltaE float64
+	//Image shows the pixels that differ in red on a faded copy of
+	//the wanted image.
+	Image *image.RGBA
+}
+
+//ErrSize is returned by Compare when the images are not the same size.
+var ErrSize = errors.New("cairotest: images differ in size")
+
+var diffColor = color.RGBA{0xff, 0, 0, 0xff}
+
+//Compare returns the difference of got from want.
+func Compare(want, got image.Image, tol Tolerance) (Diff, error) {
+	wb, gb := want.Bounds(), got.Bounds()
+	if wb.Size() != gb.Size() {
+		return Diff{}, ErrSize
+	}
+
+	d := Diff{
+		Image: image.NewRGBA(image.Rect(0, 0, wb.Dx(), wb.Dy())),
+	}
+	for y := 0; y < wb.Dy(); y++ {
+		for x := 0; x < wb.Dx(); x++ {
+			w := want.At(wb.Min.X+x, wb.Min.Y+y)
+			g := got.At(gb.Min.X+x, gb.Min.Y+y)
+
+			ch := channelDiff(w, g)
+			if ch > d.MaxChannel {
+				d.MaxChannel = ch
+			}
+			differs := ch > tol.Channel
+			if ch > 0 {
+				e := deltaE(w, g)
+				if e > d.MaxDeltaE {
+					d.MaxDeltaE = e
+				}
+				if tol.DeltaE > 0 && e <= tol.DeltaE {
+					differs = false
+				}
+			}
+
+			if differs {
+				d.Pixels++
+				d.Image.SetRGBA(x, y, diffColor)
+			} else {
+				d.Image.SetRGBA(x, y, faded(w))
+			}
+		}
+	}
+	return d, nil
+}
+
+//channelDiff returns the largest difference between a channel of a and b.
+func channelDiff(a, b color.Color) uint8 {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	var max uint32
+	for _, d := range [...]uint32{
+		absDiff(ar, br),
+		absDiff(ag, bg),
+		absDiff(ab, bb),
+		absDiff(aa, ba),
+	} {
+		if d > max {
+			max = d
+		}
+	}
+	return uint8(max >> 8)
+}
+
+func absDiff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+//deltaE returns the larger of the CIE76 ΔE of a and b composited on black
+//and on white.
+func deltaE(a, b color.Color) float64 {
+	ab, aw := over(a)
+	bb, bw := over(b)
+	return math.Max(dist(ab.Lab(), bb.Lab()), dist(aw.Lab(), bw.Lab()))
+}
+
+//over returns c composited on black and on white.
+func over(c color.Color) (black, white cairo.Color) {
+	r, g, b, a := c.RGBA()
+	black = cairo.Color{
+		R: float64(r) / 0xffff,
+		G: float64(g) / 0xffff,
+		B: float64(b) / 0xffff,
+	}
+	t := float64(0xffff-a) / 0xffff
+	white = cairo.Color{R: black.R + t, G: black.G + t, B: black.B + t}
+	return black, white
+}
+
+func dist(a, b cairo.Lab) float64 {
+	dl, da, db := a.L-b.L, a.A-b.A, a.B-b.B
+	return math.Sqrt(dl*dl + da*da + db*db)
+}
+
+//faded returns c composited on white and faded toward light gray.
+func faded(c color.Color) color.RGBA {
+	_, w := over(c)
+	y := 0.2126*w.R + 0.7152*w.G + 0.0722*w.B
+	v := uint8(0xc0 + y*0x3f)
+	return color.RGBA{v, v, v, 0xff}
+}
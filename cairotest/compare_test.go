@@ -0,0 +1,90 @@
+package cairotest
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func fill(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestCompare(t *testing.T) {
+	gray := color.RGBA{0x80, 0x80, 0x80, 0xff}
+	want := fill(4, 4, gray)
+
+	got := fill(4, 4, gray)
+	got.SetRGBA(1, 1, color.RGBA{0x83, 0x80, 0x80, 0xff})
+	got.SetRGBA(2, 2, color.RGBA{0xff, 0x00, 0x00, 0xff})
+	//offset bounds must not matter
+	got.Rect = got.Rect.Add(image.Pt(10, 10))
+
+	for _, test := range []struct {
+		tol    Tolerance
+		pixels int
+	}{
+		{Tolerance{}, 2},
+		{Tolerance{Channel: 3}, 1},
+		{Tolerance{Channel: 0xff}, 0},
+		{Tolerance{DeltaE: 2.3}, 1},
+		{Tolerance{DeltaE: 200}, 0},
+	} {
+		d, err := Compare(want, got, test.tol)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Pixels != test.pixels {
+			t.Errorf("%+v: %d pixels differ, want %d", test.tol, d.Pixels, test.pixels)
+		}
+		if d.MaxChannel != 0x80 {
+			t.Errorf("%+v: max channel difference %d, want %d", test.tol, d.MaxChannel, 0x80)
+		}
+		if d.Image.RGBAAt(2, 2) != diffColor && test.pixels > 0 {
+			t.Errorf("%+v: difference not highlighted", test.tol)
+		}
+		if d.Image.RGBAAt(0, 0) == diffColor {
+			t.Errorf("%+v: equal pixel highlighted", test.tol)
+		}
+	}
+
+	if _, err := Compare(want, fill(4, 5, gray), Tolerance{}); err != ErrSize {
+		t.Errorf("got %v, want %v", err, ErrSize)
+	}
+}
+
+func TestDeltaE(t *testing.T) {
+	black := color.RGBA{0, 0, 0, 0xff}
+	clear := color.RGBA{}
+	if e := deltaE(black, black); e != 0 {
+		t.Errorf("equal colors: ΔE %g", e)
+	}
+	//transparent and opaque black are equal on black, but not on white
+	if e := deltaE(black, clear); e < 99 {
+		t.Errorf("opaque and transparent black: ΔE %g, want ~100", e)
+	}
+}
+
+func TestModeString(t *testing.T) {
+	for _, test := range []struct {
+		m    Mode
+		want string
+	}{
+		{Direct, "direct"},
+		{Recording | Script, "recording|script"},
+		{0, "unknown mode"},
+	} {
+		if got := test.m.String(); got != test.want {
+			t.Errorf("Mode(%d): got %q, want %q", int(test.m), got, test.want)
+		}
+	}
+	if ms := Mode(0).modes(); len(ms) != 1 || ms[0] != Direct {
+		t.Errorf("zero Mode: got %v, want [direct]", ms)
+	}
+}
@@ -0,0 +1,151 @@
+package cairotest
+
+import (
+	"bytes"
+	"image"
+	"strings"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/recording"
+	"github.com/jimmyfrasche/cairo/script"
+	"github.com/jimmyfrasche/cairo/script/interpreter"
+)
+
+//Mode is a set of ways to render a test.
+type Mode int
+
+//Modes of rendering a test.
+//
+//All modes render to an ImageSurface with FormatARGB32 in the end.
+const (
+	//Direct draws on the image surface.
+	Direct Mode = 1 << iota
+	//Recording draws on a recording surface that is then replayed
+	//on the image surface.
+	Recording
+	//Script draws on a script surface, and the script written is read
+	//back with cairo/script/interpreter and replayed on the image surface.
+	Script
+)
+
+var modeNames = []struct {
+	m    Mode
+	name string
+}{
+	{Direct, "direct"},
+	{Recording, "recording"},
+	{Script, "script"},
+}
+
+func (m Mode) String() string {
+	var names []string
+	for _, n := range modeNames {
+		if m&n.m != 0 {
+			names = append(names, n.name)
+		}
+	}
+	if len(names) == 0 {
+		return "unknown mode"
+	}
+	return strings.Join(names, "|")
+}
+
+//modes returns each mode in m, in order, or Direct if m is zero.
+func (m Mode) modes() []Mode {
+	if m == 0 {
+		return []Mode{Direct}
+	}
+	var ms []Mode
+	for _, n := range modeNames {
+		if m&n.m != 0 {
+			ms = append(ms, n.m)
+		}
+	}
+	return ms
+}
+
+//render renders test in the single mode m.
+func (m Mode) render(test Test) (*image.RGBA, error) {
+	img, err := cairo.NewImageSurface(cairo.FormatARGB32, test.Width, test.Height)
+	if err != nil {
+		return nil, err
+	}
+	defer img.Close()
+
+	switch m {
+	case Direct:
+		err = draw(img, test.Draw)
+	case Recording:
+		err = renderRecording(img, test)
+	case Script:
+		err = renderScript(img, test)
+	default:
+		panic("cairotest: cannot render in " + m.String())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return img.ToImage(cairo.ZP)
+}
+
+func renderRecording(img cairo.ImageSurface, test Test) error {
+	extents := cairo.RectWH(0, 0, float64(test.Width), float64(test.Height))
+	r := recording.New(cairo.ContentColorAlpha, extents)
+	defer r.Close()
+	if err := r.Err(); err != nil {
+		return err
+	}
+	if err := draw(r, test.Draw); err != nil {
+		return err
+	}
+	return r.Replay(img, cairo.Matrix{}, cairo.Rectangle{})
+}
+
+func renderScript(img cairo.ImageSurface, test Test) error {
+	var buf bytes.Buffer
+	if err := writeScript(&buf, test); err != nil {
+		return err
+	}
+	r, err := interpreter.ReadRecording(&buf)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return r.Replay(img, cairo.Matrix{}, cairo.Rectangle{})
+}
+
+//writeScript draws test on a script surface writing to buf.
+//The script is complete once writeScript returns.
+func writeScript(buf *bytes.Buffer, test Test) error {
+	d, err := script.New(buf, script.ASCII)
+	if err != nil {
+		return err
+	}
+	s, err := d.NewSurface(cairo.ContentColorAlpha, float64(test.Width), float64(test.Height))
+	if err != nil {
+		d.Close()
+		return err
+	}
+	err = draw(s, test.Draw)
+	if cerr := s.Close(); err == nil {
+		err = cerr
+	}
+	//closing the device finishes it, writing the rest of the script
+	if cerr := d.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+//draw calls f with a Context for s.
+func draw(s cairo.Surface, f func(*cairo.Context) error) error {
+	c, err := cairo.New(s)
+	if err != nil {
+		return err
+	}
+	if err := f(c); err != nil {
+		c.Close()
+		return err
+	}
+	return c.Close()
+}
@@ -0,0 +1,50 @@
+package cairotest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+var circle = Test{
+	Name:   "circle",
+	Width:  16,
+	Height: 16,
+	Draw: func(c *cairo.Context) error {
+		c.Circle(cairo.Circ(8, 8, 6)).SetSourceColor(cairo.Blue).Fill()
+		return c.Err()
+	},
+}
+
+func TestWriteScript(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeScript(&buf, circle); err != nil {
+		t.Fatal(err)
+	}
+	//the script must be complete, not merely begun
+	if s := buf.String(); !strings.Contains(s, "fill") {
+		t.Errorf("script does not fill:\n%s", s)
+	}
+}
+
+func TestRenderModes(t *testing.T) {
+	want, err := Direct.render(circle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range (Recording | Script).modes() {
+		got, err := m.render(circle)
+		if err != nil {
+			t.Errorf("%s: %v", m, err)
+			continue
+		}
+		d, err := Compare(want, got, Tolerance{})
+		if err != nil {
+			t.Errorf("%s: %v", m, err)
+		} else if d.Pixels != 0 {
+			t.Errorf("%s: %d pixels differ from direct", m, d.Pixels)
+		}
+	}
+}
@@ -16,15 +16,15 @@ func clamp01(f float64) float64 {
 	return f
 }
 
-//convert f from [0,1] to uint32.
+//convert f from [0,1] to a 16-bit color component.
 func ctoi(f float64) uint32 {
 	f = clamp01(f)
-	return uint32(f*float64(1<<32-1) + .5)
+	return uint32(f*0xffff + .5)
 }
 
-//convert i to [0,1].
+//convert the 16-bit color component i to [0,1].
 func cto01(i uint32) float64 {
-	return float64(i) / float64(1<<32-1)
+	return float64(i) / 0xffff
 }
 
 //Color represents an RGB color where each component is in [0, 1].
@@ -38,7 +38,7 @@ func (c Color) Canon() Color {
 }
 
 func (co Color) c() (r, g, b C.double) {
-	return C.double(co.R), C.double(co.G), C.double(co.G)
+	return C.double(co.R), C.double(co.G), C.double(co.B)
 }
 
 func (c Color) RGBA() (r, g, b, a uint32) {
@@ -0,0 +1,28 @@
+package cairo
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestColorRGBA(t *testing.T) {
+	//RGBA must return 16-bit components, as required by color.Color.
+	r, g, b, a := (AlphaColor{1, .5, 0, 1}).RGBA()
+	if r != 0xffff || g != 0x8000 || b != 0 || a != 0xffff {
+		t.Errorf("RGBA: got %#x %#x %#x %#x", r, g, b, a)
+	}
+	got := color.RGBAModel.Convert(Red).(color.RGBA)
+	if want := (color.RGBA{0xff, 0, 0, 0xff}); got != want {
+		t.Errorf("Red as color.RGBA: got %v, want %v", got, want)
+	}
+	if got := colorToAlpha(color.RGBA{0, 0, 0xff, 0xff}); got != (AlphaColor{0, 0, 1, 1}) {
+		t.Errorf("color.RGBA blue: got %v", got)
+	}
+}
+
+func TestColorC(t *testing.T) {
+	r, g, b := (Color{.1, .2, .3}).c()
+	if float64(r) != .1 || float64(g) != .2 || float64(b) != .3 {
+		t.Errorf("c: got %v %v %v, want .1 .2 .3", r, g, b)
+	}
+}
@@ -0,0 +1,575 @@
+package cairo
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//Color spaces
+
+//HSL is a color in the hue, saturation, lightness color space.
+//
+//H is in degrees, in [0, 360).
+//S and L are in [0, 1].
+type HSL struct {
+	H, S, L float64
+}
+
+//HSV is a color in the hue, saturation, value color space.
+//
+//H is in degrees, in [0, 360).
+//S and V are in [0, 1].
+type HSV struct {
+	H, S, V float64
+}
+
+//LinearRGB is a color in the linear-light sRGB color space,
+//that is, without the sRGB transfer function applied.
+//
+//Each component is in [0, 1].
+type LinearRGB struct {
+	R, G, B float64
+}
+
+//Lab is a color in the CIE L*a*b* color space, relative to the D65 white
+//point.
+//
+//L is in [0, 100].
+//A and B are, for colors that can be represented as a Color,
+//roughly in [-128, 128].
+type Lab struct {
+	L, A, B float64
+}
+
+//LCh is a color in the CIE LCh color space, the cylindrical
+//form of Lab.
+//
+//L is in [0, 100].
+//C is the chroma, which is 0 for grays.
+//H is the hue in degrees, in [0, 360).
+type LCh struct {
+	L, C, H float64
+}
+
+//normalize degrees to [0, 360)
+func deg(h float64) float64 {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	return h
+}
+
+//hue returns the hue, in degrees, and the max and min components of c.
+func (c Color) hue() (h, max, min float64) {
+	max = math.Max(c.R, math.Max(c.G, c.B))
+	min = math.Min(c.R, math.Min(c.G, c.B))
+	d := max - min
+	switch {
+	case d == 0:
+		h = 0
+	case max == c.R:
+		h = (c.G - c.B) / d
+	case max == c.G:
+		h = (c.B-c.R)/d + 2
+	default:
+		h = (c.R-c.G)/d + 4
+	}
+	return deg(60 * h), max, min
+}
+
+//HSL returns c in the HSL color space.
+func (c Color) HSL() HSL {
+	h, max, min := c.hue()
+	l := (max + min) / 2
+	var s float64
+	if d := max - min; d != 0 {
+		s = d / (1 - math.Abs(2*l-1))
+	}
+	return HSL{h, s, l}
+}
+
+//HSV returns c in the HSV color space.
+func (c Color) HSV() HSV {
+	h, max, min := c.hue()
+	var s float64
+	if max != 0 {
+		s = (max - min) / max
+	}
+	return HSV{h, s, max}
+}
+
+//fromHue returns the color with hue h, chroma c, and x
+//the second largest component, before lightness is added.
+func fromHue(h, c float64) Color {
+	h = deg(h) / 60
+	x := c * (1 - math.Abs(math.Mod(h, 2)-1))
+	switch int(h) {
+	case 0:
+		return Color{c, x, 0}
+	case 1:
+		return Color{x, c, 0}
+	case 2:
+		return Color{0, c, x}
+	case 3:
+		return Color{0, x, c}
+	case 4:
+		return Color{x, 0, c}
+	}
+	return Color{c, 0, x}
+}
+
+func (c Color) plus(m float64) Color {
+	return Color{c.R + m, c.G + m, c.B + m}
+}
+
+//Color returns h as an RGB color.
+func (h HSL) Color() Color {
+	c := (1 - math.Abs(2*h.L-1)) * h.S
+	return fromHue(h.H, c).plus(h.L - c/2)
+}
+
+//RGBA implements the image/color.Color interface.
+func (h HSL) RGBA() (r, g, b, a uint32) {
+	return h.Color().RGBA()
+}
+
+//Color returns h as an RGB color.
+func (h HSV) Color() Color {
+	c := h.V * h.S
+	return fromHue(h.H, c).plus(h.V - c)
+}
+
+//RGBA implements the image/color.Color interface.
+func (h HSV) RGBA() (r, g, b, a uint32) {
+	return h.Color().RGBA()
+}
+
+func toLinear(c float64) float64 {
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func fromLinear(c float64) float64 {
+	if c <= 0.0031308 {
+		return 12.92 * c
+	}
+	return 1.055*math.Pow(c, 1/2.4) - 0.055
+}
+
+//Linear returns c in the linear-light sRGB color space.
+func (c Color) Linear() LinearRGB {
+	return LinearRGB{toLinear(c.R), toLinear(c.G), toLinear(c.B)}
+}
+
+//Color returns l as an sRGB color.
+func (l LinearRGB) Color() Color {
+	return Color{fromLinear(l.R), fromLinear(l.G), fromLinear(l.B)}
+}
+
+//RGBA implements the image/color.Color interface.
+func (l LinearRGB) RGBA() (r, g, b, a uint32) {
+	return l.Color().RGBA()
+}
+
+//D65 white point.
+const (
+	whiteX = 0.95047
+	whiteY = 1.0
+	whiteZ = 1.08883
+)
+
+const labδ = 6. / 29
+
+func labf(t float64) float64 {
+	if t > labδ*labδ*labδ {
+		return math.Cbrt(t)
+	}
+	return t/(3*labδ*labδ) + 4./29
+}
+
+func labfinv(t float64) float64 {
+	if t > labδ {
+		return t * t * t
+	}
+	return 3 * labδ * labδ * (t - 4./29)
+}
+
+//Lab returns c in the CIE L*a*b* color space.
+func (c Color) Lab() Lab {
+	l := c.Linear()
+	x := 0.4124564*l.R + 0.3575761*l.G + 0.1804375*l.B
+	y := 0.2126729*l.R + 0.7151522*l.G + 0.0721750*l.B
+	z := 0.0193339*l.R + 0.1191920*l.G + 0.9503041*l.B
+	fx, fy, fz := labf(x/whiteX), labf(y/whiteY), labf(z/whiteZ)
+	return Lab{
+		L: 116*fy - 16,
+		A: 500 * (fx - fy),
+		B: 200 * (fy - fz),
+	}
+}
+
+//Color returns l as an RGB color.
+//
+//Colors outside of the sRGB gamut will have components outside of [0, 1].
+//Use Canon to clamp them.
+func (l Lab) Color() Color {
+	fy := (l.L + 16) / 116
+	x := whiteX * labfinv(fy+l.A/500)
+	y := whiteY * labfinv(fy)
+	z := whiteZ * labfinv(fy-l.B/200)
+	return LinearRGB{
+		R: 3.2404542*x - 1.5371385*y - 0.4985314*z,
+		G: -0.9692660*x + 1.8760108*y + 0.0415560*z,
+		B: 0.0556434*x - 0.2040259*y + 1.0572252*z,
+	}.Color()
+}
+
+//RGBA implements the image/color.Color interface.
+func (l Lab) RGBA() (r, g, b, a uint32) {
+	return l.Color().RGBA()
+}
+
+//LCh returns l in the CIE LCh color space.
+func (l Lab) LCh() LCh {
+	return LCh{
+		L: l.L,
+		C: math.Hypot(l.A, l.B),
+		H: deg(math.Atan2(l.B, l.A) * 180 / math.Pi),
+	}
+}
+
+//Lab returns l in the CIE L*a*b* color space.
+func (l LCh) Lab() Lab {
+	s, c := math.Sincos(l.H * math.Pi / 180)
+	return Lab{l.L, l.C * c, l.C * s}
+}
+
+//LCh returns c in the CIE LCh color space.
+func (c Color) LCh() LCh {
+	return c.Lab().LCh()
+}
+
+//Color returns l as an RGB color.
+//
+//Colors outside of the sRGB gamut will have components outside of [0, 1].
+//Use Canon to clamp them.
+func (l LCh) Color() Color {
+	return l.Lab().Color()
+}
+
+//RGBA implements the image/color.Color interface.
+func (l LCh) RGBA() (r, g, b, a uint32) {
+	return l.Color().RGBA()
+}
+
+//CSS colors
+
+//ParseColor parses a CSS color.
+//
+//The following forms are accepted, case insensitively:
+//	#rgb #rgba #rrggbb #rrggbbaa
+//	rgb(r, g, b) rgba(r, g, b, a)
+//	rgb(r g b) rgb(r g b / a)
+//	hsl(h, s, l) hsla(h, s, l, a)
+//	hsl(h s l) hsl(h s l / a)
+//	named colors, such as "rebeccapurple", and "transparent"
+//The components of rgb may be numbers in [0, 255] or percentages.
+//Hues may be given in deg, rad, grad, or turn, and default to degrees.
+//Alpha may be a number in [0, 1] or a percentage.
+//Out of range values are clamped.
+func ParseColor(s string) (AlphaColor, error) {
+	c, ok := parseColor(strings.ToLower(strings.TrimSpace(s)))
+	if !ok {
+		return AlphaColor{}, fmt.Errorf("invalid CSS color %q", s)
+	}
+	return c.Canon(), nil
+}
+
+func parseColor(s string) (AlphaColor, bool) {
+	if s == "transparent" {
+		return Transparent, true
+	}
+	if v, ok := cssColors[s]; ok {
+		return hexColor(v), true
+	}
+	if strings.HasPrefix(s, "#") {
+		return parseHex(s[1:])
+	}
+
+	i := strings.IndexByte(s, '(')
+	if i < 0 || !strings.HasSuffix(s, ")") {
+		return AlphaColor{}, false
+	}
+	fn, args := strings.TrimSpace(s[:i]), s[i+1:len(s)-1]
+	var parts []string
+	if strings.Contains(args, ",") {
+		parts = strings.Split(args, ",")
+	} else {
+		args = strings.Replace(args, "/", " / ", 1)
+		parts = strings.Fields(args)
+		if n := len(parts); n == 5 && parts[3] == "/" {
+			parts = append(parts[:3], parts[4])
+		} else if n != 3 {
+			return AlphaColor{}, false
+		}
+	}
+	if len(parts) != 3 && len(parts) != 4 {
+		return AlphaColor{}, false
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	a := 1.
+	if len(parts) == 4 {
+		var ok bool
+		if a, ok = parseNumPct(parts[3], 1); !ok {
+			return AlphaColor{}, false
+		}
+	}
+
+	switch fn {
+	case "rgb", "rgba":
+		var c [3]float64
+		for i := range c {
+			var ok bool
+			if c[i], ok = parseNumPct(parts[i], 255); !ok {
+				return AlphaColor{}, false
+			}
+		}
+		return AlphaColor{c[0], c[1], c[2], a}, true
+	case "hsl", "hsla":
+		h, ok := parseHue(parts[0])
+		if !ok {
+			return AlphaColor{}, false
+		}
+		sat, ok := parseNumPct(parts[1], 100)
+		if !ok {
+			return AlphaColor{}, false
+		}
+		l, ok := parseNumPct(parts[2], 100)
+		if !ok {
+			return AlphaColor{}, false
+		}
+		return HSL{h, clamp01(sat), clamp01(l)}.Color().Alpha(a), true
+	}
+	return AlphaColor{}, false
+}
+
+//hexColor returns the opaque color 0xrrggbb.
+func hexColor(v uint32) AlphaColor {
+	return AlphaColor{
+		float64(v>>16&0xff) / 0xff,
+		float64(v>>8&0xff) / 0xff,
+		float64(v&0xff) / 0xff,
+		1,
+	}
+}
+
+func parseHex(s string) (AlphaColor, bool) {
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return AlphaColor{}, false
+	}
+	var bits, n uint
+	switch len(s) {
+	case 3, 4:
+		bits, n = 4, uint(len(s))
+	case 6, 8:
+		bits, n = 8, uint(len(s)/2)
+	default:
+		return AlphaColor{}, false
+	}
+	max := float64(uint64(1)<<bits - 1)
+	var c [4]float64
+	c[3] = 1
+	for i := uint(0); i < n; i++ {
+		c[i] = float64(v>>(bits*(n-1-i))&(1<<bits-1)) / max
+	}
+	return AlphaColor{c[0], c[1], c[2], c[3]}, true
+}
+
+//parseNumPct parses s as a percentage or a number with maximum value max,
+//returning a value in [0, 1].
+func parseNumPct(s string, max float64) (float64, bool) {
+	if strings.HasSuffix(s, "%") {
+		max = 100
+		s = s[:len(s)-1]
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f / max, true
+}
+
+//parseHue parses s as a CSS angle and returns it in degrees.
+func parseHue(s string) (float64, bool) {
+	scale := 1.
+	for _, u := range []struct {
+		unit  string
+		scale float64
+	}{
+		{"deg", 1},
+		{"grad", 360. / 400},
+		{"rad", 180 / math.Pi},
+		{"turn", 360},
+	} {
+		if strings.HasSuffix(s, u.unit) {
+			s, scale = s[:len(s)-len(u.unit)], u.scale
+			break
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return deg(f * scale), true
+}
+
+//Perceptual gradients
+
+//Color spaces for interpolating the color stops of a gradient
+//with NewLinearGradientIn and NewRadialGradientIn.
+const (
+	//SpaceLinearRGB interpolates in linear-light sRGB,
+	//which blends colors like light does.
+	SpaceLinearRGB ColorSpace = iota
+	//SpaceLab interpolates in CIE L*a*b*,
+	//which changes perceived color at a constant rate.
+	SpaceLab
+	//SpaceLCh interpolates in CIE LCh, which changes perceived color
+	//at a constant rate and interpolates hues around the color wheel,
+	//avoiding desaturated colors between distant hues.
+	SpaceLCh
+	//SpaceHSL interpolates in HSL, around the color wheel.
+	SpaceHSL
+)
+
+//ColorSpace is a color space that gradients may be interpolated in.
+type ColorSpace int
+
+func (c ColorSpace) String() string {
+	switch c {
+	case SpaceLinearRGB:
+		return "SpaceLinearRGB"
+	case SpaceLab:
+		return "SpaceLab"
+	case SpaceLCh:
+		return "SpaceLCh"
+	case SpaceHSL:
+		return "SpaceHSL"
+	}
+	return "unknown color space"
+}
+
+//lerpHue interpolates the angles a and b, in degrees, along the shortest arc.
+func lerpHue(a, b, t float64) float64 {
+	return deg(a + math.Remainder(b-a, 360)*t)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+//interpolate returns the color t of the way from a to b in the space c.
+func (c ColorSpace) interpolate(a, b Color, t float64) Color {
+	switch c {
+	case SpaceLab:
+		p, q := a.Lab(), b.Lab()
+		return Lab{lerp(p.L, q.L, t), lerp(p.A, q.A, t), lerp(p.B, q.B, t)}.Color()
+	case SpaceLCh:
+		p, q := a.LCh(), b.LCh()
+		//gray has no hue, so borrow the other color's
+		if p.C < 1e-6 {
+			p.H = q.H
+		}
+		if q.C < 1e-6 {
+			q.H = p.H
+		}
+		return LCh{lerp(p.L, q.L, t), lerp(p.C, q.C, t), lerpHue(p.H, q.H, t)}.Color()
+	case SpaceHSL:
+		p, q := a.HSL(), b.HSL()
+		if p.S == 0 {
+			p.H = q.H
+		}
+		if q.S == 0 {
+			q.H = p.H
+		}
+		return HSL{lerpHue(p.H, q.H, t), lerp(p.S, q.S, t), lerp(p.L, q.L, t)}.Color()
+	}
+	p, q := a.Linear(), b.Linear()
+	return LinearRGB{lerp(p.R, q.R, t), lerp(p.G, q.G, t), lerp(p.B, q.B, t)}.Color()
+}
+
+//perceptualSteps is the number of intervals each pair of adjacent
+//color stops is divided into by NewLinearGradientIn and NewRadialGradientIn.
+const perceptualSteps = 16
+
+//NewLinearGradientIn creates a linear gradient, like NewLinearGradient,
+//whose colors are interpolated in space instead of in sRGB.
+//
+//libcairo only interpolates in sRGB,
+//so the gradient approximates interpolation in space by
+//inserting additional color stops between each adjacent pair of colorStops.
+//Alpha is interpolated linearly.
+//The ColorStops method of the returned gradient reports all the stops,
+//including those inserted.
+//
+//For example,
+//	NewLinearGradientIn(SpaceLCh, start, end,
+//		ColorStop{0, Blue},
+//		ColorStop{1, Red},
+//	)
+//creates a gradient from blue to red through purple,
+//without the muddy midpoint of sRGB interpolation.
+func NewLinearGradientIn(space ColorSpace, start, end Point, colorStops ...ColorStop) LinearGradient {
+	return NewLinearGradient(start, end, space.stops(perceptualSteps, colorStops)...)
+}
+
+//NewRadialGradientIn creates a radial gradient, like NewRadialGradient,
+//whose colors are interpolated in space instead of in sRGB.
+//
+//See NewLinearGradientIn for how the interpolation is approximated.
+func NewRadialGradientIn(space ColorSpace, start, end Circle, colorStops ...ColorStop) RadialGradient {
+	return NewRadialGradient(start, end, space.stops(perceptualSteps, colorStops)...)
+}
+
+//stops returns colorStops, sorted by offset, with steps-1 additional stops
+//inserted between each adjacent pair, so that when the gradient
+//is rendered, by interpolating in sRGB, it approximates
+//interpolation in c.
+func (c ColorSpace) stops(steps int, colorStops []ColorStop) []ColorStop {
+	cs := make([]ColorStop, len(colorStops))
+	copy(cs, colorStops)
+	for i := range cs {
+		cs[i].Offset = clamp01(cs[i].Offset)
+	}
+	sort.SliceStable(cs, func(i, j int) bool {
+		return cs[i].Offset < cs[j].Offset
+	})
+
+	var out []ColorStop
+	for i, s := range cs {
+		if i > 0 && cs[i-1].Offset < s.Offset {
+			p, q := cs[i-1], s
+			a, b := colorToAlpha(p.Color), colorToAlpha(q.Color)
+			for j := 1; j < steps; j++ {
+				t := float64(j) / float64(steps)
+				col := c.interpolate(a.Color(), b.Color(), t).Canon()
+				out = append(out, ColorStop{
+					Offset: lerp(p.Offset, q.Offset, t),
+					Color:  col.Alpha(lerp(a.A, b.A, t)),
+				})
+			}
+		}
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package cairo
+
+import (
+	"math"
+	"testing"
+)
+
+func colorNear(a, b AlphaColor, ε float64) bool {
+	return math.Abs(a.R-b.R) <= ε && math.Abs(a.G-b.G) <= ε &&
+		math.Abs(a.B-b.B) <= ε && math.Abs(a.A-b.A) <= ε
+}
+
+func TestColorSpaceRoundTrip(t *testing.T) {
+	for _, c := range []Color{
+		Black, White, Red, Green, Blue,
+		{.2, .4, .6}, {.9, .1, .5}, {.5, .5, .5}, {1, 1, 0},
+	} {
+		for name, got := range map[string]Color{
+			"HSL":    c.HSL().Color(),
+			"HSV":    c.HSV().Color(),
+			"linear": c.Linear().Color(),
+			"Lab":    c.Lab().Color(),
+			"LCh":    c.LCh().Color(),
+		} {
+			if !colorNear(got.Alpha(1), c.Alpha(1), 1e-5) {
+				t.Errorf("%s: %v round trips to %v", name, c, got)
+			}
+		}
+	}
+
+	if got, want := (Color{0, 0, 1}).HSL(), (HSL{240, 1, .5}); got != want {
+		t.Errorf("HSL of blue: got %v, want %v", got, want)
+	}
+	if got := White.Lab(); math.Abs(got.L-100) > 1e-3 || math.Abs(got.A) > 1e-3 || math.Abs(got.B) > 1e-3 {
+		t.Errorf("Lab of white: got %v", got)
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want AlphaColor
+	}{
+		{"#f00", AlphaColor{1, 0, 0, 1}},
+		{"#0f08", AlphaColor{0, 1, 0, 0x88 / 255.}},
+		{"#0000FF", AlphaColor{0, 0, 1, 1}},
+		{"#ffffff80", AlphaColor{1, 1, 1, 0x80 / 255.}},
+		{"rgb(255, 0, 0)", AlphaColor{1, 0, 0, 1}},
+		{"rgba(0, 255, 0, .5)", AlphaColor{0, 1, 0, .5}},
+		{"rgb(0% 100% 0% / 25%)", AlphaColor{0, 1, 0, .25}},
+		{"RGB(300, -1, 0)", AlphaColor{1, 0, 0, 1}},
+		{"hsl(120, 100%, 50%)", AlphaColor{0, 1, 0, 1}},
+		{"hsla(240deg, 100%, 50%, 0.5)", AlphaColor{0, 0, 1, .5}},
+		{"hsl(.5turn 100% 50%)", AlphaColor{0, 1, 1, 1}},
+		{"RebeccaPurple", AlphaColor{0x66 / 255., 0x33 / 255., 0x99 / 255., 1}},
+		{"transparent", Transparent},
+	} {
+		got, err := ParseColor(tc.in)
+		if err != nil {
+			t.Errorf("%q: %s", tc.in, err)
+			continue
+		}
+		if !colorNear(got, tc.want, 1e-9) {
+			t.Errorf("%q: got %v, want %v", tc.in, got, tc.want)
+		}
+	}
+
+	for _, in := range []string{"", "#ff", "#gggggg", "rgb(1, 2)", "rgb(1 2 3 4)", "nope", "hsl(x, 1%, 1%)"} {
+		if _, err := ParseColor(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestColorSpaceStops(t *testing.T) {
+	cs := SpaceLCh.stops(4, []ColorStop{
+		{1, Red},
+		{0, Blue.Alpha(0)},
+	})
+	if len(cs) != 5 {
+		t.Fatalf("got %d stops, want 5", len(cs))
+	}
+	for i, s := range cs {
+		if want := float64(i) / 4; s.Offset != want {
+			t.Errorf("stop %d: offset %g, want %g", i, s.Offset, want)
+		}
+	}
+	if got := colorToAlpha(cs[2].Color); math.Abs(got.A-.5) > 1e-9 {
+		t.Errorf("alpha not interpolated: %v", got)
+	}
+
+	//sharp transitions get no extra stops
+	cs = SpaceLab.stops(4, []ColorStop{{.5, Red}, {.5, Blue}})
+	if len(cs) != 2 {
+		t.Errorf("got %d stops, want 2", len(cs))
+	}
+}
+
+func TestNewLinearGradientIn(t *testing.T) {
+	g := NewLinearGradientIn(SpaceLab, ZP, Pt(10, 0),
+		ColorStop{0, Blue},
+		ColorStop{1, Red},
+	)
+	defer g.Close()
+	cs := g.ColorStops()
+	if len(cs) != perceptualSteps+1 {
+		t.Fatalf("got %d stops, want %d", len(cs), perceptualSteps+1)
+	}
+	want := SpaceLab.interpolate(Blue, Red, .5)
+	if got := colorToAlpha(cs[perceptualSteps/2].Color); !colorNear(got, want.Alpha(1), 1e-5) {
+		t.Errorf("midpoint: got %v, want %v", got, want)
+	}
+}
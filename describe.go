@@ -0,0 +1,184 @@
+package cairo
+
+//#cgo pkg-config: cairo
+//#include <cairo/cairo.h>
+import "C"
+
+import (
+	"errors"
+)
+
+//PatternDescription is a pure Go description of a Pattern.
+//
+//A PatternDescription may be encoded as JSON,
+//with the exception of the Surface of a surface pattern.
+//
+//Only the fields relevant to Type are set by Describe
+//or used by NewPatternFromDescription.
+type PatternDescription struct {
+	//Type is the type of the pattern.
+	Type PatternType
+	//Matrix is the pattern's transformation matrix.
+	//
+	//The zero Matrix is not invertible, so it is never the matrix
+	//of a pattern.
+	//NewPatternFromDescription treats it as the identity,
+	//so that a description written by hand need not set Matrix.
+	Matrix Matrix
+	//Extend is the pattern's extend mode.
+	//Note that the zero value is ExtendNone, which is not the default
+	//for gradients.
+	Extend Extend
+	//Filter is the pattern's filter.
+	//Note that the zero value is FilterFast, which is not the default.
+	Filter Filter
+
+	//Color is the color of a solid pattern.
+	Color *AlphaColor `json:",omitempty"`
+	//Surface is the surface of a surface pattern.
+	//It is not encoded as JSON.
+	//
+	//The Surface set by Describe is a new reference to the surface
+	//of the pattern, which the caller must Close.
+	//NewPatternFromDescription does not take ownership of Surface:
+	//the pattern holds its own reference, so the caller must still Close
+	//the Surface when it is no longer needed.
+	Surface Surface `json:"-"`
+	//Line is the start and end point of a linear gradient.
+	Line []Point `json:",omitempty"`
+	//Circles is the start and end circle of a radial gradient.
+	Circles []Circle `json:",omitempty"`
+	//ColorStops are the color stops of a linear or radial gradient.
+	ColorStops []ColorStop `json:",omitempty"`
+	//Patches are the patches of a mesh.
+	Patches []*Patch `json:",omitempty"`
+}
+
+//Describer is implemented by patterns that can describe themselves.
+//
+//Every Pattern in this package is a Describer,
+//as is any pattern that embeds an XtensionPattern.
+type Describer interface {
+	Describe() (PatternDescription, error)
+}
+
+//Describe returns a description of the pattern.
+//
+//If the pattern is a surface pattern, the caller must Close
+//the Surface of the returned description.
+//
+//Originally cairo_pattern_get_type, cairo_pattern_get_matrix,
+//cairo_pattern_get_extend, cairo_pattern_get_filter,
+//cairo_pattern_get_rgba, cairo_pattern_get_surface,
+//cairo_pattern_get_linear_points, cairo_pattern_get_radial_circles,
+//cairo_pattern_get_color_stop_count, cairo_pattern_get_color_stop_rgba,
+//cairo_mesh_pattern_get_patch_count, cairo_mesh_pattern_get_path,
+//cairo_mesh_pattern_get_control_point,
+//and cairo_mesh_pattern_get_corner_color_rgba.
+func (p *XtensionPattern) Describe() (d PatternDescription, err error) {
+	if err = p.err("Pattern.Describe"); err != nil {
+		return
+	}
+	d = PatternDescription{
+		Type:   p.Type(),
+		Matrix: p.Matrix(),
+		Extend: p.Extend(),
+		Filter: p.Filter(),
+	}
+	switch d.Type {
+	case PatternTypeSolid:
+		var r, g, b, a C.double
+		C.cairo_pattern_get_rgba(p.p, &r, &g, &b, &a)
+		c := cColor(r, g, b, a)
+		d.Color = &c
+	case PatternTypeSurface:
+		var s *C.cairo_surface_t
+		C.cairo_pattern_get_surface(p.p, &s)
+		C.cairo_surface_reference(s) //returned surface does not up libcairo refcount
+		if d.Surface, err = XtensionRevivifySurface(s); err != nil {
+			return PatternDescription{}, err
+		}
+	case PatternTypeLinear:
+		var x0, y0, x1, y1 C.double
+		C.cairo_pattern_get_linear_points(p.p, &x0, &y0, &x1, &y1)
+		d.Line = []Point{cPt(x0, y0), cPt(x1, y1)}
+		d.ColorStops = patternGradient{p}.ColorStops()
+	case PatternTypeRadial:
+		var x0, y0, r0, x1, y1, r1 C.double
+		C.cairo_pattern_get_radial_circles(p.p, &x0, &y0, &r0, &x1, &y1, &r1)
+		d.Circles = []Circle{cCirc(x0, y0, r0), cCirc(x1, y1, r1)}
+		d.ColorStops = patternGradient{p}.ColorStops()
+	case PatternTypeMesh:
+		if d.Patches, err = (Mesh{p}).Patches(); err != nil {
+			return PatternDescription{}, err
+		}
+	}
+	return d, nil
+}
+
+//NewPatternFromDescription creates a new Pattern from a description.
+//
+//Raster source patterns cannot be created from a description.
+func NewPatternFromDescription(d PatternDescription) (Pattern, error) {
+	var p Pattern
+	var x *XtensionPattern
+	switch d.Type {
+	case PatternTypeSolid:
+		if d.Color == nil {
+			return nil, errors.New("solid pattern description has no Color")
+		}
+		s := NewSolidPattern(*d.Color)
+		p, x = s, s.XtensionPattern
+	case PatternTypeSurface:
+		if d.Surface == nil {
+			return nil, errors.New("surface pattern description has no Surface")
+		}
+		s, err := NewSurfacePattern(d.Surface)
+		if err != nil {
+			return nil, err
+		}
+		p, x = s, s.XtensionPattern
+	case PatternTypeLinear:
+		if len(d.Line) != 2 {
+			return nil, errors.New("linear gradient description must have 2 points in Line")
+		}
+		l := NewLinearGradient(d.Line[0], d.Line[1], d.ColorStops...)
+		p, x = l, l.XtensionPattern
+	case PatternTypeRadial:
+		if len(d.Circles) != 2 {
+			return nil, errors.New("radial gradient description must have 2 Circles")
+		}
+		r := NewRadialGradient(d.Circles[0], d.Circles[1], d.ColorStops...)
+		p, x = r, r.XtensionPattern
+	case PatternTypeMesh:
+		m, err := NewMesh(d.Patches...)
+		if err != nil {
+			return nil, err
+		}
+		p, x = m, m.XtensionPattern
+	case PatternTypeRasterSource:
+		return nil, errors.New("cannot create raster source pattern from description")
+	default:
+		return nil, errors.New("unknown pattern type in description")
+	}
+
+	m := d.Matrix
+	if m == (Matrix{}) {
+		m = NewIdentityMatrix()
+	}
+	x.SetMatrix(m)
+	x.SetExtend(d.Extend)
+	x.SetFilter(d.Filter)
+	if err := p.Err(); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+//copyPatternAttrs copies the matrix, extend, and filter of src to dst.
+func copyPatternAttrs(dst, src *XtensionPattern) {
+	dst.SetMatrix(src.Matrix())
+	dst.SetExtend(src.Extend())
+	dst.SetFilter(src.Filter())
+}
@@ -16,6 +16,8 @@ var cdevtogodev = map[deviceType]func(*C.cairo_device_t) (Device, error){}
 //
 //If no factory is registered a default XtensionDevice will be returned.
 func XtensionRegisterRawToDevice(d deviceType, f func(*C.cairo_device_t) (Device, error)) {
+	regmux.Lock()
+	defer regmux.Unlock()
 	cdevtogodev[d] = f
 }
 
@@ -71,10 +73,17 @@ type XtensionDevice struct {
 	d *C.cairo_device_t
 }
 
+func deviceConverter(t deviceType) (func(*C.cairo_device_t) (Device, error), bool) {
+	regmux.RLock()
+	defer regmux.RUnlock()
+	f, ok := cdevtogodev[t]
+	return f, ok
+}
+
 func newCairoDevice(d *C.cairo_device_t) (Device, error) {
 	t := deviceType(C.cairo_device_get_type(d))
 	_ = deviceGetID(d) //panics if created outside of cairo without being registered
-	f, ok := cdevtogodev[t]
+	f, ok := deviceConverter(t)
 	if !ok {
 		D := NewXtensionDevice(d)
 		return D, D.Err()
@@ -125,7 +134,7 @@ func (c *XtensionDevice) Equal(d Device) bool {
 //
 //Orignally cairo_device_acquire.
 func (c *XtensionDevice) Lock() (err error) {
-	return toerr(C.cairo_device_acquire(c.d))
+	return toerrIded(C.cairo_device_acquire(c.d), "Device.Lock", "device", c.d, c)
 }
 
 //Unlock releases the device previously acquired by Lock.
@@ -142,21 +151,27 @@ func (c *XtensionDevice) Close() error {
 	if c == nil || c.d == nil {
 		return nil
 	}
-	err := c.Err()
+	W := writerFor(c.id())
+	err := c.err("Device.Close")
 	C.cairo_device_destroy(c.d)
 	c.d = nil
 	runtime.SetFinalizer(c, nil)
-	return err
+	return writeErr(W, err)
 }
 
 //Err reports any error on this device.
 //
 //Originally cairo_device_status.
 func (c *XtensionDevice) Err() error {
+	return c.err("")
+}
+
+//err reports the current error state of c as reported by op.
+func (c *XtensionDevice) err(op string) error {
 	if c.d == nil {
 		return ErrInvalidLibcairoHandle
 	}
-	return toerrIded(C.cairo_device_status(c.d), c)
+	return toerrIded(C.cairo_device_status(c.d), op, "device", c.d, c)
 }
 
 //Type reports the type of this device.
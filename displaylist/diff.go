@@ -0,0 +1,127 @@
+package displaylist
+
+import (
+	"fmt"
+	"reflect"
+)
+
+//EditKind is the kind of difference an Edit describes.
+type EditKind int
+
+//Kinds of Edit.
+const (
+	//Added is an operation in b but not in a.
+	Added EditKind = iota
+	//Removed is an operation in a but not in b.
+	Removed
+	//Changed is an operation in a replaced by an operation of the same type,
+	//but with different arguments, in b.
+	Changed
+)
+
+func (k EditKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	}
+	return "unknown edit"
+}
+
+//Edit is a difference between two Lists.
+type Edit struct {
+	Kind EditKind
+	//A and B are the indices of the operation in a and b.
+	//A is -1 if Kind is Added and B is -1 if Kind is Removed.
+	A, B int
+	//Old and New are the operations at A and B.
+	//Old is nil if Kind is Added and New is nil if Kind is Removed.
+	Old, New Op
+}
+
+func (e Edit) String() string {
+	switch e.Kind {
+	case Added:
+		return fmt.Sprintf("+%d %s", e.B, opString(e.New))
+	case Removed:
+		return fmt.Sprintf("-%d %s", e.A, opString(e.Old))
+	}
+	return fmt.Sprintf("~%d→%d %s → %s", e.A, e.B, opString(e.Old), opString(e.New))
+}
+
+//Diff returns the operations that were added, removed, or changed to
+//turn a into b, in order.
+//
+//Operations are equal if they are reflect.DeepEqual.
+//Of the operations removed from a and added to b between two unchanged
+//operations, those of the same type in the same position are reported
+//as Changed.
+//
+//Diff returns no Edits if a and b are equal.
+func Diff(a, b *List) []Edit {
+	x, y := a.ops, b.ops
+
+	//lcs[i][j] is the length of the longest common subsequence
+	//of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			switch {
+			case reflect.DeepEqual(x[i], y[j]):
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var edits []Edit
+	var removed, added []int
+	flush := func() {
+		for k := 0; k < len(removed) || k < len(added); k++ {
+			switch {
+			case k >= len(added):
+				i := removed[k]
+				edits = append(edits, Edit{Kind: Removed, A: i, B: -1, Old: x[i]})
+			case k >= len(removed):
+				j := added[k]
+				edits = append(edits, Edit{Kind: Added, A: -1, B: j, New: y[j]})
+			case reflect.TypeOf(x[removed[k]]) == reflect.TypeOf(y[added[k]]):
+				i, j := removed[k], added[k]
+				edits = append(edits, Edit{Kind: Changed, A: i, B: j, Old: x[i], New: y[j]})
+			default:
+				i, j := removed[k], added[k]
+				edits = append(edits,
+					Edit{Kind: Removed, A: i, B: -1, Old: x[i]},
+					Edit{Kind: Added, A: -1, B: j, New: y[j]})
+			}
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && reflect.DeepEqual(x[i], y[j]):
+			flush()
+			i++
+			j++
+		case j == len(y) || (i < len(x) && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, i)
+			i++
+		default:
+			added = append(added, j)
+			j++
+		}
+	}
+	flush()
+	return edits
+}
@@ -0,0 +1,407 @@
+package displaylist
+
+import (
+	"image/color"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//Drawer is the methods of cairo.Context that a List records.
+//
+//Both a *List and the Drawer returned by Direct implement it,
+//so code that draws on a Drawer may either draw on a Context
+//or be recorded.
+//
+//The methods that return a *cairo.Context return a Drawer instead,
+//so calls may be chained as they are on a Context,
+//and SaveRestore calls f with a Drawer.
+//
+//The methods of cairo.Context that query the state of the Context,
+//such as CurrentPoint or TextExtents, are not included,
+//as a List cannot answer them.
+type Drawer interface {
+	//Err reports any error drawing so far.
+	Err() error
+
+	Save() Drawer
+	Restore() error
+	SaveRestore(f func(Drawer) error) error
+	PushGroup() Drawer
+	PushGroupWithContent(content cairo.Content) Drawer
+	PopGroupToSource() error
+	SetSourceColor(col color.Color) Drawer
+	SetSource(source cairo.Pattern) Drawer
+	SetSourceSurface(s cairo.Surface, originDisplacement cairo.Point) error
+	SetAntialiasMode(a cairo.Antialias) Drawer
+	SetDash(offset float64, dashes ...float64) error
+	SetFillRule(f cairo.FillRule) Drawer
+	SetLineCap(lc cairo.LineCap) Drawer
+	SetLineJoin(lj cairo.LineJoin) Drawer
+	SetLineWidth(width float64) Drawer
+	SetMiterLimit(ml float64) Drawer
+	SetOperator(op cairo.Operator) Drawer
+	SetTolerance(tolerance float64) Drawer
+	Clip() Drawer
+	ClipPreserve() Drawer
+	ResetClip() Drawer
+	Fill() Drawer
+	FillPreserve() Drawer
+	Mask(p cairo.Pattern) Drawer
+	MaskSurface(s cairo.Surface, offsetVector cairo.Point) Drawer
+	Paint() Drawer
+	PaintAlpha(alpha float64) Drawer
+	Stroke() Drawer
+	StrokePreserve() Drawer
+	CopyPage() Drawer
+	ShowPage() Drawer
+	AppendPath(path cairo.Path) error
+	NewPath() Drawer
+	NewSubPath() Drawer
+	ClosePath() Drawer
+	Arc(circle cairo.Circle, fromAngle float64, toAngle float64) Drawer
+	ArcNegative(circle cairo.Circle, fromAngle float64, toAngle float64) Drawer
+	Circle(circle cairo.Circle) Drawer
+	CurveTo(p1 cairo.Point, p2 cairo.Point, p3 cairo.Point) Drawer
+	LineTo(p cairo.Point) Drawer
+	MoveTo(p cairo.Point) Drawer
+	Rectangle(r cairo.Rectangle) Drawer
+	RelCurveTo(v1 cairo.Point, v2 cairo.Point, v3 cairo.Point) Drawer
+	RelLineTo(v cairo.Point) Drawer
+	RelMoveTo(v cairo.Point) Drawer
+	GlyphPath(glyphs []cairo.Glyph) Drawer
+	TextPath(s string) Drawer
+	SelectFont(family string, slant cairo.Slant, weight cairo.Weight) Drawer
+	SetFontSize(size float64) Drawer
+	SetFontMatrix(m cairo.Matrix) Drawer
+	SetFontOptions(opts *cairo.FontOptions) Drawer
+	SetFont(f cairo.Font) Drawer
+	SetScaledFont(sf *cairo.ScaledFont) Drawer
+	ShowText(s string) Drawer
+	ShowGlyphs(glyphs []cairo.Glyph) Drawer
+	ShowTextGlyphs(s string, glyphs []cairo.Glyph, clusters []cairo.TextCluster, flags cairo.TextClusterFlags) Drawer
+	Translate(v cairo.Point) Drawer
+	Scale(v cairo.Point) Drawer
+	Rotate(θ float64) Drawer
+	Transform(m cairo.Matrix) Drawer
+	SetMatrix(m cairo.Matrix) Drawer
+	ResetMatrix() Drawer
+}
+
+//Direct returns a Drawer that draws on c.
+func Direct(c *cairo.Context) Drawer {
+	return direct{c}
+}
+
+type direct struct {
+	c *cairo.Context
+}
+
+func (d direct) Err() error {
+	return d.c.Err()
+}
+
+func (d direct) SaveRestore(f func(Drawer) error) error {
+	return d.c.SaveRestore(func(*cairo.Context) error {
+		return f(d)
+	})
+}
+
+func (d direct) Save() Drawer {
+	d.c.Save()
+	return d
+}
+
+func (d direct) Restore() error {
+	return d.c.Restore()
+}
+
+func (d direct) PushGroup() Drawer {
+	d.c.PushGroup()
+	return d
+}
+
+func (d direct) PushGroupWithContent(content cairo.Content) Drawer {
+	d.c.PushGroupWithContent(content)
+	return d
+}
+
+func (d direct) PopGroupToSource() error {
+	return d.c.PopGroupToSource()
+}
+
+func (d direct) SetSourceColor(col color.Color) Drawer {
+	d.c.SetSourceColor(col)
+	return d
+}
+
+func (d direct) SetSource(source cairo.Pattern) Drawer {
+	d.c.SetSource(source)
+	return d
+}
+
+func (d direct) SetSourceSurface(s cairo.Surface, originDisplacement cairo.Point) error {
+	return d.c.SetSourceSurface(s, originDisplacement)
+}
+
+func (d direct) SetAntialiasMode(a cairo.Antialias) Drawer {
+	d.c.SetAntialiasMode(a)
+	return d
+}
+
+func (d direct) SetDash(offset float64, dashes ...float64) error {
+	return d.c.SetDash(offset, dashes...)
+}
+
+func (d direct) SetFillRule(f cairo.FillRule) Drawer {
+	d.c.SetFillRule(f)
+	return d
+}
+
+func (d direct) SetLineCap(lc cairo.LineCap) Drawer {
+	d.c.SetLineCap(lc)
+	return d
+}
+
+func (d direct) SetLineJoin(lj cairo.LineJoin) Drawer {
+	d.c.SetLineJoin(lj)
+	return d
+}
+
+func (d direct) SetLineWidth(width float64) Drawer {
+	d.c.SetLineWidth(width)
+	return d
+}
+
+func (d direct) SetMiterLimit(ml float64) Drawer {
+	d.c.SetMiterLimit(ml)
+	return d
+}
+
+func (d direct) SetOperator(op cairo.Operator) Drawer {
+	d.c.SetOperator(op)
+	return d
+}
+
+func (d direct) SetTolerance(tolerance float64) Drawer {
+	d.c.SetTolerance(tolerance)
+	return d
+}
+
+func (d direct) Clip() Drawer {
+	d.c.Clip()
+	return d
+}
+
+func (d direct) ClipPreserve() Drawer {
+	d.c.ClipPreserve()
+	return d
+}
+
+func (d direct) ResetClip() Drawer {
+	d.c.ResetClip()
+	return d
+}
+
+func (d direct) Fill() Drawer {
+	d.c.Fill()
+	return d
+}
+
+func (d direct) FillPreserve() Drawer {
+	d.c.FillPreserve()
+	return d
+}
+
+func (d direct) Mask(p cairo.Pattern) Drawer {
+	d.c.Mask(p)
+	return d
+}
+
+func (d direct) MaskSurface(s cairo.Surface, offsetVector cairo.Point) Drawer {
+	d.c.MaskSurface(s, offsetVector)
+	return d
+}
+
+func (d direct) Paint() Drawer {
+	d.c.Paint()
+	return d
+}
+
+func (d direct) PaintAlpha(alpha float64) Drawer {
+	d.c.PaintAlpha(alpha)
+	return d
+}
+
+func (d direct) Stroke() Drawer {
+	d.c.Stroke()
+	return d
+}
+
+func (d direct) StrokePreserve() Drawer {
+	d.c.StrokePreserve()
+	return d
+}
+
+func (d direct) CopyPage() Drawer {
+	d.c.CopyPage()
+	return d
+}
+
+func (d direct) ShowPage() Drawer {
+	d.c.ShowPage()
+	return d
+}
+
+func (d direct) AppendPath(path cairo.Path) error {
+	return d.c.AppendPath(path)
+}
+
+func (d direct) NewPath() Drawer {
+	d.c.NewPath()
+	return d
+}
+
+func (d direct) NewSubPath() Drawer {
+	d.c.NewSubPath()
+	return d
+}
+
+func (d direct) ClosePath() Drawer {
+	d.c.ClosePath()
+	return d
+}
+
+func (d direct) Arc(circle cairo.Circle, fromAngle float64, toAngle float64) Drawer {
+	d.c.Arc(circle, fromAngle, toAngle)
+	return d
+}
+
+func (d direct) ArcNegative(circle cairo.Circle, fromAngle float64, toAngle float64) Drawer {
+	d.c.ArcNegative(circle, fromAngle, toAngle)
+	return d
+}
+
+func (d direct) Circle(circle cairo.Circle) Drawer {
+	d.c.Circle(circle)
+	return d
+}
+
+func (d direct) CurveTo(p1 cairo.Point, p2 cairo.Point, p3 cairo.Point) Drawer {
+	d.c.CurveTo(p1, p2, p3)
+	return d
+}
+
+func (d direct) LineTo(p cairo.Point) Drawer {
+	d.c.LineTo(p)
+	return d
+}
+
+func (d direct) MoveTo(p cairo.Point) Drawer {
+	d.c.MoveTo(p)
+	return d
+}
+
+func (d direct) Rectangle(r cairo.Rectangle) Drawer {
+	d.c.Rectangle(r)
+	return d
+}
+
+func (d direct) RelCurveTo(v1 cairo.Point, v2 cairo.Point, v3 cairo.Point) Drawer {
+	d.c.RelCurveTo(v1, v2, v3)
+	return d
+}
+
+func (d direct) RelLineTo(v cairo.Point) Drawer {
+	d.c.RelLineTo(v)
+	return d
+}
+
+func (d direct) RelMoveTo(v cairo.Point) Drawer {
+	d.c.RelMoveTo(v)
+	return d
+}
+
+func (d direct) GlyphPath(glyphs []cairo.Glyph) Drawer {
+	d.c.GlyphPath(glyphs)
+	return d
+}
+
+func (d direct) TextPath(s string) Drawer {
+	d.c.TextPath(s)
+	return d
+}
+
+func (d direct) SelectFont(family string, slant cairo.Slant, weight cairo.Weight) Drawer {
+	d.c.SelectFont(family, slant, weight)
+	return d
+}
+
+func (d direct) SetFontSize(size float64) Drawer {
+	d.c.SetFontSize(size)
+	return d
+}
+
+func (d direct) SetFontMatrix(m cairo.Matrix) Drawer {
+	d.c.SetFontMatrix(m)
+	return d
+}
+
+func (d direct) SetFontOptions(opts *cairo.FontOptions) Drawer {
+	d.c.SetFontOptions(opts)
+	return d
+}
+
+func (d direct) SetFont(f cairo.Font) Drawer {
+	d.c.SetFont(f)
+	return d
+}
+
+func (d direct) SetScaledFont(sf *cairo.ScaledFont) Drawer {
+	d.c.SetScaledFont(sf)
+	return d
+}
+
+func (d direct) ShowText(s string) Drawer {
+	d.c.ShowText(s)
+	return d
+}
+
+func (d direct) ShowGlyphs(glyphs []cairo.Glyph) Drawer {
+	d.c.ShowGlyphs(glyphs)
+	return d
+}
+
+func (d direct) ShowTextGlyphs(s string, glyphs []cairo.Glyph, clusters []cairo.TextCluster, flags cairo.TextClusterFlags) Drawer {
+	d.c.ShowTextGlyphs(s, glyphs, clusters, flags)
+	return d
+}
+
+func (d direct) Translate(v cairo.Point) Drawer {
+	d.c.Translate(v)
+	return d
+}
+
+func (d direct) Scale(v cairo.Point) Drawer {
+	d.c.Scale(v)
+	return d
+}
+
+func (d direct) Rotate(θ float64) Drawer {
+	d.c.Rotate(θ)
+	return d
+}
+
+func (d direct) Transform(m cairo.Matrix) Drawer {
+	d.c.Transform(m)
+	return d
+}
+
+func (d direct) SetMatrix(m cairo.Matrix) Drawer {
+	d.c.SetMatrix(m)
+	return d
+}
+
+func (d direct) ResetMatrix() Drawer {
+	d.c.ResetMatrix()
+	return d
+}
@@ -0,0 +1,141 @@
+//Package displaylist records drawing operations as Go values.
+//
+//A List implements Drawer, the methods of cairo.Context that draw or change
+//the drawing state, but rather than drawing it records each call as an Op,
+//such as MoveTo or Fill, that may be inspected, filtered, or compared,
+//and then replayed on any Context.
+//
+//Unlike cairo/recording, no libcairo object is created until the List is
+//replayed, so that, for example, a test may assert on the exact operations
+//a widget draws without rasterizing them.
+//Given a widget that draws on a Drawer,
+//	func (b *Box) Draw(d displaylist.Drawer) error {
+//		d.Rectangle(b.Bounds).Fill()
+//		return d.Err()
+//	}
+//it is drawn on a Context c with
+//	b.Draw(displaylist.Direct(c))
+//and tested with
+//	var l displaylist.List
+//	b.Draw(&l)
+//	want := displaylist.New(
+//		displaylist.Rectangle{Rectangle: cairo.RectWH(0, 0, 10, 10)},
+//		displaylist.Fill{},
+//	)
+//	if edits := displaylist.Diff(want, &l); len(edits) > 0 {
+//		t.Errorf("got\n%vdiffs from want: %v", &l, edits)
+//	}
+//
+//Ops that hold a libcairo object, such as SetSource, hold a reference to
+//it, not a copy, so the object must not be closed until the List is no
+//longer replayed.
+//
+//The methods of cairo.Context that query the state of the Context,
+//such as CurrentPoint or TextExtents, cannot be recorded.
+package displaylist
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//Op is a recorded drawing operation.
+type Op interface {
+	//Apply performs the operation on c.
+	//
+	//Errors are only returned by operations that return an error
+	//from the Context method they call.
+	//Otherwise, they are reported by c.Err.
+	Apply(c *cairo.Context) error
+}
+
+//List is a list of drawing operations.
+//
+//The zero value is an empty List ready to use.
+type List struct {
+	ops []Op
+}
+
+//New returns a List of ops.
+func New(ops ...Op) *List {
+	return new(List).Append(ops...)
+}
+
+//Append records ops.
+func (l *List) Append(ops ...Op) *List {
+	l.ops = append(l.ops, ops...)
+	return l
+}
+
+//Ops returns a copy of the operations recorded on l.
+func (l *List) Ops() []Op {
+	return append([]Op(nil), l.ops...)
+}
+
+//Len reports the number of operations recorded on l.
+func (l *List) Len() int {
+	return len(l.ops)
+}
+
+//Reset removes all operations from l.
+func (l *List) Reset() {
+	l.ops = nil
+}
+
+//Replay applies each operation recorded on l to c, in order.
+//
+//It stops and returns the first error returned by an operation,
+//or the error of c after all operations have been applied.
+func (l *List) Replay(c *cairo.Context) error {
+	for _, op := range l.ops {
+		if err := op.Apply(c); err != nil {
+			return err
+		}
+	}
+	return c.Err()
+}
+
+//Filter returns a new List of the operations in l for which keep
+//returns true.
+func (l *List) Filter(keep func(Op) bool) *List {
+	f := &List{}
+	for _, op := range l.ops {
+		if keep(op) {
+			f.ops = append(f.ops, op)
+		}
+	}
+	return f
+}
+
+//Transformed returns a new List that applies m, as by Context.Transform,
+//to the operations of l.
+//
+//The operations are wrapped in a Save and Restore, so the transformation
+//does not outlive them.
+//A SetMatrix or ResetMatrix in l replaces the transformation matrix,
+//so it replaces m as well.
+func (l *List) Transformed(m cairo.Matrix) *List {
+	t := &List{ops: make([]Op, 0, len(l.ops)+3)}
+	t.ops = append(t.ops, Save{}, Transform{Matrix: m})
+	t.ops = append(t.ops, l.ops...)
+	t.ops = append(t.ops, Restore{})
+	return t
+}
+
+//String returns the operations of l, one per line, in a form suited
+//to comparing Lists in tests.
+func (l *List) String() string {
+	var buf bytes.Buffer
+	for _, op := range l.ops {
+		buf.WriteString(opString(op))
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+func opString(op Op) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T%+v", op, op), "displaylist.")
+}
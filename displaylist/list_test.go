@@ -0,0 +1,173 @@
+package displaylist
+
+import (
+	"errors"
+	"image"
+	"reflect"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+func square(d Drawer) error {
+	d.Save().SetSourceColor(cairo.Red)
+	if err := d.SetDash(0, 2, 1); err != nil {
+		return err
+	}
+	d.Rectangle(cairo.RectWH(0, 0, 10, 10)).Fill()
+	if err := d.Restore(); err != nil {
+		return err
+	}
+	return d.Err()
+}
+
+func TestRecord(t *testing.T) {
+	var l List
+	square(&l)
+	want := []Op{
+		Save{},
+		SetSourceColor{Color: cairo.Red},
+		SetDash{Offset: 0, Dashes: []float64{2, 1}},
+		Rectangle{Rectangle: cairo.RectWH(0, 0, 10, 10)},
+		Fill{},
+		Restore{},
+	}
+	if got := l.Ops(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%vwant\n%v", &l, New(want...))
+	}
+	if l.Len() != len(want) {
+		t.Errorf("Len: got %d, want %d", l.Len(), len(want))
+	}
+}
+
+func TestRecordCopies(t *testing.T) {
+	var l List
+	dashes := []float64{1, 2}
+	l.SetDash(0, dashes...)
+	dashes[0] = 100
+	if got := l.Ops()[0].(SetDash).Dashes[0]; got != 1 {
+		t.Errorf("recorded dashes changed to %g", got)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	var l List
+	square(&l)
+	state := l.Filter(func(op Op) bool {
+		switch op.(type) {
+		case Save, Restore, SetSourceColor, SetDash:
+			return true
+		}
+		return false
+	})
+	if state.Len() != 4 {
+		t.Errorf("got\n%v", state)
+	}
+	if l.Len() != 6 {
+		t.Error("Filter modified the original List")
+	}
+}
+
+func TestTransformed(t *testing.T) {
+	l := New(MoveTo{P: cairo.Pt(1, 1)}, LineTo{P: cairo.Pt(2, 2)}, Stroke{})
+	m := cairo.NewTranslateMatrix(cairo.Pt(5, 5))
+	got := l.Transformed(m).Ops()
+	want := append([]Op{Save{}, Transform{Matrix: m}}, l.Ops()...)
+	want = append(want, Restore{})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%vwant\n%v", New(got...), New(want...))
+	}
+}
+
+func TestString(t *testing.T) {
+	l := New(NewPath{}, MoveTo{P: cairo.Pt(1, 2)}, ShowText{Text: "hi"})
+	want := "NewPath{}\nMoveTo{P:" + cairo.Pt(1, 2).String() + "}\nShowText{Text:hi}\n"
+	if got := l.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSaveRestore(t *testing.T) {
+	var l List
+	err := l.SaveRestore(func(d Drawer) error {
+		d.Paint()
+		return errors.New("stop")
+	})
+	if err == nil || err.Error() != "stop" {
+		t.Errorf("got error %v, want stop", err)
+	}
+	want := []Op{Save{}, Paint{}, Restore{}}
+	if got := l.Ops(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%vwant\n%v", &l, New(want...))
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := New(
+		Save{},
+		SetSourceColor{Color: cairo.Red},
+		MoveTo{P: cairo.Pt(0, 0)},
+		LineTo{P: cairo.Pt(1, 1)},
+		Stroke{},
+		Restore{},
+	)
+	b := New(
+		Save{},
+		SetLineWidth{Width: 2},
+		SetSourceColor{Color: cairo.Red},
+		MoveTo{P: cairo.Pt(0, 0)},
+		LineTo{P: cairo.Pt(2, 2)},
+		Restore{},
+	)
+	want := []Edit{
+		{Kind: Added, A: -1, B: 1, New: SetLineWidth{Width: 2}},
+		{Kind: Changed, A: 3, B: 4, Old: LineTo{P: cairo.Pt(1, 1)}, New: LineTo{P: cairo.Pt(2, 2)}},
+		{Kind: Removed, A: 4, B: -1, Old: Stroke{}},
+	}
+	if got := Diff(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := Diff(a, a); len(got) != 0 {
+		t.Errorf("equal Lists: got %v", got)
+	}
+	if got := Diff(&List{}, New(Fill{})); len(got) != 1 || got[0].Kind != Added {
+		t.Errorf("from empty: got %v", got)
+	}
+}
+
+func TestDirect(t *testing.T) {
+	var l List
+	if err := square(&l); err != nil {
+		t.Fatal(err)
+	}
+
+	render := func(draw func(c *cairo.Context) error) *image.RGBA {
+		s, err := cairo.NewImageSurface(cairo.FormatARGB32, 12, 12)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		c, err := cairo.New(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := draw(c); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Close(); err != nil {
+			t.Fatal(err)
+		}
+		img, err := s.ToImage(cairo.ZP)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return img
+	}
+	direct := render(func(c *cairo.Context) error {
+		return square(Direct(c))
+	})
+	replayed := render(l.Replay)
+	if !reflect.DeepEqual(direct.Pix, replayed.Pix) {
+		t.Error("replayed List differs from drawing directly")
+	}
+}
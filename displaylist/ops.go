@@ -0,0 +1,653 @@
+package displaylist
+
+import (
+	"image/color"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//Save is a recorded call of Context.Save.
+type Save struct{}
+
+//Apply calls Context.Save on c.
+func (Save) Apply(c *cairo.Context) error {
+	c.Save()
+	return nil
+}
+
+//Restore is a recorded call of Context.Restore.
+type Restore struct{}
+
+//Apply calls Context.Restore on c.
+func (Restore) Apply(c *cairo.Context) error {
+	return c.Restore()
+}
+
+//PushGroup is a recorded call of Context.PushGroup.
+type PushGroup struct{}
+
+//Apply calls Context.PushGroup on c.
+func (PushGroup) Apply(c *cairo.Context) error {
+	c.PushGroup()
+	return nil
+}
+
+//PushGroupWithContent is a recorded call of Context.PushGroupWithContent.
+type PushGroupWithContent struct {
+	Content cairo.Content
+}
+
+//Apply calls Context.PushGroupWithContent on c.
+func (o PushGroupWithContent) Apply(c *cairo.Context) error {
+	c.PushGroupWithContent(o.Content)
+	return nil
+}
+
+//PopGroupToSource is a recorded call of Context.PopGroupToSource.
+type PopGroupToSource struct{}
+
+//Apply calls Context.PopGroupToSource on c.
+func (PopGroupToSource) Apply(c *cairo.Context) error {
+	return c.PopGroupToSource()
+}
+
+//SetSourceColor is a recorded call of Context.SetSourceColor.
+type SetSourceColor struct {
+	Color color.Color
+}
+
+//Apply calls Context.SetSourceColor on c.
+func (o SetSourceColor) Apply(c *cairo.Context) error {
+	c.SetSourceColor(o.Color)
+	return nil
+}
+
+//SetSource is a recorded call of Context.SetSource.
+type SetSource struct {
+	Pattern cairo.Pattern
+}
+
+//Apply calls Context.SetSource on c.
+func (o SetSource) Apply(c *cairo.Context) error {
+	c.SetSource(o.Pattern)
+	return nil
+}
+
+//SetSourceSurface is a recorded call of Context.SetSourceSurface.
+type SetSourceSurface struct {
+	Surface cairo.Surface
+	Origin  cairo.Point
+}
+
+//Apply calls Context.SetSourceSurface on c.
+func (o SetSourceSurface) Apply(c *cairo.Context) error {
+	return c.SetSourceSurface(o.Surface, o.Origin)
+}
+
+//SetAntialiasMode is a recorded call of Context.SetAntialiasMode.
+type SetAntialiasMode struct {
+	Antialias cairo.Antialias
+}
+
+//Apply calls Context.SetAntialiasMode on c.
+func (o SetAntialiasMode) Apply(c *cairo.Context) error {
+	c.SetAntialiasMode(o.Antialias)
+	return nil
+}
+
+//SetDash is a recorded call of Context.SetDash.
+type SetDash struct {
+	Offset float64
+	Dashes []float64
+}
+
+//Apply calls Context.SetDash on c.
+func (o SetDash) Apply(c *cairo.Context) error {
+	return c.SetDash(o.Offset, o.Dashes...)
+}
+
+//SetFillRule is a recorded call of Context.SetFillRule.
+type SetFillRule struct {
+	FillRule cairo.FillRule
+}
+
+//Apply calls Context.SetFillRule on c.
+func (o SetFillRule) Apply(c *cairo.Context) error {
+	c.SetFillRule(o.FillRule)
+	return nil
+}
+
+//SetLineCap is a recorded call of Context.SetLineCap.
+type SetLineCap struct {
+	LineCap cairo.LineCap
+}
+
+//Apply calls Context.SetLineCap on c.
+func (o SetLineCap) Apply(c *cairo.Context) error {
+	c.SetLineCap(o.LineCap)
+	return nil
+}
+
+//SetLineJoin is a recorded call of Context.SetLineJoin.
+type SetLineJoin struct {
+	LineJoin cairo.LineJoin
+}
+
+//Apply calls Context.SetLineJoin on c.
+func (o SetLineJoin) Apply(c *cairo.Context) error {
+	c.SetLineJoin(o.LineJoin)
+	return nil
+}
+
+//SetLineWidth is a recorded call of Context.SetLineWidth.
+type SetLineWidth struct {
+	Width float64
+}
+
+//Apply calls Context.SetLineWidth on c.
+func (o SetLineWidth) Apply(c *cairo.Context) error {
+	c.SetLineWidth(o.Width)
+	return nil
+}
+
+//SetMiterLimit is a recorded call of Context.SetMiterLimit.
+type SetMiterLimit struct {
+	Limit float64
+}
+
+//Apply calls Context.SetMiterLimit on c.
+func (o SetMiterLimit) Apply(c *cairo.Context) error {
+	c.SetMiterLimit(o.Limit)
+	return nil
+}
+
+//SetOperator is a recorded call of Context.SetOperator.
+type SetOperator struct {
+	Operator cairo.Operator
+}
+
+//Apply calls Context.SetOperator on c.
+func (o SetOperator) Apply(c *cairo.Context) error {
+	c.SetOperator(o.Operator)
+	return nil
+}
+
+//SetTolerance is a recorded call of Context.SetTolerance.
+type SetTolerance struct {
+	Tolerance float64
+}
+
+//Apply calls Context.SetTolerance on c.
+func (o SetTolerance) Apply(c *cairo.Context) error {
+	c.SetTolerance(o.Tolerance)
+	return nil
+}
+
+//Clip is a recorded call of Context.Clip.
+type Clip struct{}
+
+//Apply calls Context.Clip on c.
+func (Clip) Apply(c *cairo.Context) error {
+	c.Clip()
+	return nil
+}
+
+//ClipPreserve is a recorded call of Context.ClipPreserve.
+type ClipPreserve struct{}
+
+//Apply calls Context.ClipPreserve on c.
+func (ClipPreserve) Apply(c *cairo.Context) error {
+	c.ClipPreserve()
+	return nil
+}
+
+//ResetClip is a recorded call of Context.ResetClip.
+type ResetClip struct{}
+
+//Apply calls Context.ResetClip on c.
+func (ResetClip) Apply(c *cairo.Context) error {
+	c.ResetClip()
+	return nil
+}
+
+//Fill is a recorded call of Context.Fill.
+type Fill struct{}
+
+//Apply calls Context.Fill on c.
+func (Fill) Apply(c *cairo.Context) error {
+	c.Fill()
+	return nil
+}
+
+//FillPreserve is a recorded call of Context.FillPreserve.
+type FillPreserve struct{}
+
+//Apply calls Context.FillPreserve on c.
+func (FillPreserve) Apply(c *cairo.Context) error {
+	c.FillPreserve()
+	return nil
+}
+
+//Mask is a recorded call of Context.Mask.
+type Mask struct {
+	Pattern cairo.Pattern
+}
+
+//Apply calls Context.Mask on c.
+func (o Mask) Apply(c *cairo.Context) error {
+	c.Mask(o.Pattern)
+	return nil
+}
+
+//MaskSurface is a recorded call of Context.MaskSurface.
+type MaskSurface struct {
+	Surface cairo.Surface
+	Offset  cairo.Point
+}
+
+//Apply calls Context.MaskSurface on c.
+func (o MaskSurface) Apply(c *cairo.Context) error {
+	c.MaskSurface(o.Surface, o.Offset)
+	return nil
+}
+
+//Paint is a recorded call of Context.Paint.
+type Paint struct{}
+
+//Apply calls Context.Paint on c.
+func (Paint) Apply(c *cairo.Context) error {
+	c.Paint()
+	return nil
+}
+
+//PaintAlpha is a recorded call of Context.PaintAlpha.
+type PaintAlpha struct {
+	Alpha float64
+}
+
+//Apply calls Context.PaintAlpha on c.
+func (o PaintAlpha) Apply(c *cairo.Context) error {
+	c.PaintAlpha(o.Alpha)
+	return nil
+}
+
+//Stroke is a recorded call of Context.Stroke.
+type Stroke struct{}
+
+//Apply calls Context.Stroke on c.
+func (Stroke) Apply(c *cairo.Context) error {
+	c.Stroke()
+	return nil
+}
+
+//StrokePreserve is a recorded call of Context.StrokePreserve.
+type StrokePreserve struct{}
+
+//Apply calls Context.StrokePreserve on c.
+func (StrokePreserve) Apply(c *cairo.Context) error {
+	c.StrokePreserve()
+	return nil
+}
+
+//CopyPage is a recorded call of Context.CopyPage.
+type CopyPage struct{}
+
+//Apply calls Context.CopyPage on c.
+func (CopyPage) Apply(c *cairo.Context) error {
+	c.CopyPage()
+	return nil
+}
+
+//ShowPage is a recorded call of Context.ShowPage.
+type ShowPage struct{}
+
+//Apply calls Context.ShowPage on c.
+func (ShowPage) Apply(c *cairo.Context) error {
+	c.ShowPage()
+	return nil
+}
+
+//AppendPath is a recorded call of Context.AppendPath.
+type AppendPath struct {
+	Path cairo.Path
+}
+
+//Apply calls Context.AppendPath on c.
+func (o AppendPath) Apply(c *cairo.Context) error {
+	return c.AppendPath(o.Path)
+}
+
+//NewPath is a recorded call of Context.NewPath.
+type NewPath struct{}
+
+//Apply calls Context.NewPath on c.
+func (NewPath) Apply(c *cairo.Context) error {
+	c.NewPath()
+	return nil
+}
+
+//NewSubPath is a recorded call of Context.NewSubPath.
+type NewSubPath struct{}
+
+//Apply calls Context.NewSubPath on c.
+func (NewSubPath) Apply(c *cairo.Context) error {
+	c.NewSubPath()
+	return nil
+}
+
+//ClosePath is a recorded call of Context.ClosePath.
+type ClosePath struct{}
+
+//Apply calls Context.ClosePath on c.
+func (ClosePath) Apply(c *cairo.Context) error {
+	c.ClosePath()
+	return nil
+}
+
+//Arc is a recorded call of Context.Arc.
+type Arc struct {
+	Circle cairo.Circle
+	From   float64
+	To     float64
+}
+
+//Apply calls Context.Arc on c.
+func (o Arc) Apply(c *cairo.Context) error {
+	c.Arc(o.Circle, o.From, o.To)
+	return nil
+}
+
+//ArcNegative is a recorded call of Context.ArcNegative.
+type ArcNegative struct {
+	Circle cairo.Circle
+	From   float64
+	To     float64
+}
+
+//Apply calls Context.ArcNegative on c.
+func (o ArcNegative) Apply(c *cairo.Context) error {
+	c.ArcNegative(o.Circle, o.From, o.To)
+	return nil
+}
+
+//Circle is a recorded call of Context.Circle.
+type Circle struct {
+	Circle cairo.Circle
+}
+
+//Apply calls Context.Circle on c.
+func (o Circle) Apply(c *cairo.Context) error {
+	c.Circle(o.Circle)
+	return nil
+}
+
+//CurveTo is a recorded call of Context.CurveTo.
+type CurveTo struct {
+	P1 cairo.Point
+	P2 cairo.Point
+	P3 cairo.Point
+}
+
+//Apply calls Context.CurveTo on c.
+func (o CurveTo) Apply(c *cairo.Context) error {
+	c.CurveTo(o.P1, o.P2, o.P3)
+	return nil
+}
+
+//LineTo is a recorded call of Context.LineTo.
+type LineTo struct {
+	P cairo.Point
+}
+
+//Apply calls Context.LineTo on c.
+func (o LineTo) Apply(c *cairo.Context) error {
+	c.LineTo(o.P)
+	return nil
+}
+
+//MoveTo is a recorded call of Context.MoveTo.
+type MoveTo struct {
+	P cairo.Point
+}
+
+//Apply calls Context.MoveTo on c.
+func (o MoveTo) Apply(c *cairo.Context) error {
+	c.MoveTo(o.P)
+	return nil
+}
+
+//Rectangle is a recorded call of Context.Rectangle.
+type Rectangle struct {
+	Rectangle cairo.Rectangle
+}
+
+//Apply calls Context.Rectangle on c.
+func (o Rectangle) Apply(c *cairo.Context) error {
+	c.Rectangle(o.Rectangle)
+	return nil
+}
+
+//RelCurveTo is a recorded call of Context.RelCurveTo.
+type RelCurveTo struct {
+	V1 cairo.Point
+	V2 cairo.Point
+	V3 cairo.Point
+}
+
+//Apply calls Context.RelCurveTo on c.
+func (o RelCurveTo) Apply(c *cairo.Context) error {
+	c.RelCurveTo(o.V1, o.V2, o.V3)
+	return nil
+}
+
+//RelLineTo is a recorded call of Context.RelLineTo.
+type RelLineTo struct {
+	V cairo.Point
+}
+
+//Apply calls Context.RelLineTo on c.
+func (o RelLineTo) Apply(c *cairo.Context) error {
+	c.RelLineTo(o.V)
+	return nil
+}
+
+//RelMoveTo is a recorded call of Context.RelMoveTo.
+type RelMoveTo struct {
+	V cairo.Point
+}
+
+//Apply calls Context.RelMoveTo on c.
+func (o RelMoveTo) Apply(c *cairo.Context) error {
+	c.RelMoveTo(o.V)
+	return nil
+}
+
+//GlyphPath is a recorded call of Context.GlyphPath.
+type GlyphPath struct {
+	Glyphs []cairo.Glyph
+}
+
+//Apply calls Context.GlyphPath on c.
+func (o GlyphPath) Apply(c *cairo.Context) error {
+	c.GlyphPath(o.Glyphs)
+	return nil
+}
+
+//TextPath is a recorded call of Context.TextPath.
+type TextPath struct {
+	Text string
+}
+
+//Apply calls Context.TextPath on c.
+func (o TextPath) Apply(c *cairo.Context) error {
+	c.TextPath(o.Text)
+	return nil
+}
+
+//SelectFont is a recorded call of Context.SelectFont.
+type SelectFont struct {
+	Family string
+	Slant  cairo.Slant
+	Weight cairo.Weight
+}
+
+//Apply calls Context.SelectFont on c.
+func (o SelectFont) Apply(c *cairo.Context) error {
+	c.SelectFont(o.Family, o.Slant, o.Weight)
+	return nil
+}
+
+//SetFontSize is a recorded call of Context.SetFontSize.
+type SetFontSize struct {
+	Size float64
+}
+
+//Apply calls Context.SetFontSize on c.
+func (o SetFontSize) Apply(c *cairo.Context) error {
+	c.SetFontSize(o.Size)
+	return nil
+}
+
+//SetFontMatrix is a recorded call of Context.SetFontMatrix.
+type SetFontMatrix struct {
+	Matrix cairo.Matrix
+}
+
+//Apply calls Context.SetFontMatrix on c.
+func (o SetFontMatrix) Apply(c *cairo.Context) error {
+	c.SetFontMatrix(o.Matrix)
+	return nil
+}
+
+//SetFontOptions is a recorded call of Context.SetFontOptions.
+type SetFontOptions struct {
+	Options *cairo.FontOptions
+}
+
+//Apply calls Context.SetFontOptions on c.
+func (o SetFontOptions) Apply(c *cairo.Context) error {
+	c.SetFontOptions(o.Options)
+	return nil
+}
+
+//SetFont is a recorded call of Context.SetFont.
+type SetFont struct {
+	Font cairo.Font
+}
+
+//Apply calls Context.SetFont on c.
+func (o SetFont) Apply(c *cairo.Context) error {
+	c.SetFont(o.Font)
+	return nil
+}
+
+//SetScaledFont is a recorded call of Context.SetScaledFont.
+type SetScaledFont struct {
+	Font *cairo.ScaledFont
+}
+
+//Apply calls Context.SetScaledFont on c.
+func (o SetScaledFont) Apply(c *cairo.Context) error {
+	c.SetScaledFont(o.Font)
+	return nil
+}
+
+//ShowText is a recorded call of Context.ShowText.
+type ShowText struct {
+	Text string
+}
+
+//Apply calls Context.ShowText on c.
+func (o ShowText) Apply(c *cairo.Context) error {
+	c.ShowText(o.Text)
+	return nil
+}
+
+//ShowGlyphs is a recorded call of Context.ShowGlyphs.
+type ShowGlyphs struct {
+	Glyphs []cairo.Glyph
+}
+
+//Apply calls Context.ShowGlyphs on c.
+func (o ShowGlyphs) Apply(c *cairo.Context) error {
+	c.ShowGlyphs(o.Glyphs)
+	return nil
+}
+
+//ShowTextGlyphs is a recorded call of Context.ShowTextGlyphs.
+type ShowTextGlyphs struct {
+	Text     string
+	Glyphs   []cairo.Glyph
+	Clusters []cairo.TextCluster
+	Flags    cairo.TextClusterFlags
+}
+
+//Apply calls Context.ShowTextGlyphs on c.
+func (o ShowTextGlyphs) Apply(c *cairo.Context) error {
+	c.ShowTextGlyphs(o.Text, o.Glyphs, o.Clusters, o.Flags)
+	return nil
+}
+
+//Translate is a recorded call of Context.Translate.
+type Translate struct {
+	V cairo.Point
+}
+
+//Apply calls Context.Translate on c.
+func (o Translate) Apply(c *cairo.Context) error {
+	c.Translate(o.V)
+	return nil
+}
+
+//Scale is a recorded call of Context.Scale.
+type Scale struct {
+	V cairo.Point
+}
+
+//Apply calls Context.Scale on c.
+func (o Scale) Apply(c *cairo.Context) error {
+	c.Scale(o.V)
+	return nil
+}
+
+//Rotate is a recorded call of Context.Rotate.
+type Rotate struct {
+	Angle float64
+}
+
+//Apply calls Context.Rotate on c.
+func (o Rotate) Apply(c *cairo.Context) error {
+	c.Rotate(o.Angle)
+	return nil
+}
+
+//Transform is a recorded call of Context.Transform.
+type Transform struct {
+	Matrix cairo.Matrix
+}
+
+//Apply calls Context.Transform on c.
+func (o Transform) Apply(c *cairo.Context) error {
+	c.Transform(o.Matrix)
+	return nil
+}
+
+//SetMatrix is a recorded call of Context.SetMatrix.
+type SetMatrix struct {
+	Matrix cairo.Matrix
+}
+
+//Apply calls Context.SetMatrix on c.
+func (o SetMatrix) Apply(c *cairo.Context) error {
+	c.SetMatrix(o.Matrix)
+	return nil
+}
+
+//ResetMatrix is a recorded call of Context.ResetMatrix.
+type ResetMatrix struct{}
+
+//Apply calls Context.ResetMatrix on c.
+func (ResetMatrix) Apply(c *cairo.Context) error {
+	c.ResetMatrix()
+	return nil
+}
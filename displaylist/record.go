@@ -0,0 +1,339 @@
+package displaylist
+
+import (
+	"image/color"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//Err returns nil, as a List records any operation.
+//Errors from the operations are reported when the List is replayed.
+func (l *List) Err() error {
+	return nil
+}
+
+//Save records a Save.
+func (l *List) Save() Drawer {
+	return l.Append(Save{})
+}
+
+//Restore records a Restore.
+func (l *List) Restore() error {
+	l.Append(Restore{})
+	return nil
+}
+
+//SaveRestore records a Save, calls f with l, and then records a Restore.
+//It returns the error returned by f.
+func (l *List) SaveRestore(f func(Drawer) error) error {
+	l.Append(Save{})
+	defer l.Append(Restore{})
+	return f(l)
+}
+
+//PushGroup records a PushGroup.
+func (l *List) PushGroup() Drawer {
+	return l.Append(PushGroup{})
+}
+
+//PushGroupWithContent records a PushGroupWithContent.
+func (l *List) PushGroupWithContent(content cairo.Content) Drawer {
+	return l.Append(PushGroupWithContent{Content: content})
+}
+
+//PopGroupToSource records a PopGroupToSource.
+func (l *List) PopGroupToSource() error {
+	l.Append(PopGroupToSource{})
+	return nil
+}
+
+//SetSourceColor records a SetSourceColor.
+func (l *List) SetSourceColor(col color.Color) Drawer {
+	return l.Append(SetSourceColor{Color: col})
+}
+
+//SetSource records a SetSource.
+func (l *List) SetSource(source cairo.Pattern) Drawer {
+	return l.Append(SetSource{Pattern: source})
+}
+
+//SetSourceSurface records a SetSourceSurface.
+func (l *List) SetSourceSurface(s cairo.Surface, originDisplacement cairo.Point) error {
+	l.Append(SetSourceSurface{Surface: s, Origin: originDisplacement})
+	return nil
+}
+
+//SetAntialiasMode records a SetAntialiasMode.
+func (l *List) SetAntialiasMode(a cairo.Antialias) Drawer {
+	return l.Append(SetAntialiasMode{Antialias: a})
+}
+
+//SetDash records a SetDash.
+func (l *List) SetDash(offset float64, dashes ...float64) error {
+	l.Append(SetDash{
+		Offset: offset,
+		Dashes: append([]float64(nil), dashes...),
+	})
+	return nil
+}
+
+//SetFillRule records a SetFillRule.
+func (l *List) SetFillRule(f cairo.FillRule) Drawer {
+	return l.Append(SetFillRule{FillRule: f})
+}
+
+//SetLineCap records a SetLineCap.
+func (l *List) SetLineCap(lc cairo.LineCap) Drawer {
+	return l.Append(SetLineCap{LineCap: lc})
+}
+
+//SetLineJoin records a SetLineJoin.
+func (l *List) SetLineJoin(lj cairo.LineJoin) Drawer {
+	return l.Append(SetLineJoin{LineJoin: lj})
+}
+
+//SetLineWidth records a SetLineWidth.
+func (l *List) SetLineWidth(width float64) Drawer {
+	return l.Append(SetLineWidth{Width: width})
+}
+
+//SetMiterLimit records a SetMiterLimit.
+func (l *List) SetMiterLimit(ml float64) Drawer {
+	return l.Append(SetMiterLimit{Limit: ml})
+}
+
+//SetOperator records a SetOperator.
+func (l *List) SetOperator(op cairo.Operator) Drawer {
+	return l.Append(SetOperator{Operator: op})
+}
+
+//SetTolerance records a SetTolerance.
+func (l *List) SetTolerance(tolerance float64) Drawer {
+	return l.Append(SetTolerance{Tolerance: tolerance})
+}
+
+//Clip records a Clip.
+func (l *List) Clip() Drawer {
+	return l.Append(Clip{})
+}
+
+//ClipPreserve records a ClipPreserve.
+func (l *List) ClipPreserve() Drawer {
+	return l.Append(ClipPreserve{})
+}
+
+//ResetClip records a ResetClip.
+func (l *List) ResetClip() Drawer {
+	return l.Append(ResetClip{})
+}
+
+//Fill records a Fill.
+func (l *List) Fill() Drawer {
+	return l.Append(Fill{})
+}
+
+//FillPreserve records a FillPreserve.
+func (l *List) FillPreserve() Drawer {
+	return l.Append(FillPreserve{})
+}
+
+//Mask records a Mask.
+func (l *List) Mask(p cairo.Pattern) Drawer {
+	return l.Append(Mask{Pattern: p})
+}
+
+//MaskSurface records a MaskSurface.
+func (l *List) MaskSurface(s cairo.Surface, offsetVector cairo.Point) Drawer {
+	return l.Append(MaskSurface{Surface: s, Offset: offsetVector})
+}
+
+//Paint records a Paint.
+func (l *List) Paint() Drawer {
+	return l.Append(Paint{})
+}
+
+//PaintAlpha records a PaintAlpha.
+func (l *List) PaintAlpha(alpha float64) Drawer {
+	return l.Append(PaintAlpha{Alpha: alpha})
+}
+
+//Stroke records a Stroke.
+func (l *List) Stroke() Drawer {
+	return l.Append(Stroke{})
+}
+
+//StrokePreserve records a StrokePreserve.
+func (l *List) StrokePreserve() Drawer {
+	return l.Append(StrokePreserve{})
+}
+
+//CopyPage records a CopyPage.
+func (l *List) CopyPage() Drawer {
+	return l.Append(CopyPage{})
+}
+
+//ShowPage records a ShowPage.
+func (l *List) ShowPage() Drawer {
+	return l.Append(ShowPage{})
+}
+
+//AppendPath records an AppendPath.
+func (l *List) AppendPath(path cairo.Path) error {
+	l.Append(AppendPath{Path: append(cairo.Path(nil), path...)})
+	return nil
+}
+
+//NewPath records a NewPath.
+func (l *List) NewPath() Drawer {
+	return l.Append(NewPath{})
+}
+
+//NewSubPath records a NewSubPath.
+func (l *List) NewSubPath() Drawer {
+	return l.Append(NewSubPath{})
+}
+
+//ClosePath records a ClosePath.
+func (l *List) ClosePath() Drawer {
+	return l.Append(ClosePath{})
+}
+
+//Arc records an Arc.
+func (l *List) Arc(circle cairo.Circle, fromAngle float64, toAngle float64) Drawer {
+	return l.Append(Arc{Circle: circle, From: fromAngle, To: toAngle})
+}
+
+//ArcNegative records an ArcNegative.
+func (l *List) ArcNegative(circle cairo.Circle, fromAngle float64, toAngle float64) Drawer {
+	return l.Append(ArcNegative{Circle: circle, From: fromAngle, To: toAngle})
+}
+
+//Circle records a Circle.
+func (l *List) Circle(circle cairo.Circle) Drawer {
+	return l.Append(Circle{Circle: circle})
+}
+
+//CurveTo records a CurveTo.
+func (l *List) CurveTo(p1 cairo.Point, p2 cairo.Point, p3 cairo.Point) Drawer {
+	return l.Append(CurveTo{P1: p1, P2: p2, P3: p3})
+}
+
+//LineTo records a LineTo.
+func (l *List) LineTo(p cairo.Point) Drawer {
+	return l.Append(LineTo{P: p})
+}
+
+//MoveTo records a MoveTo.
+func (l *List) MoveTo(p cairo.Point) Drawer {
+	return l.Append(MoveTo{P: p})
+}
+
+//Rectangle records a Rectangle.
+func (l *List) Rectangle(r cairo.Rectangle) Drawer {
+	return l.Append(Rectangle{Rectangle: r})
+}
+
+//RelCurveTo records a RelCurveTo.
+func (l *List) RelCurveTo(v1 cairo.Point, v2 cairo.Point, v3 cairo.Point) Drawer {
+	return l.Append(RelCurveTo{V1: v1, V2: v2, V3: v3})
+}
+
+//RelLineTo records a RelLineTo.
+func (l *List) RelLineTo(v cairo.Point) Drawer {
+	return l.Append(RelLineTo{V: v})
+}
+
+//RelMoveTo records a RelMoveTo.
+func (l *List) RelMoveTo(v cairo.Point) Drawer {
+	return l.Append(RelMoveTo{V: v})
+}
+
+//GlyphPath records a GlyphPath.
+func (l *List) GlyphPath(glyphs []cairo.Glyph) Drawer {
+	return l.Append(GlyphPath{Glyphs: append([]cairo.Glyph(nil), glyphs...)})
+}
+
+//TextPath records a TextPath.
+func (l *List) TextPath(s string) Drawer {
+	return l.Append(TextPath{Text: s})
+}
+
+//SelectFont records a SelectFont.
+func (l *List) SelectFont(family string, slant cairo.Slant, weight cairo.Weight) Drawer {
+	return l.Append(SelectFont{Family: family, Slant: slant, Weight: weight})
+}
+
+//SetFontSize records a SetFontSize.
+func (l *List) SetFontSize(size float64) Drawer {
+	return l.Append(SetFontSize{Size: size})
+}
+
+//SetFontMatrix records a SetFontMatrix.
+func (l *List) SetFontMatrix(m cairo.Matrix) Drawer {
+	return l.Append(SetFontMatrix{Matrix: m})
+}
+
+//SetFontOptions records a SetFontOptions.
+func (l *List) SetFontOptions(opts *cairo.FontOptions) Drawer {
+	return l.Append(SetFontOptions{Options: opts})
+}
+
+//SetFont records a SetFont.
+func (l *List) SetFont(f cairo.Font) Drawer {
+	return l.Append(SetFont{Font: f})
+}
+
+//SetScaledFont records a SetScaledFont.
+func (l *List) SetScaledFont(sf *cairo.ScaledFont) Drawer {
+	return l.Append(SetScaledFont{Font: sf})
+}
+
+//ShowText records a ShowText.
+func (l *List) ShowText(s string) Drawer {
+	return l.Append(ShowText{Text: s})
+}
+
+//ShowGlyphs records a ShowGlyphs.
+func (l *List) ShowGlyphs(glyphs []cairo.Glyph) Drawer {
+	return l.Append(ShowGlyphs{Glyphs: append([]cairo.Glyph(nil), glyphs...)})
+}
+
+//ShowTextGlyphs records a ShowTextGlyphs.
+func (l *List) ShowTextGlyphs(s string, glyphs []cairo.Glyph, clusters []cairo.TextCluster, flags cairo.TextClusterFlags) Drawer {
+	return l.Append(ShowTextGlyphs{
+		Text:     s,
+		Glyphs:   append([]cairo.Glyph(nil), glyphs...),
+		Clusters: append([]cairo.TextCluster(nil), clusters...),
+		Flags:    flags,
+	})
+}
+
+//Translate records a Translate.
+func (l *List) Translate(v cairo.Point) Drawer {
+	return l.Append(Translate{V: v})
+}
+
+//Scale records a Scale.
+func (l *List) Scale(v cairo.Point) Drawer {
+	return l.Append(Scale{V: v})
+}
+
+//Rotate records a Rotate.
+func (l *List) Rotate(θ float64) Drawer {
+	return l.Append(Rotate{Angle: θ})
+}
+
+//Transform records a Transform.
+func (l *List) Transform(m cairo.Matrix) Drawer {
+	return l.Append(Transform{Matrix: m})
+}
+
+//SetMatrix records a SetMatrix.
+func (l *List) SetMatrix(m cairo.Matrix) Drawer {
+	return l.Append(SetMatrix{Matrix: m})
+}
+
+//ResetMatrix records a ResetMatrix.
+func (l *List) ResetMatrix() Drawer {
+	return l.Append(ResetMatrix{})
+}
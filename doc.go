@@ -19,6 +19,35 @@
 //object referenced must be freed explicitly with Close (or Unmap,
 //in the case of MappedImageSurface).
 //
+//Concurrency
+//
+//Libcairo objects are not safe for concurrent use, with the exceptions below,
+//and neither are the handles of this package that refer to them.
+//
+//A Context must only be used by one goroutine at a time.
+//To share a Context between goroutines, use a SyncContext.
+//
+//A Surface must only be drawn on by one goroutine at a time,
+//even through different Contexts.
+//Separate Contexts drawing on separate surfaces, such as one ImageSurface
+//for each worker goroutine, may be used concurrently.
+//
+//Patterns, Fonts, and ScaledFonts may be used by many Contexts concurrently,
+//as a source or font, as long as they are not modified, as by AddColorStop
+//or SetMatrix, while they are in use.
+//Libcairo guards its own font caches.
+//
+//No handle may be closed while it is in use by another goroutine.
+//
+//Devices may be acquired for exclusive use with Lock; see Device.
+//
+//The functions that register extensions, such as
+//XtensionRegisterRawToSurface, are safe for concurrent use,
+//though they are usually called during init.
+//
+//Values that do not refer to libcairo objects, such as Matrix and Point,
+//are ordinary Go values.
+//
 //Naming Conventions
 //
 //Cairo refers to this package and its related packages.
@@ -26,15 +55,35 @@
 //
 //Libcairo version
 //
-//This package requires libcairo version 1.12 or greater.
+//This package requires libcairo version 1.14 or greater.
 //Libcairo must be compiled with:
 //	CAIRO_HAS_IMAGE_SURFACE
 //
-//Related packages, such as cairo/ps, may require further options compiled
-//in to libcairo, but they will be documented.
+//Related packages, such as cairo/ps, use further options compiled
+//in to libcairo.
+//They build against a libcairo compiled without those options,
+//but return a *NotSupportedError when used,
+//so one program may be deployed with different builds of libcairo.
+//Features reports which options the linked libcairo was compiled with,
+//and VersionNumber reports its version.
+//
+//Likewise, mesh patterns, mapped images, and device scales use functions
+//added in libcairo 1.12 and 1.14, and return a *NotSupportedError
+//when the linked libcairo is older.
+//Features also reports whether these are supported.
 //
 //Libcairo can be found at http://cairographics.org .
 //
+//Encoding
+//
+//The types that describe how to draw, such as LineCap and Operator,
+//implement encoding.TextMarshaler, encoding.TextUnmarshaler, and flag.Value,
+//so that they may be read from configuration files and command line flags.
+//Each value is encoded as the lower case name of the libcairo constant,
+//without its prefix and with words separated by hyphens:
+//for example, LineCapRound is "round" and OpDestOver is "dest-over".
+//Decoding ignores case.
+//
 //Xtensions
 //
 //Many types, functions, and methods are prefixed by Xtension.
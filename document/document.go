@@ -0,0 +1,278 @@
+//Package document builds multi-page documents on any cairo.PagedSurface.
+//
+//A Document hands out a new Context for each page and takes care of the
+//details of changing the size and orientation of pages that libcairo
+//leaves to the user:
+//the size of a page may only be changed before anything is drawn on it,
+//and landscape pages in PostScript must be drawn rotated on a portrait page
+//with a %%PageOrientation comment.
+//
+//A Document may also draw templates, such as headers and footers,
+//on each page, and number its pages:
+//	d := document.New(surface, document.A4)
+//	d.AddTemplate(func(c *cairo.Context, p document.Page) error {
+//		c.MoveTo(cairo.Pt(p.Size.X/2, p.Size.Y-36)).ShowText(p.Number)
+//		return nil
+//	})
+//	for _, ch := range chapters {
+//		//ch.Orientation is ps.Portrait or ps.Landscape
+//		c, err := d.AddPage(document.A4, ch.Orientation)
+//		if err != nil {
+//			return err
+//		}
+//		ch.Draw(c)
+//	}
+//	return d.Close()
+//
+//The Document does not own the surface. It must still be closed by the user,
+//after the Document.
+package document
+
+import (
+	"errors"
+	"math"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/ps"
+)
+
+//Paper sizes, in points, in portrait orientation.
+var (
+	A3     = cairo.Pt(842, 1191)
+	A4     = cairo.Pt(595, 842)
+	A5     = cairo.Pt(420, 595)
+	Letter = cairo.Pt(612, 792)
+	Legal  = cairo.Pt(612, 1008)
+)
+
+//ErrSize is returned by AddPage when asked to change the size of a page
+//on a surface that cannot change size.
+var ErrSize = errors.New("document: surface cannot change page size")
+
+//ErrClosed is returned by AddPage after the Document has been closed.
+var ErrClosed = errors.New("document: closed")
+
+//sizer is implemented by surfaces whose page size may change,
+//such as pdf.Surface and ps.Surface.
+type sizer interface {
+	SetSize(width, height float64) error
+}
+
+//commenter is implemented by surfaces that take DSC comments,
+//such as ps.Surface.
+type commenter interface {
+	AddComments(comments ps.Comments) error
+}
+
+//Template draws on each page of a Document.
+//
+//The Context is new for each call, with user space set up for the
+//orientation of the page, as for the Context returned by AddPage.
+type Template func(c *cairo.Context, p Page) error
+
+//Page describes a page of a Document.
+type Page struct {
+	//Index is the index of the page, from 0.
+	Index int
+	//Number is the page number, as formatted by the Numbering
+	//of the Document.
+	Number string
+	//Size is the width and height of the page in user space,
+	//after orientation.
+	Size cairo.Point
+	//Orientation is the orientation of the page.
+	Orientation ps.Orientation
+}
+
+//Document is a sequence of pages drawn on a PagedSurface.
+type Document struct {
+	s         cairo.PagedSurface
+	size      cairo.Point
+	c         *cairo.Context
+	page      Page
+	pages     int
+	templates []Template
+	number    Numbering
+	closed    bool
+}
+
+//New creates a Document that draws on s.
+//
+//Size is the size s was created with.
+//It is used for pages that do not specify a size.
+func New(s cairo.PagedSurface, size cairo.Point) *Document {
+	return &Document{
+		s:      s,
+		size:   size,
+		number: Arabic,
+	}
+}
+
+//AddTemplate adds t to the templates drawn on each page.
+//
+//Templates are drawn in the order they were added, over the contents of
+//the page, when the page is finished.
+func (d *Document) AddTemplate(t Template) *Document {
+	d.templates = append(d.templates, t)
+	return d
+}
+
+//SetNumbering sets how pages are numbered.
+//
+//The default is Arabic.
+//It applies to pages that have not been finished.
+func (d *Document) SetNumbering(n Numbering) *Document {
+	if n == nil {
+		n = Arabic
+	}
+	d.number = n
+	return d
+}
+
+//Pages reports the number of pages added to d.
+func (d *Document) Pages() int {
+	return d.pages
+}
+
+//Page returns the current page.
+//
+//If no page has been added, it returns the zero Page.
+func (d *Document) Page() Page {
+	if d.c == nil {
+		return Page{}
+	}
+	d.page.Number = d.number(d.page.Index)
+	return d.page
+}
+
+//AddPage finishes the current page, if any, and starts a new one.
+//
+//The size is the size of the paper, in points, in either orientation.
+//If it is the zero Point, the size of the previous page is used.
+//Changing the size requires a surface with a SetSize method,
+//such as pdf.Surface or ps.Surface; otherwise ErrSize is returned.
+//
+//The orientation is ps.Portrait or ps.Landscape, for any surface.
+//In Landscape orientation, the longer side of the paper is the width of the
+//page.
+//On a surface with an AddComments method, such as ps.Surface, the page
+//is kept portrait, user space is rotated, and a ps.PageOrientation comment
+//is added, as recommended for PostScript.
+//On other surfaces, the size of the page is set to the landscape size.
+//
+//The returned Context is valid until the next call to AddPage or Close,
+//which close it.
+//Its user space is the page, with the origin at the top left corner,
+//in either orientation.
+func (d *Document) AddPage(size cairo.Point, o ps.Orientation) (*cairo.Context, error) {
+	if d.closed {
+		return nil, ErrClosed
+	}
+	if err := d.finish(); err != nil {
+		return nil, err
+	}
+
+	if size == cairo.ZP {
+		size = d.size
+	}
+	portrait := portrait(size)
+	cm, rotate := d.s.(commenter)
+
+	media := orient(o, portrait)
+	if rotate {
+		media = portrait
+	}
+	if err := d.setSize(media); err != nil {
+		return nil, err
+	}
+	d.size = media
+
+	if rotate {
+		if err := cm.AddComments(ps.Comments{ps.PageOrientation(o)}); err != nil {
+			return nil, err
+		}
+	}
+
+	d.page = Page{
+		Index:       d.pages,
+		Size:        orient(o, portrait),
+		Orientation: o,
+	}
+	c, err := d.context()
+	if err != nil {
+		return nil, err
+	}
+	d.c = c
+	d.pages++
+	return c, nil
+}
+
+//Close finishes the current page.
+//
+//It does not close the surface.
+func (d *Document) Close() error {
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	return d.finish()
+}
+
+func (d *Document) setSize(size cairo.Point) error {
+	if size == d.size {
+		return nil
+	}
+	s, ok := d.s.(sizer)
+	if !ok {
+		return ErrSize
+	}
+	return s.SetSize(size.X, size.Y)
+}
+
+//context returns a new Context for the current page,
+//with user space rotated if the page is drawn rotated.
+func (d *Document) context() (*cairo.Context, error) {
+	c, err := cairo.New(d.s)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	if _, ok := d.s.(commenter); ok && d.page.Orientation == ps.Landscape {
+		c.
+			Translate(cairo.Pt(0, d.page.Size.X)).
+			Rotate(-math.Pi / 2)
+	}
+	return c, nil
+}
+
+//finish draws the templates on the current page, if any, and shows it.
+func (d *Document) finish() error {
+	if d.c == nil {
+		return nil
+	}
+	p := d.Page()
+	err := d.c.Close()
+	d.c = nil
+	if err != nil {
+		return err
+	}
+
+	if len(d.templates) > 0 {
+		c, err := d.context()
+		if err != nil {
+			return err
+		}
+		for _, t := range d.templates {
+			if err := t(c, p); err != nil {
+				c.Close()
+				return err
+			}
+		}
+		if err := c.Close(); err != nil {
+			return err
+		}
+	}
+
+	d.s.ShowPage()
+	return d.s.Err()
+}
@@ -0,0 +1,127 @@
+package document
+
+import (
+	"bytes"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/ps"
+	"github.com/jimmyfrasche/cairo/recording"
+)
+
+const ε = 1e-9
+
+var pageSetup = regexp.MustCompile(`(?m)^%%(PageOrientation|PageBoundingBox): (.*)$`)
+
+func TestAddPagePS(t *testing.T) {
+	var buf bytes.Buffer
+	s, err := ps.New(&buf, A4.X, A4.Y, false, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var templated []Page
+	d := New(s, A4)
+	d.AddTemplate(func(c *cairo.Context, p Page) error {
+		templated = append(templated, p)
+		return c.Err()
+	})
+
+	pages := []struct {
+		size cairo.Point
+		o    ps.Orientation
+	}{
+		{A4, ps.Portrait},
+		{Letter, ps.Portrait},
+		//the size of the previous page, given in either orientation
+		{cairo.Pt(792, 612), ps.Landscape},
+	}
+	for i, p := range pages {
+		c, err := d.AddPage(p.size, p.o)
+		if err != nil {
+			t.Fatalf("page %d: %v", i, err)
+		}
+		size := d.Page().Size
+		//cover the page so that its bounding box is the page
+		c.SetSourceColor(cairo.Black).Paint()
+
+		//user space is the page, in either orientation,
+		//and device space the portrait page
+		media := portrait(p.size)
+		for _, corner := range []cairo.Point{cairo.ZP, size} {
+			q := c.UserToDevice(corner)
+			if q.X < -ε || q.Y < -ε || q.X > media.X+ε || q.Y > media.Y+ε {
+				t.Errorf("page %d: %v is outside the page at %v", i, corner, q)
+			}
+		}
+		if p.o == ps.Landscape {
+			if size != cairo.Pt(792, 612) {
+				t.Errorf("page %d: got size %v, want landscape Letter", i, size)
+			}
+			got := c.UserToDevice(cairo.Pt(792, 0))
+			if math.Abs(got.X) > ε || math.Abs(got.Y) > ε {
+				t.Errorf("page %d: top right corner at %v, want rotated to the origin", i, got)
+			}
+		}
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(templated) != len(pages) {
+		t.Fatalf("templates drawn on %d pages, want %d", len(templated), len(pages))
+	}
+	for i, p := range templated {
+		if p.Index != i || p.Number != Arabic(i) || p.Orientation != pages[i].o {
+			t.Errorf("template %d: got %+v", i, p)
+		}
+	}
+
+	//the size is set before each page is drawn
+	//and the orientation added to the rotated page
+	var got []string
+	for _, m := range pageSetup.FindAllStringSubmatch(buf.String(), -1) {
+		got = append(got, m[1]+": "+m[2])
+	}
+	want := []string{
+		"PageBoundingBox: 0 0 595 842",
+		"PageBoundingBox: 0 0 612 792",
+		"PageOrientation: Landscape",
+		"PageBoundingBox: 0 0 612 792",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got page setup\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestAddPageErrSize(t *testing.T) {
+	s := recording.New(cairo.ContentColorAlpha, cairo.Rectangle{})
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	d := New(s, A4)
+	if _, err := d.AddPage(A4, ps.Portrait); err != nil {
+		t.Fatal(err)
+	}
+	//landscape is a change of size without comments to rotate the page
+	if _, err := d.AddPage(A4, ps.Landscape); err != ErrSize {
+		t.Errorf("landscape: got %v, want ErrSize", err)
+	}
+	if _, err := d.AddPage(Letter, ps.Portrait); err != ErrSize {
+		t.Errorf("Letter: got %v, want ErrSize", err)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.AddPage(A4, ps.Portrait); err != ErrClosed {
+		t.Errorf("after Close: got %v, want ErrClosed", err)
+	}
+}
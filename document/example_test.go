@@ -0,0 +1,46 @@
+package document_test
+
+import (
+	"log"
+	"os"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/document"
+	"github.com/jimmyfrasche/cairo/ps"
+)
+
+//This is the landscape example of cairo/ps, with page numbers.
+func Example_landscape() {
+	surface, err := ps.New(os.Stdout, document.A4.X, document.A4.Y, false, nil, nil)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer surface.Close()
+
+	d := document.New(surface, document.A4)
+	d.AddTemplate(func(c *cairo.Context, p document.Page) error {
+		c.
+			SelectFont("Sans", 0, 0).
+			SetFontSize(12).
+			MoveTo(cairo.Pt(p.Size.X/2, p.Size.Y-30)).
+			ShowText(p.Number)
+		return nil
+	})
+
+	for _, o := range []ps.Orientation{ps.Portrait, ps.Landscape} {
+		c, err := d.AddPage(document.A4, o)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		c.
+			SelectFont("Sans", 0, 0).
+			SetFontSize(60).
+			MoveTo(cairo.Pt(200, d.Page().Size.Y/3)).
+			ShowText(o.String())
+	}
+	if err := d.Close(); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,77 @@
+package document
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/ps"
+)
+
+//orient returns the portrait size p in orientation o.
+func orient(o ps.Orientation, p cairo.Point) cairo.Point {
+	if o == ps.Landscape {
+		return cairo.Pt(p.Y, p.X)
+	}
+	return p
+}
+
+//portrait returns size with the shorter side as the width.
+func portrait(size cairo.Point) cairo.Point {
+	if size.X > size.Y {
+		return cairo.Pt(size.Y, size.X)
+	}
+	return size
+}
+
+//Numbering formats the number of the page at index, from 0.
+type Numbering func(index int) string
+
+//Arabic numbers pages 1, 2, 3, and so on.
+func Arabic(index int) string {
+	return strconv.Itoa(index + 1)
+}
+
+//Roman numbers pages i, ii, iii, and so on.
+//
+//Pages past 3999 are numbered as by Arabic.
+func Roman(index int) string {
+	n := index + 1
+	if n < 1 || n > 3999 {
+		return Arabic(index)
+	}
+	var buf []string
+	for _, r := range roman {
+		for n >= r.n {
+			buf = append(buf, r.s)
+			n -= r.n
+		}
+	}
+	return strings.Join(buf, "")
+}
+
+var roman = []struct {
+	n int
+	s string
+}{
+	{1000, "m"}, {900, "cm"}, {500, "d"}, {400, "cd"},
+	{100, "c"}, {90, "xc"}, {50, "l"}, {40, "xl"},
+	{10, "x"}, {9, "ix"}, {5, "v"}, {4, "iv"},
+	{1, "i"},
+}
+
+//Offset returns a Numbering that numbers pages as n, starting at the page
+//at index start.
+//
+//Pages before start are numbered as by before.
+//This allows, for example, front matter numbered by Roman followed by
+//the body numbered from 1 by Arabic:
+//	d.SetNumbering(document.Offset(4, document.Roman, document.Arabic))
+func Offset(start int, before, n Numbering) Numbering {
+	return func(index int) string {
+		if index < start {
+			return before(index)
+		}
+		return n(index - start)
+	}
+}
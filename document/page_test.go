@@ -0,0 +1,44 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/ps"
+)
+
+func TestOrient(t *testing.T) {
+	if p := portrait(A4); p != A4 {
+		t.Errorf("portrait of A4 = %v, want %v", p, A4)
+	}
+	if p := portrait(cairo.Pt(842, 595)); p != A4 {
+		t.Errorf("portrait of landscape A4 = %v, want %v", p, A4)
+	}
+	if p := orient(ps.Landscape, A4); p != cairo.Pt(842, 595) {
+		t.Errorf("landscape A4 = %v, want 842x595", p)
+	}
+	if p := orient(ps.Portrait, A4); p != A4 {
+		t.Errorf("portrait A4 = %v, want %v", p, A4)
+	}
+}
+
+func TestNumbering(t *testing.T) {
+	for _, test := range []struct {
+		n     Numbering
+		index int
+		want  string
+	}{
+		{Arabic, 0, "1"},
+		{Arabic, 41, "42"},
+		{Roman, 0, "i"},
+		{Roman, 3, "iv"},
+		{Roman, 1993, "mcmxciv"},
+		{Roman, 3999, "4000"},
+		{Offset(2, Roman, Arabic), 1, "ii"},
+		{Offset(2, Roman, Arabic), 2, "1"},
+	} {
+		if got := test.n(test.index); got != test.want {
+			t.Errorf("page %d: got %q, want %q", test.index, got, test.want)
+		}
+	}
+}
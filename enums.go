@@ -5,7 +5,7 @@ package cairo
 import "C"
 
 //cairo_antialias_t
-type antialias int
+type Antialias int
 
 //Specifies the type of antialiasing to do when rendering text or shapes.
 //
@@ -31,49 +31,35 @@ type antialias int
 const (
 	//AntialiasDefault uses the default antialiasing for the subsystem
 	//and target device.
-	AntialiasDefault antialias = C.CAIRO_ANTIALIAS_DEFAULT
+	AntialiasDefault Antialias = C.CAIRO_ANTIALIAS_DEFAULT
 
 	//AntialiasNone uses a bilevel alpha mask.
-	AntialiasNone antialias = C.CAIRO_ANTIALIAS_NONE
+	AntialiasNone Antialias = C.CAIRO_ANTIALIAS_NONE
 	//AntialiasGray performs single-color antialiasing (using shades of gray
 	//for black text on white background, for example).
-	AntialiasGray antialias = C.CAIRO_ANTIALIAS_GRAY
+	AntialiasGray Antialias = C.CAIRO_ANTIALIAS_GRAY
 	//AntialiasSubpixel performs antialiasing by taking advantage of the order
 	//of subpixel elements on devices such as LCD panels.
-	AntialiasSubpixel antialias = C.CAIRO_ANTIALIAS_SUBPIXEL
+	AntialiasSubpixel Antialias = C.CAIRO_ANTIALIAS_SUBPIXEL
 
 	//AntialiasFast is a hint that the backend should perform some antialiasing
 	//but prefer speed over quality.
-	AntialiasFast antialias = C.CAIRO_ANTIALIAS_FAST
+	AntialiasFast Antialias = C.CAIRO_ANTIALIAS_FAST
 	//AntialiasGood is a hint that the backend should balance quality against
 	//performance.
-	AntialiasGood antialias = C.CAIRO_ANTIALIAS_GOOD
+	AntialiasGood Antialias = C.CAIRO_ANTIALIAS_GOOD
 	//AntialiasBest is a hint that the backend should render at the highest
 	//quality, sacrificing speed if necessary.
-	AntialiasBest antialias = C.CAIRO_ANTIALIAS_BEST
+	AntialiasBest Antialias = C.CAIRO_ANTIALIAS_BEST
 )
 
-func (a antialias) c() C.cairo_antialias_t {
+func (a Antialias) c() C.cairo_antialias_t {
 	return C.cairo_antialias_t(a)
 }
 
-func (a antialias) String() string {
-	s := ""
-	switch a {
-	case AntialiasNone:
-		s = "No"
-	case AntialiasGray:
-		s = "Gray"
-	case AntialiasSubpixel:
-		s = "Supixel"
-	case AntialiasFast:
-		s = "Fast"
-	case AntialiasGood:
-		s = "Good"
-	case AntialiasBest:
-		s = "Best"
-	}
-	return s + " antialiasing"
+//String returns the text encoding of a.
+func (a Antialias) String() string {
+	return antialiasText.string(int(a))
 }
 
 //Content is used to describe the content that a surface will contain, whether
@@ -186,7 +172,7 @@ func (d deviceType) Pseudo() bool {
 }
 
 //cairo_extend_t
-type extend int
+type Extend int
 
 //The extend type describes how pattern color/alpha will be determined
 //for areas "outside" the pattern's natural area, (for example, outside
@@ -195,41 +181,29 @@ type extend int
 //Originally cairo_extend_t.
 const (
 	//ExtendNone makes pixels outside of the source pattern are fully transparent.
-	ExtendNone extend = C.CAIRO_EXTEND_NONE
+	ExtendNone Extend = C.CAIRO_EXTEND_NONE
 	//ExtendRepeat means the pattern is tiled by repeating.
-	ExtendRepeat extend = C.CAIRO_EXTEND_REPEAT
+	ExtendRepeat Extend = C.CAIRO_EXTEND_REPEAT
 	//ExtendReflect means the pattern is tiled by reflecting at the edges.
-	ExtendReflect extend = C.CAIRO_EXTEND_REFLECT
+	ExtendReflect Extend = C.CAIRO_EXTEND_REFLECT
 	//ExtendPad means pixels outside of the pattern copy the closest pixel
 	//from the source.
-	ExtendPad extend = C.CAIRO_EXTEND_PAD
+	ExtendPad Extend = C.CAIRO_EXTEND_PAD
 )
 
-func (e extend) c() C.cairo_extend_t {
+func (e Extend) c() C.cairo_extend_t {
 	return C.cairo_extend_t(e)
 }
 
-func (e extend) String() string {
-	var s string
-	switch e {
-	case ExtendNone:
-		s = "No"
-	case ExtendRepeat:
-		s = "Repeat"
-	case ExtendReflect:
-		s = "Relfect"
-	case ExtendPad:
-		s = "Pad"
-	default:
-		s = "unknown"
-	}
-	return s + " extend"
+//String returns the text encoding of e.
+func (e Extend) String() string {
+	return extendText.string(int(e))
 }
 
 //cairo_fill_rule_t
-type fillRule int
+type FillRule int
 
-//The fillRule type is used to select how paths are filled.
+//The FillRule type is used to select how paths are filled.
 //For both fill rules, whether or not a point is included in the fill
 //is determined by taking a ray from that point to infinity and looking
 //at intersections with the path.
@@ -247,30 +221,25 @@ const (
 	//(Left and right are determined from the perspective of looking along
 	//the ray from the starting point.) If the total count is non-zero,
 	//the point will be filled.
-	FillRuleWinding fillRule = C.CAIRO_FILL_RULE_WINDING
+	FillRuleWinding FillRule = C.CAIRO_FILL_RULE_WINDING
 
 	//FillRuleEvenOdd counts the total number of intersections,
 	//without regard to the orientation of the contour.
 	//If the total number of intersections is odd, the point will be filled.
-	FillRuleEvenOdd fillRule = C.CAIRO_FILL_RULE_EVEN_ODD
+	FillRuleEvenOdd FillRule = C.CAIRO_FILL_RULE_EVEN_ODD
 )
 
-func (f fillRule) c() C.cairo_fill_rule_t {
+func (f FillRule) c() C.cairo_fill_rule_t {
 	return C.cairo_fill_rule_t(f)
 }
 
-func (f fillRule) String() string {
-	switch f {
-	case FillRuleWinding:
-		return "winding fill rule"
-	case FillRuleEvenOdd:
-		return "even-odd fill rule"
-	}
-	return "unknown fill rule"
+//String returns the text encoding of f.
+func (f FillRule) String() string {
+	return fillRuleText.string(int(f))
 }
 
 //cairo_filter_t
-type filter int
+type Filter int
 
 //NB CAIRO_FILTER_GAUSSIAN is left off as the docs say it is currently unimplemented
 
@@ -281,102 +250,85 @@ type filter int
 const (
 	//FilterFast is a high performance filter with quality similar to
 	//FilterNearest.
-	FilterFast filter = C.CAIRO_FILTER_FAST
+	FilterFast Filter = C.CAIRO_FILTER_FAST
 
 	//FilterGood is a reasonable performance filter, with quality similiar to
 	//FilterBilinear.
-	FilterGood filter = C.CAIRO_FILTER_GOOD
+	FilterGood Filter = C.CAIRO_FILTER_GOOD
 
 	//FilterBest is the highest quality filter, but may not be suitable
 	//for interactive use.
-	FilterBest filter = C.CAIRO_FILTER_BEST
+	FilterBest Filter = C.CAIRO_FILTER_BEST
 
 	//FilterNearest is nearest-neighbor filtering.
-	FilterNearest filter = C.CAIRO_FILTER_NEAREST
+	FilterNearest Filter = C.CAIRO_FILTER_NEAREST
 
 	//FilterBilinear uses linear interpolation in two dimensions.
-	FilterBilinear filter = C.CAIRO_FILTER_BILINEAR
+	FilterBilinear Filter = C.CAIRO_FILTER_BILINEAR
 )
 
-func (f filter) c() C.cairo_filter_t {
+func (f Filter) c() C.cairo_filter_t {
 	return C.cairo_filter_t(f)
 }
 
-func (f filter) String() string {
-	var s string
-	switch f {
-	case FilterFast:
-		s = "Fast"
-	case FilterGood:
-		s = "Good"
-	case FilterBest:
-		s = "Best"
-	case FilterNearest:
-		s = "Nearest"
-	case FilterBilinear:
-		s = "Bilinear"
-	default:
-		s = "unknown"
-	}
-	return s + " filter"
+//String returns the text encoding of f.
+func (f Filter) String() string {
+	return filterText.string(int(f))
 }
 
 //cairo_font_slant_t
-type slant int
+type Slant int
 
 //Specifies variants of a font face based on their slant.
 //
 //Originally cairo_font_slant_t.
 const (
 	//SlantNormal is standard upright font style.
-	SlantNormal slant = C.CAIRO_FONT_SLANT_NORMAL
+	SlantNormal Slant = C.CAIRO_FONT_SLANT_NORMAL
 	//SlantItalic is italic font style.
-	SlantItalic slant = C.CAIRO_FONT_SLANT_ITALIC
+	SlantItalic Slant = C.CAIRO_FONT_SLANT_ITALIC
 	//SlantOblique is oblique font style.
-	SlantOblique slant = C.CAIRO_FONT_SLANT_OBLIQUE
+	SlantOblique Slant = C.CAIRO_FONT_SLANT_OBLIQUE
 )
 
-func (s slant) c() C.cairo_font_slant_t {
+func (s Slant) c() C.cairo_font_slant_t {
 	return C.cairo_font_slant_t(s)
 }
 
-func (s slant) String() string {
-	switch s {
-	case SlantNormal:
-		return "normal font slant"
-	case SlantItalic:
-		return "italic font slant"
-	case SlantOblique:
-		return "oblique font slant"
-	}
-	return "unknown font slant"
+//String returns the text encoding of s.
+func (s Slant) String() string {
+	return slantText.string(int(s))
 }
 
 //cairo_font_type_t
-type fontType int
+type FontType int
 
-//A fontType describes the type of a given font face or scaled font.
+//A FontType describes the type of a given font face or scaled font.
 //The font types are also known as "font backends" within cairo.
 //
 //Originally cairo_font_type_t.
 const (
 	//FontTypeToy fonts are created using cairo's toy font api.
-	FontTypeToy fontType = C.CAIRO_FONT_TYPE_TOY
+	FontTypeToy FontType = C.CAIRO_FONT_TYPE_TOY
+	//FontTypeFT is a FreeType font.
+	FontTypeFT FontType = C.CAIRO_FONT_TYPE_FT
 	//FontTypeWin32 is a native Windows font.
-	FontTypeWin32 fontType = C.CAIRO_FONT_TYPE_WIN32
+	FontTypeWin32 FontType = C.CAIRO_FONT_TYPE_WIN32
 	//FontTypeQuartz is a native Macintosh font.
-	FontTypeQuartz fontType = C.CAIRO_FONT_TYPE_QUARTZ //previously knonw as CAIRO_FONT_TYPE_ATSUI
+	FontTypeQuartz FontType = C.CAIRO_FONT_TYPE_QUARTZ //previously knonw as CAIRO_FONT_TYPE_ATSUI
 	//FontTypeUser was created using cairo's user font api.
 	//
 	//A type with FontTypeUser also has a Subtype.
-	FontTypeUser fontType = C.CAIRO_FONT_TYPE_USER
+	FontTypeUser FontType = C.CAIRO_FONT_TYPE_USER
 )
 
-func (f fontType) String() string {
+func (f FontType) String() string {
 	s := ""
 	switch f {
 	case FontTypeToy:
 		s = "toy"
+	case FontTypeFT:
+		s = "FreeType"
 	case FontTypeWin32:
 		s = "Win32"
 	case FontTypeQuartz:
@@ -390,30 +342,25 @@ func (f fontType) String() string {
 }
 
 //cairo_font_weight_t
-type weight int
+type Weight int
 
 //Specifies variants of a font face based on their weight.
 //
 //Orginally cairo_font_weight_t.
 const (
 	//WeightNormal is normal font weight.
-	WeightNormal weight = C.CAIRO_FONT_WEIGHT_NORMAL
+	WeightNormal Weight = C.CAIRO_FONT_WEIGHT_NORMAL
 	//WeightBold is bold font weight.
-	WeightBold weight = C.CAIRO_FONT_WEIGHT_BOLD
+	WeightBold Weight = C.CAIRO_FONT_WEIGHT_BOLD
 )
 
-func (w weight) c() C.cairo_font_weight_t {
+func (w Weight) c() C.cairo_font_weight_t {
 	return C.cairo_font_weight_t(w)
 }
 
-func (w weight) String() string {
-	switch w {
-	case WeightNormal:
-		return "normal font weight"
-	case WeightBold:
-		return "bold font weight"
-	}
-	return "unknown font weight"
+//String returns the text encoding of w.
+func (w Weight) String() string {
+	return weightText.string(int(w))
 }
 
 //Format identifies the memory format of image data.
@@ -474,7 +421,7 @@ func (f Format) String() string {
 }
 
 //cairo_hint_metrics_t
-type hintMetrics int
+type HintMetrics int
 
 //Specifies whether to hint font metrics; hinting font metrics means quantizing
 //them so that they are integer values in device space. Doing this improves
@@ -485,29 +432,22 @@ type hintMetrics int
 const (
 	//HintMetricsDefault use hint metrics in the default manner
 	//for the font backend and target device.
-	HintMetricsDefault hintMetrics = C.CAIRO_HINT_METRICS_DEFAULT
+	HintMetricsDefault HintMetrics = C.CAIRO_HINT_METRICS_DEFAULT
 	//HintMetricsOff does not hint font metrics.
-	HintMetricsOff hintMetrics = C.CAIRO_HINT_METRICS_OFF
+	HintMetricsOff HintMetrics = C.CAIRO_HINT_METRICS_OFF
 	//HintMetricsOn hints font metrics.
-	HintMetricsOn hintMetrics = C.CAIRO_HINT_METRICS_ON
+	HintMetricsOn HintMetrics = C.CAIRO_HINT_METRICS_ON
 )
 
-func (h hintMetrics) String() string {
-	switch h {
-	case HintMetricsDefault:
-		return "Default hint metrics"
-	case HintMetricsOff:
-		return "No hint metrics"
-	case HintMetricsOn:
-		return "Hint metrics on"
-	}
-	return "unknown hint style"
+//String returns the text encoding of h.
+func (h HintMetrics) String() string {
+	return hintMetricsText.string(int(h))
 }
 
 //cairo_hint_style_t
-type hintStyle int
+type HintStyle int
 
-//The hintStyle type specifies the hinting method to use for font outlines.
+//The HintStyle type specifies the hinting method to use for font outlines.
 // Hinting is the process of fitting outlines to the pixel grid in order
 //to improve the appearance of the result.
 //Since hinting outlines involves distorting them, it also reduces
@@ -518,115 +458,81 @@ type hintStyle int
 const (
 	//HintStyleDefault uses the default hint style for the font backend and target
 	//device.
-	HintStyleDefault hintStyle = C.CAIRO_HINT_STYLE_DEFAULT
+	HintStyleDefault HintStyle = C.CAIRO_HINT_STYLE_DEFAULT
 
 	//HintStyleNone does not hint outlines.
-	HintStyleNone hintStyle = C.CAIRO_HINT_STYLE_NONE
+	HintStyleNone HintStyle = C.CAIRO_HINT_STYLE_NONE
 
 	//HintStyleSlight outlines slightly, to improve contrast while retaining
 	//good fidelity of the original shapes.
-	HintStyleSlight hintStyle = C.CAIRO_HINT_STYLE_SLIGHT
+	HintStyleSlight HintStyle = C.CAIRO_HINT_STYLE_SLIGHT
 
 	//HintStyleMedium outlines with medium strength, giving a compromise
 	//between fidelity to the original shapes and contrast
-	HintStyleMedium hintStyle = C.CAIRO_HINT_STYLE_MEDIUM
+	HintStyleMedium HintStyle = C.CAIRO_HINT_STYLE_MEDIUM
 
 	//HintStyleFull outlines to maximize contrast.
-	HintStyleFull hintStyle = C.CAIRO_HINT_STYLE_FULL
+	HintStyleFull HintStyle = C.CAIRO_HINT_STYLE_FULL
 )
 
-func (h hintStyle) String() string {
-	s := "Hint style "
-	switch h {
-	case HintStyleDefault:
-		s = "Default hint style"
-	case HintStyleNone:
-		s = "No hint style"
-	case HintStyleSlight:
-		s += "slight"
-	case HintStyleMedium:
-		s += "medium"
-	case HintStyleFull:
-		s += "full"
-	default:
-		s = "unknown hint style"
-	}
-	return s
+//String returns the text encoding of h.
+func (h HintStyle) String() string {
+	return hintStyleText.string(int(h))
 }
 
 //cairo_line_cap_t
-type lineCap int
+type LineCap int
 
 //Specifies how to render the endpoints of the path when stroking.
 //
 //Originally cairo_line_cap_t.
 const (
 	//LineCapButt starts(stops) the line exactly at the start(end) point.
-	LineCapButt lineCap = C.CAIRO_LINE_CAP_BUTT
+	LineCapButt LineCap = C.CAIRO_LINE_CAP_BUTT
 	//LineCapRound uses a round ending, the center of the circle is the end point.
-	LineCapRound lineCap = C.CAIRO_LINE_CAP_ROUND
+	LineCapRound LineCap = C.CAIRO_LINE_CAP_ROUND
 	//LineCapSquare uses a squared ending, the center of the square is
 	//the end point.
-	LineCapSquare lineCap = C.CAIRO_LINE_CAP_SQUARE
+	LineCapSquare LineCap = C.CAIRO_LINE_CAP_SQUARE
 )
 
-func (l lineCap) c() C.cairo_line_cap_t {
+func (l LineCap) c() C.cairo_line_cap_t {
 	return C.cairo_line_cap_t(l)
 }
 
-func (l lineCap) String() string {
-	s := ""
-	switch l {
-	case LineCapButt:
-		s = "Butt" //lol
-	case LineCapRound:
-		s = "Round"
-	case LineCapSquare:
-		s = "Square"
-	default:
-		s = "unknown"
-	}
-	return s + " line cap"
+//String returns the text encoding of l.
+func (l LineCap) String() string {
+	return lineCapText.string(int(l))
 }
 
 //cairo_line_join_t
-type lineJoin int
+type LineJoin int
 
 //Specifies how to render the junction of two lines when stroking.
 //
 //Originally cairo_line_join_t.
 const (
 	//LineJoinMiter uses a sharp (angled) corner.
-	LineJoinMiter lineJoin = C.CAIRO_LINE_JOIN_MITER
+	LineJoinMiter LineJoin = C.CAIRO_LINE_JOIN_MITER
 	//LineJoinRound uses a rounded join, the center of the circle
 	//is the join point.
-	LineJoinRound lineJoin = C.CAIRO_LINE_JOIN_ROUND
+	LineJoinRound LineJoin = C.CAIRO_LINE_JOIN_ROUND
 	//LineJoinBevel uses a cut-off join, the join is cut off at half
 	//the line width from the joint point.
-	LineJoinBevel lineJoin = C.CAIRO_LINE_JOIN_BEVEL
+	LineJoinBevel LineJoin = C.CAIRO_LINE_JOIN_BEVEL
 )
 
-func (l lineJoin) c() C.cairo_line_join_t {
+func (l LineJoin) c() C.cairo_line_join_t {
 	return C.cairo_line_join_t(l)
 }
 
-func (l lineJoin) String() string {
-	s := ""
-	switch l {
-	case LineJoinMiter:
-		s = "Miter"
-	case LineJoinRound:
-		s = "Round"
-	case LineJoinBevel:
-		s = "Bevel"
-	default:
-		s = "unknown"
-	}
-	return s + " line join"
+//String returns the text encoding of l.
+func (l LineJoin) String() string {
+	return lineJoinText.string(int(l))
 }
 
 //cairo_operator_t
-type operator int
+type Operator int
 
 //An operator sets the compositing operator for all cairo drawing operations.
 //
@@ -645,206 +551,145 @@ type operator int
 //Originally cairo_operator_t.
 const (
 	//OpClear clears destination layer (bounded).
-	OpClear operator = C.CAIRO_OPERATOR_CLEAR
+	OpClear Operator = C.CAIRO_OPERATOR_CLEAR
 
 	//OpSource replaces destination layer (bounded).
-	OpSource operator = C.CAIRO_OPERATOR_SOURCE
+	OpSource Operator = C.CAIRO_OPERATOR_SOURCE
 
 	//OpOver draws source layer on top of destination layer (bounded).
-	OpOver operator = C.CAIRO_OPERATOR_OVER
+	OpOver Operator = C.CAIRO_OPERATOR_OVER
 
 	//OpIn draws source where there was destination content (unbounded).
-	OpIn operator = C.CAIRO_OPERATOR_IN
+	OpIn Operator = C.CAIRO_OPERATOR_IN
 
 	//OpOut draws source where there was no destination content (unounded).
-	OpOut operator = C.CAIRO_OPERATOR_OUT
+	OpOut Operator = C.CAIRO_OPERATOR_OUT
 
 	//OpAtop draws source on top of destination content and only there.
-	OpAtop operator = C.CAIRO_OPERATOR_ATOP
+	OpAtop Operator = C.CAIRO_OPERATOR_ATOP
 
 	//OpDest ignores the source.
-	OpDest operator = C.CAIRO_OPERATOR_DEST
+	OpDest Operator = C.CAIRO_OPERATOR_DEST
 
 	//OpDestOver draw destination on top of source.
-	OpDestOver operator = C.CAIRO_OPERATOR_DEST_OVER
+	OpDestOver Operator = C.CAIRO_OPERATOR_DEST_OVER
 
 	//OpDestIn leaves destination only where there was source content.
-	OpDestIn operator = C.CAIRO_OPERATOR_DEST_IN
+	OpDestIn Operator = C.CAIRO_OPERATOR_DEST_IN
 
 	//OpDestOut leaves destination only where there was no source content.
-	OpDestOut operator = C.CAIRO_OPERATOR_DEST_OUT
+	OpDestOut Operator = C.CAIRO_OPERATOR_DEST_OUT
 
 	//OpDestAtop leaves destination on top of source content and only there.
-	OpDestAtop operator = C.CAIRO_OPERATOR_DEST_ATOP
+	OpDestAtop Operator = C.CAIRO_OPERATOR_DEST_ATOP
 
 	//OpXor shows source and destination where there is only one of them.
-	OpXor operator = C.CAIRO_OPERATOR_XOR
+	OpXor Operator = C.CAIRO_OPERATOR_XOR
 
 	//OpAdd accumulates source and destination layers.
-	OpAdd operator = C.CAIRO_OPERATOR_ADD
+	OpAdd Operator = C.CAIRO_OPERATOR_ADD
 
 	//OpSaturate is like OpOver, but assumes source and dest are disjoint
 	//geometries.
-	OpSaturate operator = C.CAIRO_OPERATOR_SATURATE
+	OpSaturate Operator = C.CAIRO_OPERATOR_SATURATE
 
 	//OpMultiply multiplies source and destination layers.
 	//This causes the result to be at least as the darker inputs.
-	OpMultiply operator = C.CAIRO_OPERATOR_MULTIPLY
+	OpMultiply Operator = C.CAIRO_OPERATOR_MULTIPLY
 
 	//OpScreen complements and multiples source and destination.
 	//This causes the result to be as light as the lighter inputs.
-	OpScreen operator = C.CAIRO_OPERATOR_SCREEN
+	OpScreen Operator = C.CAIRO_OPERATOR_SCREEN
 
 	//OpOverlay multiplies or screens, depending on the lightness
 	//of the destination color.
-	OpOverlay operator = C.CAIRO_OPERATOR_OVERLAY
+	OpOverlay Operator = C.CAIRO_OPERATOR_OVERLAY
 
 	//OpDarken replaces the destination with source if is darker, otherwise
 	//keeps the source.
-	OpDarken operator = C.CAIRO_OPERATOR_DARKEN
+	OpDarken Operator = C.CAIRO_OPERATOR_DARKEN
 
 	//OpLighten replaces the destiantion with source if it is lighter, otherwise
 	//keeps the source.
-	OpLighten operator = C.CAIRO_OPERATOR_LIGHTEN
+	OpLighten Operator = C.CAIRO_OPERATOR_LIGHTEN
 
 	//OpColorDodge brightens the destination color to reflect the source color.
-	OpColorDodge operator = C.CAIRO_OPERATOR_COLOR_DODGE
+	OpColorDodge Operator = C.CAIRO_OPERATOR_COLOR_DODGE
 
 	//OpColorBurn darkens the destination color to reflect the source color.
-	OpColorBurn operator = C.CAIRO_OPERATOR_COLOR_BURN
+	OpColorBurn Operator = C.CAIRO_OPERATOR_COLOR_BURN
 
 	//OpHardLight multiplies or screens, dependent on source color.
-	OpHardLight operator = C.CAIRO_OPERATOR_HARD_LIGHT
+	OpHardLight Operator = C.CAIRO_OPERATOR_HARD_LIGHT
 
 	//OpSoftLight darkens or lightens, dependent on source color.
-	OpSoftLight operator = C.CAIRO_OPERATOR_SOFT_LIGHT
+	OpSoftLight Operator = C.CAIRO_OPERATOR_SOFT_LIGHT
 
 	//OpDifference takes the difference of the source and destination color.
-	OpDifference operator = C.CAIRO_OPERATOR_DIFFERENCE
+	OpDifference Operator = C.CAIRO_OPERATOR_DIFFERENCE
 
 	//OpExclusion produces an effect similar to difference, but with lower contrast.
-	OpExclusion operator = C.CAIRO_OPERATOR_EXCLUSION
+	OpExclusion Operator = C.CAIRO_OPERATOR_EXCLUSION
 
 	//OpHueHSL creates a color with the hue of the source and the saturation
 	//and luminosity of the target.
-	OpHueHSL operator = C.CAIRO_OPERATOR_HSL_HUE
+	OpHueHSL Operator = C.CAIRO_OPERATOR_HSL_HUE
 
 	//OpSaturationHSL creates a color with the saturation of the source
 	//and the hue and luminosity of the target.
 	//Painting with this mode onto a gray area produces no change.
-	OpSaturationHSL operator = C.CAIRO_OPERATOR_HSL_SATURATION
+	OpSaturationHSL Operator = C.CAIRO_OPERATOR_HSL_SATURATION
 
 	//OpColorHSL creates a color with the hue and saturation of the source
 	//and the luminosity of the target.
 	//This preserves the gray levels of the target and useful for coloring
 	//monochrome images or tinting color images.
-	OpColorHSL operator = C.CAIRO_OPERATOR_HSL_COLOR
+	OpColorHSL Operator = C.CAIRO_OPERATOR_HSL_COLOR
 
 	//OpLuminosityHSL creates a color with the luminosity of the source
 	//and the hue and saturation of the target.
 	//This produces an inverse effect to OpColorHSL.
-	OpLuminosityHSL operator = C.CAIRO_OPERATOR_HSL_LUMINOSITY
+	OpLuminosityHSL Operator = C.CAIRO_OPERATOR_HSL_LUMINOSITY
 )
 
-func (o operator) c() C.cairo_operator_t {
+func (o Operator) c() C.cairo_operator_t {
 	return C.cairo_operator_t(o)
 }
 
-func (o operator) String() string {
-	s := ""
-	switch o {
-	case OpClear:
-		s = "Clear"
-	case OpSource:
-		s = "Source"
-	case OpOver:
-		s = "Over"
-	case OpIn:
-		s = "In"
-	case OpOut:
-		s = "Out"
-	case OpAtop:
-		s = "Atop"
-	case OpDest:
-		s = "Dest"
-	case OpDestOver:
-		s = "Dest Over"
-	case OpDestIn:
-		s = "Dest In"
-	case OpDestOut:
-		s = "Dest Out"
-	case OpDestAtop:
-		s = "Dest Atop"
-	case OpXor:
-		s = "Xor"
-	case OpAdd:
-		s = "Add"
-	case OpSaturate:
-		s = "Saturate"
-	case OpMultiply:
-		s = "Multiply"
-	case OpScreen:
-		s = "Screen"
-	case OpOverlay:
-		s = "Overlay"
-	case OpDarken:
-		s = "Darken"
-	case OpLighten:
-		s = "Lighten"
-	case OpColorDodge:
-		s = "Color Dodge"
-	case OpColorBurn:
-		s = "Color Burn"
-	case OpHardLight:
-		s = "Hard Light"
-	case OpSoftLight:
-		s = "Soft Light"
-	case OpDifference:
-		s = "Difference"
-	case OpExclusion:
-		s = "Exclusion"
-	case OpHueHSL:
-		s = "HSL Hue"
-	case OpSaturationHSL:
-		s = "HSL Saturation"
-	case OpColorHSL:
-		s = "HSL Color"
-	case OpLuminosityHSL:
-		s = "HSL Luminosity"
-	default:
-		s = "unknown"
-	}
-	return s + " operator"
+//String returns the text encoding of o.
+func (o Operator) String() string {
+	return operatorText.string(int(o))
 }
 
-type patternType int
+//cairo_pattern_type_t
+type PatternType int
 
-//A patternType describes the type of a given pattern.
+//A PatternType describes the type of a given pattern.
 //
 //Originally cairo_pattern_type_t
 const (
 	//PatternTypeSolid represents a uniform color, which may be opaque
 	//or translucent.
-	PatternTypeSolid = C.CAIRO_PATTERN_TYPE_SOLID
+	PatternTypeSolid PatternType = C.CAIRO_PATTERN_TYPE_SOLID
 	//PatternTypeSurface represents a pattern defined by a Surface.
-	PatternTypeSurface = C.CAIRO_PATTERN_TYPE_SURFACE
+	PatternTypeSurface PatternType = C.CAIRO_PATTERN_TYPE_SURFACE
 	//PatternTypeLinear represents a pattern that is a linear gradient.
-	PatternTypeLinear = C.CAIRO_PATTERN_TYPE_LINEAR
+	PatternTypeLinear PatternType = C.CAIRO_PATTERN_TYPE_LINEAR
 	//PatternTypeRadial represents a pattern that is a radial gradient.
-	PatternTypeRadial = C.CAIRO_PATTERN_TYPE_RADIAL
+	PatternTypeRadial PatternType = C.CAIRO_PATTERN_TYPE_RADIAL
 	//PatternTypeMesh represents a pattern defined by a mesh.
-	PatternTypeMesh = C.CAIRO_PATTERN_TYPE_MESH
+	PatternTypeMesh PatternType = C.CAIRO_PATTERN_TYPE_MESH
 	//PatternTypeRasterSource is a user pattern providing raster data.
 	//
 	//A raster pattern also has a Subtype.
-	PatternTypeRasterSource = C.CAIRO_PATTERN_TYPE_RASTER_SOURCE
+	PatternTypeRasterSource PatternType = C.CAIRO_PATTERN_TYPE_RASTER_SOURCE
 )
 
-func (p patternType) c() C.cairo_pattern_type_t {
+func (p PatternType) c() C.cairo_pattern_type_t {
 	return C.cairo_pattern_type_t(p)
 }
 
-func (p patternType) String() string {
+func (p PatternType) String() string {
 	s := ""
 	switch p {
 	case PatternTypeSolid:
@@ -903,39 +748,25 @@ func (p pathDataType) String() string {
 //cairo_status_t is handled in error.go
 
 //cairo_subpixel_order_t
-type subpixelOrder int
+type SubpixelOrder int
 
 //Originally cairo_subpixel_order_t.
 const (
 	//SubpixelOrderDefault uses the default subpixel order for the target device.
-	SubpixelOrderDefault subpixelOrder = C.CAIRO_SUBPIXEL_ORDER_DEFAULT
+	SubpixelOrderDefault SubpixelOrder = C.CAIRO_SUBPIXEL_ORDER_DEFAULT
 	//SubpixelOrderRGB organizes subpixels horizontally with red at the left.
-	SubpixelOrderRGB subpixelOrder = C.CAIRO_SUBPIXEL_ORDER_RGB
+	SubpixelOrderRGB SubpixelOrder = C.CAIRO_SUBPIXEL_ORDER_RGB
 	//SubpixelOrderBGR organizes supixels horizontally with blue at the left.
-	SubpixelOrderBGR subpixelOrder = C.CAIRO_SUBPIXEL_ORDER_BGR
+	SubpixelOrderBGR SubpixelOrder = C.CAIRO_SUBPIXEL_ORDER_BGR
 	//SubpixelOrderVRGB organizes supixels vertically with red on top.
-	SubpixelOrderVRGB subpixelOrder = C.CAIRO_SUBPIXEL_ORDER_VRGB
+	SubpixelOrderVRGB SubpixelOrder = C.CAIRO_SUBPIXEL_ORDER_VRGB
 	//SubpixelOrderVBGR organizes supixels vertically with blue on top.
-	SubpixelOrderVBGR subpixelOrder = C.CAIRO_SUBPIXEL_ORDER_VBGR
+	SubpixelOrderVBGR SubpixelOrder = C.CAIRO_SUBPIXEL_ORDER_VBGR
 )
 
-func (o subpixelOrder) String() string {
-	var s string
-	switch o {
-	case SubpixelOrderRGB:
-		s = "RGB"
-	case SubpixelOrderBGR:
-		s = "BGR"
-	case SubpixelOrderVRGB:
-		s = "VRGB"
-	case SubpixelOrderVBGR:
-		s = "VBGR"
-	case SubpixelOrderDefault:
-		return "Default subpixel ordering"
-	default:
-		return "unknown subpixel ordering"
-	}
-	return "Subpixels ordered " + s
+//String returns the text encoding of o.
+func (o SubpixelOrder) String() string {
+	return subpixelOrderText.string(int(o))
 }
 
 //cairo_surface_type_t
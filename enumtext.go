@@ -0,0 +1,458 @@
+package cairo
+
+import (
+	"fmt"
+	"strings"
+)
+
+//enumText is the text encoding of the values of an enum type.
+type enumText struct {
+	kind  string
+	names map[int]string
+}
+
+func (e enumText) marshal(v int) ([]byte, error) {
+	s, ok := e.names[v]
+	if !ok {
+		return nil, fmt.Errorf("cairo: cannot encode unknown %s %d", e.kind, v)
+	}
+	return []byte(s), nil
+}
+
+//string returns the text encoding of v,
+//or a description of v if it has none.
+func (e enumText) string(v int) string {
+	if s, ok := e.names[v]; ok {
+		return s
+	}
+	return "unknown " + e.kind
+}
+
+func (e enumText) parse(s string) (int, error) {
+	t := strings.ToLower(s)
+	for v, name := range e.names {
+		if name == t {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("cairo: unknown %s %q", e.kind, s)
+}
+
+var antialiasText = enumText{"antialias mode", map[int]string{
+	int(AntialiasDefault):  "default",
+	int(AntialiasNone):     "none",
+	int(AntialiasGray):     "gray",
+	int(AntialiasSubpixel): "subpixel",
+	int(AntialiasFast):     "fast",
+	int(AntialiasGood):     "good",
+	int(AntialiasBest):     "best",
+}}
+
+//ParseAntialias returns the Antialias encoded as s.
+func ParseAntialias(s string) (Antialias, error) {
+	v, err := antialiasText.parse(s)
+	return Antialias(v), err
+}
+
+//MarshalText implements encoding.TextMarshaler.
+func (a Antialias) MarshalText() ([]byte, error) {
+	return antialiasText.marshal(int(a))
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler.
+func (a *Antialias) UnmarshalText(text []byte) error {
+	return a.Set(string(text))
+}
+
+//Set implements flag.Value.
+func (a *Antialias) Set(s string) error {
+	v, err := ParseAntialias(s)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+var extendText = enumText{"extend", map[int]string{
+	int(ExtendNone):    "none",
+	int(ExtendRepeat):  "repeat",
+	int(ExtendReflect): "reflect",
+	int(ExtendPad):     "pad",
+}}
+
+//ParseExtend returns the Extend encoded as s.
+func ParseExtend(s string) (Extend, error) {
+	v, err := extendText.parse(s)
+	return Extend(v), err
+}
+
+//MarshalText implements encoding.TextMarshaler.
+func (e Extend) MarshalText() ([]byte, error) {
+	return extendText.marshal(int(e))
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler.
+func (e *Extend) UnmarshalText(text []byte) error {
+	return e.Set(string(text))
+}
+
+//Set implements flag.Value.
+func (e *Extend) Set(s string) error {
+	v, err := ParseExtend(s)
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+var fillRuleText = enumText{"fill rule", map[int]string{
+	int(FillRuleWinding): "winding",
+	int(FillRuleEvenOdd): "even-odd",
+}}
+
+//ParseFillRule returns the FillRule encoded as s.
+func ParseFillRule(s string) (FillRule, error) {
+	v, err := fillRuleText.parse(s)
+	return FillRule(v), err
+}
+
+//MarshalText implements encoding.TextMarshaler.
+func (f FillRule) MarshalText() ([]byte, error) {
+	return fillRuleText.marshal(int(f))
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler.
+func (f *FillRule) UnmarshalText(text []byte) error {
+	return f.Set(string(text))
+}
+
+//Set implements flag.Value.
+func (f *FillRule) Set(s string) error {
+	v, err := ParseFillRule(s)
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+var filterText = enumText{"filter", map[int]string{
+	int(FilterFast):     "fast",
+	int(FilterGood):     "good",
+	int(FilterBest):     "best",
+	int(FilterNearest):  "nearest",
+	int(FilterBilinear): "bilinear",
+}}
+
+//ParseFilter returns the Filter encoded as s.
+func ParseFilter(s string) (Filter, error) {
+	v, err := filterText.parse(s)
+	return Filter(v), err
+}
+
+//MarshalText implements encoding.TextMarshaler.
+func (f Filter) MarshalText() ([]byte, error) {
+	return filterText.marshal(int(f))
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler.
+func (f *Filter) UnmarshalText(text []byte) error {
+	return f.Set(string(text))
+}
+
+//Set implements flag.Value.
+func (f *Filter) Set(s string) error {
+	v, err := ParseFilter(s)
+	if err != nil {
+		return err
+	}
+	*f = v
+	return nil
+}
+
+var slantText = enumText{"slant", map[int]string{
+	int(SlantNormal):  "normal",
+	int(SlantItalic):  "italic",
+	int(SlantOblique): "oblique",
+}}
+
+//ParseSlant returns the Slant encoded as s.
+func ParseSlant(s string) (Slant, error) {
+	v, err := slantText.parse(s)
+	return Slant(v), err
+}
+
+//MarshalText implements encoding.TextMarshaler.
+func (sl Slant) MarshalText() ([]byte, error) {
+	return slantText.marshal(int(sl))
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler.
+func (sl *Slant) UnmarshalText(text []byte) error {
+	return sl.Set(string(text))
+}
+
+//Set implements flag.Value.
+func (sl *Slant) Set(s string) error {
+	v, err := ParseSlant(s)
+	if err != nil {
+		return err
+	}
+	*sl = v
+	return nil
+}
+
+var weightText = enumText{"weight", map[int]string{
+	int(WeightNormal): "normal",
+	int(WeightBold):   "bold",
+}}
+
+//ParseWeight returns the Weight encoded as s.
+func ParseWeight(s string) (Weight, error) {
+	v, err := weightText.parse(s)
+	return Weight(v), err
+}
+
+//MarshalText implements encoding.TextMarshaler.
+func (w Weight) MarshalText() ([]byte, error) {
+	return weightText.marshal(int(w))
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler.
+func (w *Weight) UnmarshalText(text []byte) error {
+	return w.Set(string(text))
+}
+
+//Set implements flag.Value.
+func (w *Weight) Set(s string) error {
+	v, err := ParseWeight(s)
+	if err != nil {
+		return err
+	}
+	*w = v
+	return nil
+}
+
+var hintMetricsText = enumText{"hint metrics", map[int]string{
+	int(HintMetricsDefault): "default",
+	int(HintMetricsOff):     "off",
+	int(HintMetricsOn):      "on",
+}}
+
+//ParseHintMetrics returns the HintMetrics encoded as s.
+func ParseHintMetrics(s string) (HintMetrics, error) {
+	v, err := hintMetricsText.parse(s)
+	return HintMetrics(v), err
+}
+
+//MarshalText implements encoding.TextMarshaler.
+func (h HintMetrics) MarshalText() ([]byte, error) {
+	return hintMetricsText.marshal(int(h))
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler.
+func (h *HintMetrics) UnmarshalText(text []byte) error {
+	return h.Set(string(text))
+}
+
+//Set implements flag.Value.
+func (h *HintMetrics) Set(s string) error {
+	v, err := ParseHintMetrics(s)
+	if err != nil {
+		return err
+	}
+	*h = v
+	return nil
+}
+
+var hintStyleText = enumText{"hint style", map[int]string{
+	int(HintStyleDefault): "default",
+	int(HintStyleNone):    "none",
+	int(HintStyleSlight):  "slight",
+	int(HintStyleMedium):  "medium",
+	int(HintStyleFull):    "full",
+}}
+
+//ParseHintStyle returns the HintStyle encoded as s.
+func ParseHintStyle(s string) (HintStyle, error) {
+	v, err := hintStyleText.parse(s)
+	return HintStyle(v), err
+}
+
+//MarshalText implements encoding.TextMarshaler.
+func (h HintStyle) MarshalText() ([]byte, error) {
+	return hintStyleText.marshal(int(h))
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler.
+func (h *HintStyle) UnmarshalText(text []byte) error {
+	return h.Set(string(text))
+}
+
+//Set implements flag.Value.
+func (h *HintStyle) Set(s string) error {
+	v, err := ParseHintStyle(s)
+	if err != nil {
+		return err
+	}
+	*h = v
+	return nil
+}
+
+var lineCapText = enumText{"line cap", map[int]string{
+	int(LineCapButt):   "butt",
+	int(LineCapRound):  "round",
+	int(LineCapSquare): "square",
+}}
+
+//ParseLineCap returns the LineCap encoded as s.
+func ParseLineCap(s string) (LineCap, error) {
+	v, err := lineCapText.parse(s)
+	return LineCap(v), err
+}
+
+//MarshalText implements encoding.TextMarshaler.
+func (l LineCap) MarshalText() ([]byte, error) {
+	return lineCapText.marshal(int(l))
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler.
+func (l *LineCap) UnmarshalText(text []byte) error {
+	return l.Set(string(text))
+}
+
+//Set implements flag.Value.
+func (l *LineCap) Set(s string) error {
+	v, err := ParseLineCap(s)
+	if err != nil {
+		return err
+	}
+	*l = v
+	return nil
+}
+
+var lineJoinText = enumText{"line join", map[int]string{
+	int(LineJoinMiter): "miter",
+	int(LineJoinRound): "round",
+	int(LineJoinBevel): "bevel",
+}}
+
+//ParseLineJoin returns the LineJoin encoded as s.
+func ParseLineJoin(s string) (LineJoin, error) {
+	v, err := lineJoinText.parse(s)
+	return LineJoin(v), err
+}
+
+//MarshalText implements encoding.TextMarshaler.
+func (l LineJoin) MarshalText() ([]byte, error) {
+	return lineJoinText.marshal(int(l))
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler.
+func (l *LineJoin) UnmarshalText(text []byte) error {
+	return l.Set(string(text))
+}
+
+//Set implements flag.Value.
+func (l *LineJoin) Set(s string) error {
+	v, err := ParseLineJoin(s)
+	if err != nil {
+		return err
+	}
+	*l = v
+	return nil
+}
+
+var operatorText = enumText{"operator", map[int]string{
+	int(OpClear):         "clear",
+	int(OpSource):        "source",
+	int(OpOver):          "over",
+	int(OpIn):            "in",
+	int(OpOut):           "out",
+	int(OpAtop):          "atop",
+	int(OpDest):          "dest",
+	int(OpDestOver):      "dest-over",
+	int(OpDestIn):        "dest-in",
+	int(OpDestOut):       "dest-out",
+	int(OpDestAtop):      "dest-atop",
+	int(OpXor):           "xor",
+	int(OpAdd):           "add",
+	int(OpSaturate):      "saturate",
+	int(OpMultiply):      "multiply",
+	int(OpScreen):        "screen",
+	int(OpOverlay):       "overlay",
+	int(OpDarken):        "darken",
+	int(OpLighten):       "lighten",
+	int(OpColorDodge):    "color-dodge",
+	int(OpColorBurn):     "color-burn",
+	int(OpHardLight):     "hard-light",
+	int(OpSoftLight):     "soft-light",
+	int(OpDifference):    "difference",
+	int(OpExclusion):     "exclusion",
+	int(OpHueHSL):        "hsl-hue",
+	int(OpSaturationHSL): "hsl-saturation",
+	int(OpColorHSL):      "hsl-color",
+	int(OpLuminosityHSL): "hsl-luminosity",
+}}
+
+//ParseOperator returns the Operator encoded as s.
+func ParseOperator(s string) (Operator, error) {
+	v, err := operatorText.parse(s)
+	return Operator(v), err
+}
+
+//MarshalText implements encoding.TextMarshaler.
+func (o Operator) MarshalText() ([]byte, error) {
+	return operatorText.marshal(int(o))
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler.
+func (o *Operator) UnmarshalText(text []byte) error {
+	return o.Set(string(text))
+}
+
+//Set implements flag.Value.
+func (o *Operator) Set(s string) error {
+	v, err := ParseOperator(s)
+	if err != nil {
+		return err
+	}
+	*o = v
+	return nil
+}
+
+var subpixelOrderText = enumText{"subpixel order", map[int]string{
+	int(SubpixelOrderDefault): "default",
+	int(SubpixelOrderRGB):     "rgb",
+	int(SubpixelOrderBGR):     "bgr",
+	int(SubpixelOrderVRGB):    "vrgb",
+	int(SubpixelOrderVBGR):    "vbgr",
+}}
+
+//ParseSubpixelOrder returns the SubpixelOrder encoded as s.
+func ParseSubpixelOrder(s string) (SubpixelOrder, error) {
+	v, err := subpixelOrderText.parse(s)
+	return SubpixelOrder(v), err
+}
+
+//MarshalText implements encoding.TextMarshaler.
+func (o SubpixelOrder) MarshalText() ([]byte, error) {
+	return subpixelOrderText.marshal(int(o))
+}
+
+//UnmarshalText implements encoding.TextUnmarshaler.
+func (o *SubpixelOrder) UnmarshalText(text []byte) error {
+	return o.Set(string(text))
+}
+
+//Set implements flag.Value.
+func (o *SubpixelOrder) Set(s string) error {
+	v, err := ParseSubpixelOrder(s)
+	if err != nil {
+		return err
+	}
+	*o = v
+	return nil
+}
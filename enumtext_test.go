@@ -0,0 +1,118 @@
+package cairo
+
+import (
+	"encoding"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"testing"
+)
+
+func TestEnumText(t *testing.T) {
+	type enum interface {
+		encoding.TextMarshaler
+		encoding.TextUnmarshaler
+		flag.Value
+	}
+	//new returns a pointer to v converted to the enum type.
+	for _, test := range []struct {
+		text enumText
+		new  func(v int) enum
+	}{
+		{antialiasText, func(v int) enum { x := Antialias(v); return &x }},
+		{extendText, func(v int) enum { x := Extend(v); return &x }},
+		{fillRuleText, func(v int) enum { x := FillRule(v); return &x }},
+		{filterText, func(v int) enum { x := Filter(v); return &x }},
+		{slantText, func(v int) enum { x := Slant(v); return &x }},
+		{weightText, func(v int) enum { x := Weight(v); return &x }},
+		{hintMetricsText, func(v int) enum { x := HintMetrics(v); return &x }},
+		{hintStyleText, func(v int) enum { x := HintStyle(v); return &x }},
+		{lineCapText, func(v int) enum { x := LineCap(v); return &x }},
+		{lineJoinText, func(v int) enum { x := LineJoin(v); return &x }},
+		{operatorText, func(v int) enum { x := Operator(v); return &x }},
+		{subpixelOrderText, func(v int) enum { x := SubpixelOrder(v); return &x }},
+	} {
+		seen := map[string]bool{}
+		for v, name := range test.text.names {
+			if seen[name] {
+				t.Errorf("%s: duplicate name %q", test.text.kind, name)
+			}
+			seen[name] = true
+
+			b, err := test.new(v).MarshalText()
+			if err != nil || string(b) != name {
+				t.Errorf("%s %d: marshaled to %q, %v; want %q", test.text.kind, v, b, err, name)
+			}
+			e := test.new(-1)
+			if err := e.UnmarshalText(b); err != nil {
+				t.Errorf("%s %q: %v", test.text.kind, b, err)
+			}
+			if e.String() != test.new(v).String() {
+				t.Errorf("%s %q: round trip gave %v, want %v", test.text.kind, b, e, test.new(v))
+			}
+			//String is the text encoding, so flag defaults may be Set
+			if got := test.new(v).String(); got != name {
+				t.Errorf("%s %d: String is %q, want %q", test.text.kind, v, got, name)
+			}
+		}
+		if _, err := test.new(-1).MarshalText(); err == nil {
+			t.Errorf("%s: marshaled invalid value", test.text.kind)
+		}
+		if err := test.new(0).UnmarshalText([]byte("no such value")); err == nil {
+			t.Errorf("%s: unmarshaled invalid text", test.text.kind)
+		}
+	}
+}
+
+func TestParseOperator(t *testing.T) {
+	for _, test := range []struct {
+		in   string
+		want Operator
+	}{
+		{"over", OpOver},
+		{"Dest-Over", OpDestOver},
+		{"HSL-LUMINOSITY", OpLuminosityHSL},
+	} {
+		got, err := ParseOperator(test.in)
+		if err != nil || got != test.want {
+			t.Errorf("ParseOperator(%q): got %v, %v; want %v", test.in, got, err, test.want)
+		}
+	}
+	if _, err := ParseOperator("over "); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func ExampleLineCap_UnmarshalText() {
+	var style struct {
+		Cap  LineCap
+		Join LineJoin
+		Op   Operator
+	}
+	err := json.Unmarshal([]byte(`{"Cap": "round", "Join": "bevel", "Op": "multiply"}`), &style)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Println(style.Cap == LineCapRound, style.Join == LineJoinBevel, style.Op == OpMultiply)
+
+	b, err := json.Marshal(style)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Println(string(b))
+	// Output:
+	// true true true
+	// {"Cap":"round","Join":"bevel","Op":"multiply"}
+}
+
+func ExampleFillRule_Set() {
+	fs := flag.NewFlagSet("example", flag.ContinueOnError)
+	rule := FillRuleWinding
+	fs.Var(&rule, "fill-rule", "fill rule: winding or even-odd")
+	if err := fs.Parse([]string{"-fill-rule", "even-odd"}); err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Println(rule == FillRuleEvenOdd)
+	// Output: true
+}
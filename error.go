@@ -6,6 +6,8 @@ import "C"
 
 import (
 	"errors"
+	"fmt"
+	"sync/atomic"
 )
 
 const (
@@ -50,47 +52,223 @@ const (
 	errLastStatus              = C.CAIRO_STATUS_LAST_STATUS
 )
 
-var (
-	//ErrInvalidLibcairoHandle is returned if a Go handle to a libcairo resource
-	//has no pointer to any libcairo resource.
-	ErrInvalidLibcairoHandle = errors.New("invalid handle to libcairo resource")
-	//ErrInvalidPathData is returned if a Path contains undefined data.
-	ErrInvalidPathData = mkerr(errInvalidPathData)
-	//ErrInvalidDash is returned by Context.SetDash if the dash format
-	//is ill-specified.
-	ErrInvalidDash = mkerr(errInvalidDash)
+//Status is an error status reported by libcairo.
+//
+//Every status other than success is a Status constant.
+//Statuses are usually reported wrapped in an *Error, so they should be
+//compared with errors.Is.
+//
+//Originally cairo_status_t.
+type Status int
+
+//Statuses reported by libcairo.
+const (
+	//ErrNoMemory is reported when libcairo could not allocate memory.
+	ErrNoMemory Status = errNoMem
+	//ErrInvalidRestore is reported when Restore is called without a matching Save.
+	ErrInvalidRestore Status = errInvalidRestore
+	//ErrInvalidPopGroup is reported when PopGroup is called without a matching PushGroup.
+	ErrInvalidPopGroup Status = errInvalidPopGroup
+	//ErrNoCurrentPoint is reported when an operation requires a current point and there is none.
+	ErrNoCurrentPoint Status = errNoCurrentPoint
+	//ErrInvalidMatrix is reported when a matrix is not invertible.
+	ErrInvalidMatrix Status = errInvalidMatrix
+	//ErrInvalidStatus is reported when libcairo is given an invalid status.
+	ErrInvalidStatus Status = errInvalidStatus
+	//ErrNullPointer is reported when libcairo is given a nil pointer.
+	ErrNullPointer Status = errNullPointer
+	//ErrInvalidString is reported when a string is not valid UTF-8.
+	ErrInvalidString Status = errInvalidString
+	//ErrInvalidPathData is reported when a Path contains undefined data.
+	ErrInvalidPathData Status = errInvalidPathData
+	//ErrReadError is reported when an error occurs reading from an input stream.
+	ErrReadError Status = errReadError
+	//ErrWriteError is reported when an error occurs writing to an output stream.
+	//If the stream is an io.Writer, its error is reported instead.
+	ErrWriteError Status = errWriteError
+	//ErrSurfaceFinished is reported when drawing to a surface that has been finished.
+	ErrSurfaceFinished Status = errSurfaceFinished
+	//ErrSurfaceTypeMismatch is reported when a surface is the wrong type for an operation.
+	ErrSurfaceTypeMismatch Status = errSurfaceTypeMismatch
+	//ErrPatternTypeMismatch is reported when a pattern is the wrong type for an operation.
+	ErrPatternTypeMismatch Status = errPatternTypeMismatch
+	//ErrInvalidContent is reported when a Content is not valid.
+	ErrInvalidContent Status = errInvalidContent
+	//ErrInvalidFormat is reported when a Format is not valid.
+	ErrInvalidFormat Status = errInvalidFormat
+	//ErrInvalidVisual is reported when an X visual is not valid.
+	ErrInvalidVisual Status = errInvalidVisual
+	//ErrFileNotFound is reported when a file does not exist.
+	ErrFileNotFound Status = errFileNotFound
+	//ErrInvalidDash is reported when a dash pattern is ill-specified.
+	ErrInvalidDash Status = errInvalidDash
+	//ErrInvalidDSCComment is reported when a PostScript DSC comment is not valid.
+	ErrInvalidDSCComment Status = errInvalidDSCComment
+	//ErrInvalidIndex is reported when an index is out of range.
+	ErrInvalidIndex Status = errInvalidIndex
+	//ErrClipNotRepresentable is reported when the clip cannot be represented as a list of rectangles.
+	ErrClipNotRepresentable Status = errClipNotRepresentable
+	//ErrTempFileError is reported when a temporary file cannot be created.
+	ErrTempFileError Status = errTempFileError
+	//ErrInvalidStride is reported when the stride of an image is not valid.
+	ErrInvalidStride Status = errInvalidStride
+	//ErrFontTypeMismatch is reported when a font is the wrong type for an operation.
+	ErrFontTypeMismatch Status = errFontTypeMismatch
+	//ErrUserFontImmutable is reported when modifying a user font that has been used.
+	ErrUserFontImmutable Status = errUserFontImmutable
+	//ErrUserFontError is reported when a user font callback fails.
+	ErrUserFontError Status = errUserFontError
+	//ErrNegativeCount is reported when a count is negative.
+	ErrNegativeCount Status = errNegativeCount
+	//ErrInvalidClusters is reported when text clusters do not match their text and glyphs.
+	ErrInvalidClusters Status = errInvalidClusters
+	//ErrInvalidSlant is reported when a slant is not valid.
+	ErrInvalidSlant Status = errInvalidSlant
+	//ErrInvalidWeight is reported when a weight is not valid.
+	ErrInvalidWeight Status = errInvalidWeight
+	//ErrInvalidSize is reported when a size is not valid.
+	ErrInvalidSize Status = errInvalidSize
+	//ErrUserFontNotImplemented is reported when a user font does not implement a required callback.
+	ErrUserFontNotImplemented Status = errUserFontNotImplemented
+	//ErrDeviceTypeMismatch is reported when a device is the wrong type for an operation.
+	ErrDeviceTypeMismatch Status = errDeviceTypeMismatch
+	//ErrDeviceError is reported when an operation on a device fails.
+	ErrDeviceError Status = errDeviceError
+	//ErrInvalidMeshConstruction is reported when a mesh pattern is constructed incorrectly.
+	ErrInvalidMeshConstruction Status = errInvalidMeshConstruction
+	//ErrDeviceFinished is reported when using a device that has been finished.
+	ErrDeviceFinished Status = errDeviceFinished
 )
 
+//Error returns libcairo's description of s.
+//
+//Originally cairo_status_to_string.
+func (s Status) Error() string {
+	return st2str(s.c())
+}
+
+func (s Status) c() C.cairo_status_t {
+	return C.cairo_status_t(s)
+}
+
+//programming reports whether s indicates a programming error
+//rather than a problem with the input or environment.
+func (s Status) programming() bool {
+	switch s {
+	case ErrInvalidRestore, ErrInvalidPopGroup, ErrNoCurrentPoint, ErrInvalidMatrix, ErrInvalidString, ErrSurfaceFinished:
+		return true
+	}
+	return false
+}
+
+//Error is a Status along with the context in which it was reported.
+type Error struct {
+	//Op is the operation that reported the error, such as
+	//"Context.Restore" or "Surface.Finish".
+	//
+	//Errors are sticky in libcairo, so this is not necessarily the
+	//operation that caused the error:
+	//that may be any earlier operation on the same object.
+	//Op is empty if the error was reported by an Err method,
+	//as the operations that do not return an error cannot be told apart.
+	Op string
+	//Handle describes the libcairo object that the error is on.
+	//It may be empty.
+	Handle string
+	//Status is the status reported by libcairo.
+	Status Status
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Op == "" && e.Handle == "":
+		return "cairo: " + e.Status.Error()
+	case e.Op == "":
+		return "cairo: " + e.Handle + ": " + e.Status.Error()
+	case e.Handle == "":
+		return "cairo: " + e.Op + ": " + e.Status.Error()
+	}
+	return "cairo: " + e.Op + " on " + e.Handle + ": " + e.Status.Error()
+}
+
+//Unwrap returns e.Status, so that errors.Is may compare an *Error
+//with a Status.
+func (e *Error) Unwrap() error {
+	return e.Status
+}
+
+var strict int32
+
+//SetStrict sets whether errors that indicate a programming error, such as
+//ErrInvalidRestore or ErrNoCurrentPoint, cause a panic when reported
+//instead of being returned.
+//
+//Strict mode is off by default.
+//It is useful in tests and during development, but a program that draws
+//from untrusted input, such as a matrix that may be singular,
+//should not use it.
+func SetStrict(on bool) {
+	var v int32
+	if on {
+		v = 1
+	}
+	atomic.StoreInt32(&strict, v)
+}
+
+//Strict reports whether strict mode is on.
+//See SetStrict.
+func Strict() bool {
+	return atomic.LoadInt32(&strict) == 1
+}
+
+//ErrInvalidLibcairoHandle is returned if a Go handle to a libcairo resource
+//has no pointer to any libcairo resource.
+var ErrInvalidLibcairoHandle = errors.New("invalid handle to libcairo resource")
+
 func st2str(st C.cairo_status_t) string {
 	return C.GoString(C.cairo_status_to_string(st))
 }
 
-func mkerr(st C.cairo_status_t) error {
-	return errors.New(st2str(st))
+//handle describes the libcairo object at p, of the given kind,
+//or returns "" if kind is empty.
+func handle(kind string, p interface{}) string {
+	if kind == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s %p", kind, p)
 }
 
-func toerr(st C.cairo_status_t) error {
-	return toerrIded(st, nil)
+//toerr converts st to an error reported by op on the libcairo object at p,
+//of the given kind, or nil if st is success.
+//op is empty when the error is reported by an Err method.
+//
+//The object is only described when there is an error,
+//as toerr is called after nearly every operation.
+func toerr(st C.cairo_status_t, op, kind string, p interface{}) error {
+	return toerrIded(st, op, kind, p, nil)
 }
 
-func toerrIded(st C.cairo_status_t, ider interface {
+//toerrIded is toerr for objects with an id.
+//If st is ErrWriteError and there is a writer registered with ider,
+//the error returned by the writer is reported instead.
+func toerrIded(st C.cairo_status_t, op, kind string, p interface{}, ider interface {
 	id() id
 }) error {
-	switch int(st) {
-	case errSuccess:
+	if st == errSuccess {
 		return nil
-	case errInvalidRestore, errInvalidPopGroup, errNoCurrentPoint, errInvalidMatrix, errInvalidString, errSurfaceFinished:
-		panic(st2str(st))
-	case errInvalidPathData:
-		return ErrInvalidPathData
-	case errInvalidDash:
-		return ErrInvalidDash
-	case errWriteError:
+	}
+	s := Status(st)
+	if s == ErrWriteError && ider != nil {
 		mux.Lock()
-		defer mux.Unlock()
-		if w, ok := wmap[ider.id()]; ok {
+		w, ok := wmap[ider.id()]
+		mux.Unlock()
+		if ok && w.err != nil {
 			return w.err
 		}
 	}
-	return errors.New(st2str(st))
+	err := &Error{Op: op, Handle: handle(kind, p), Status: s}
+	if s.programming() && Strict() {
+		panic(err)
+	}
+	return err
 }
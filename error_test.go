@@ -0,0 +1,92 @@
+package cairo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	p := new(int)
+	h := fmt.Sprintf("context %p", p)
+	if err := toerr(errSuccess, "Context.Restore", "context", p); err != nil {
+		t.Fatalf("success: got %v, want nil", err)
+	}
+	//the handle is only described on error
+	if n := testing.AllocsPerRun(10, func() {
+		toerr(errSuccess, "Context.Restore", "context", p)
+	}); n != 0 {
+		t.Errorf("success: got %v allocations, want 0", n)
+	}
+
+	err := toerr(ErrInvalidMatrix.c(), "Context.Restore", "context", p)
+	if !errors.Is(err, ErrInvalidMatrix) {
+		t.Errorf("errors.Is(%v, ErrInvalidMatrix) is false", err)
+	}
+	if errors.Is(err, ErrNoMemory) {
+		t.Errorf("errors.Is(%v, ErrNoMemory) is true", err)
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("%T is not an *Error", err)
+	}
+	if e.Op != "Context.Restore" || e.Handle != h || e.Status != ErrInvalidMatrix {
+		t.Errorf("got %+v", *e)
+	}
+	if got, want := e.Error(), "cairo: Context.Restore on "+h+": "+ErrInvalidMatrix.Error(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	//errors reported by Err do not name an operation
+	err = toerr(ErrInvalidMatrix.c(), "", "context", p)
+	if got, want := err.Error(), "cairo: "+h+": "+ErrInvalidMatrix.Error(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	defer SetStrict(Strict())
+
+	SetStrict(false)
+	if err := toerr(ErrInvalidRestore.c(), "Context.Restore", "", nil); err == nil {
+		t.Error("expected error")
+	}
+
+	SetStrict(true)
+	//only programming errors panic
+	if err := toerr(ErrNoMemory.c(), "Context.Restore", "", nil); err == nil {
+		t.Error("expected error")
+	}
+	defer func() {
+		r := recover()
+		if err, ok := r.(error); !ok || !errors.Is(err, ErrInvalidRestore) {
+			t.Errorf("got panic %v, want ErrInvalidRestore", r)
+		}
+	}()
+	toerr(ErrInvalidRestore.c(), "Context.Restore", "", nil)
+	t.Error("expected panic")
+}
+
+func TestErrorOp(t *testing.T) {
+	s, err := NewImageSurface(FormatARGB32, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	c, err := New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	var e *Error
+	if err := c.Restore(); !errors.As(err, &e) {
+		t.Fatalf("Restore without Save: got %v, want an *Error", err)
+	}
+	if e.Op != "Context.Restore" || e.Status != ErrInvalidRestore {
+		t.Errorf("got %+v", *e)
+	}
+	if err := c.Err(); !errors.As(err, &e) || e.Op != "" {
+		t.Errorf("Err: got %v", err)
+	}
+}
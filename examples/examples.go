@@ -16,7 +16,7 @@ import (
 	"os"
 
 	"github.com/jimmyfrasche/cairo"
-	"github.com/jimmyfrasche/cairo/pdf"
+	"github.com/jimmyfrasche/cairo/render"
 )
 
 var img image.Image
@@ -132,7 +132,7 @@ var examples = []struct {
 		return nil
 	}},
 	{"clip-image", func(c *cairo.Context) error {
-		i, err := cairo.FromImage(img) //img declared globally and set in main
+		i, err := cairo.FromImage(img, cairo.Pt(1, 1)) //img declared globally and set in main
 		if err != nil {
 			return err
 		}
@@ -363,7 +363,7 @@ var examples = []struct {
 		return nil
 	}},
 	{"image", func(c *cairo.Context) error {
-		i, err := cairo.FromImage(img) //img declared globally and set in main
+		i, err := cairo.FromImage(img, cairo.Pt(1, 1)) //img declared globally and set in main
 		if err != nil {
 			return err
 		}
@@ -384,7 +384,7 @@ var examples = []struct {
 		return nil
 	}},
 	{"image-pattern", func(c *cairo.Context) error {
-		i, err := cairo.FromImage(img) //img declared globally and set in main
+		i, err := cairo.FromImage(img, cairo.Pt(1, 1)) //img declared globally and set in main
 		if err != nil {
 			return err
 		}
@@ -591,36 +591,7 @@ func main() {
 
 	for _, example := range examples {
 		nm := example.Name
-
-		outname := nm + ".pdf"
-
-		outfile, err := os.Create(outname)
-		if err != nil {
-			logerr(nm, err)
-			continue
-		}
-		defer outfile.Close()
-
-		surface, err := pdf.New(outfile, 595, 842) //A4
-		if err != nil {
-			logerr(nm, err)
-			continue
-		}
-		defer surface.Close()
-
-		context, err := cairo.New(surface)
-		if err != nil {
-			logerr(nm, err)
-			continue
-		}
-		defer context.Close()
-
-		if err = example.Run(context); err != nil {
-			logerr(nm, err)
-			continue
-		}
-
-		if err = context.Err(); err != nil {
+		if err := render.File(nm+".pdf", 595, 842, example.Run); err != nil { //A4
 			logerr(nm, err)
 		}
 	}
@@ -0,0 +1,113 @@
+//Package fallback reports the fallback images in PostScript and PDF output.
+//
+//When libcairo draws something that cannot be represented natively by a
+//vector backend, the drawing is rasterized and embedded as a fallback image.
+//Fallback images are the main cause of large file sizes and slow printing
+//times.
+//Libcairo marks each with a comment of the form
+//	% Fallback Image: x=100, y=100, w=299, h=50 res=300dpi size=783750
+//
+//A Writer passes output through to another io.Writer while collecting these
+//comments into a Report.
+//Writers are used by cairo/ps and cairo/pdf, whose surfaces have a
+//FallbackReport method, so that, for example, a test can fail if a change
+//introduces fallback images:
+//	s, err := pdf.New(w, 595, 842)
+//	//draw on s
+//	s.Close()
+//	if r := s.FallbackReport(); len(r) > 0 {
+//		t.Errorf("rasterized %d bytes:\n%s", r.Size(), r)
+//	}
+package fallback
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//Image is a fallback image.
+type Image struct {
+	//Page is the page the image is on, from 1.
+	Page int
+	//Rect is the area of the page covered by the image, in points.
+	Rect cairo.Rectangle
+	//Resolution is the resolution of the image, in pixels per inch.
+	Resolution float64
+	//Size is the size of the image data, in bytes, before compression.
+	Size int64
+}
+
+func (i Image) String() string {
+	return fmt.Sprintf("page %d: %v at %gdpi, %d bytes", i.Page, i.Rect, i.Resolution, i.Size)
+}
+
+//Report is a list of fallback images, in the order they were written.
+type Report []Image
+
+//Size returns the total size of the images in r.
+func (r Report) Size() (n int64) {
+	for _, i := range r {
+		n += i.Size
+	}
+	return n
+}
+
+//Pages returns the pages with fallback images, in order.
+func (r Report) Pages() []int {
+	var pages []int
+	for _, i := range r {
+		if len(pages) == 0 || pages[len(pages)-1] != i.Page {
+			pages = append(pages, i.Page)
+		}
+	}
+	return pages
+}
+
+//String returns the images of r, one per line.
+func (r Report) String() string {
+	var buf bytes.Buffer
+	for _, i := range r {
+		buf.WriteString(i.String())
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+var (
+	num       = `(-?[0-9]+(?:\.[0-9]*)?)`
+	sep       = `,?\s*`
+	commentRE = regexp.MustCompile(`^%\s*Fallback Image:\s*` +
+		`x=` + num + sep + `y=` + num + sep +
+		`w=` + num + sep + `h=` + num + sep +
+		`res=` + num + `\s*dpi` + sep + `size=([0-9]+)`)
+)
+
+//parse returns the Image described by the fallback image comment line,
+//if it is one.
+func parse(line []byte, page int) (Image, bool) {
+	m := commentRE.FindSubmatch(bytes.TrimSpace(line))
+	if m == nil {
+		return Image{}, false
+	}
+	var f [5]float64
+	for i := range f {
+		var err error
+		if f[i], err = strconv.ParseFloat(string(m[i+1]), 64); err != nil {
+			return Image{}, false
+		}
+	}
+	size, err := strconv.ParseInt(string(m[6]), 10, 64)
+	if err != nil {
+		return Image{}, false
+	}
+	return Image{
+		Page:       page,
+		Rect:       cairo.RectWH(f[0], f[1], f[2], f[3]),
+		Resolution: f[4],
+		Size:       size,
+	}, true
+}
@@ -0,0 +1,71 @@
+package fallback_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/fallback"
+	"github.com/jimmyfrasche/cairo/pdf"
+	"github.com/jimmyfrasche/cairo/ps"
+)
+
+//surface is the part of ps.Surface and pdf.Surface used by testSurface.
+type surface interface {
+	cairo.Surface
+	FallbackReport() fallback.Report
+}
+
+//testSurface draws an opaque rectangle on the first page and a translucent
+//rectangle with an operator that no vector backend supports on the second,
+//and checks that only the second is reported as a fallback image.
+func testSurface(t *testing.T, s surface, err error) {
+	if _, ok := err.(*cairo.NotSupportedError); ok {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := cairo.New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.
+		SetSourceColor(cairo.Blue).
+		Rectangle(cairo.RectWH(0, 0, 50, 50)).
+		Fill().
+		ShowPage()
+	r := cairo.RectWH(10, 20, 30, 40)
+	c.
+		SetOperator(cairo.OpXor).
+		SetSourceColor(cairo.Red.Alpha(.5)).
+		Rectangle(r).
+		Fill().
+		ShowPage()
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	report := s.FallbackReport()
+	if len(report) != 1 {
+		t.Fatalf("got %d fallback images, want 1:\n%s", len(report), report)
+	}
+	if img := report[0]; img.Page != 2 || img.Rect != r {
+		t.Errorf("got fallback image %v, want on page 2 at %v", img, r)
+	}
+}
+
+func TestPSSurface(t *testing.T) {
+	var buf bytes.Buffer
+	s, err := ps.New(&buf, 100, 100, false, nil, nil)
+	testSurface(t, s, err)
+}
+
+func TestPDFSurface(t *testing.T) {
+	var buf bytes.Buffer
+	s, err := pdf.New(&buf, 100, 100)
+	testSurface(t, s, err)
+}
@@ -0,0 +1,225 @@
+package fallback
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sync"
+)
+
+//Format is the format of the output scanned by a Writer.
+type Format int
+
+//The formats that may be scanned.
+const (
+	//PS is PostScript, including Encapsulated PostScript.
+	//Pages are counted by their %%Page comments.
+	PS Format = iota
+	//PDF is the Portable Document Format.
+	//Content streams are decompressed to find the comments,
+	//and pages are counted by their content streams,
+	//as the page objects may be compressed in object streams.
+	PDF
+)
+
+//maxLine is the length after which a line is not scanned.
+//The comments are much shorter than this, but PDF dictionaries may not be.
+const maxLine = 4096
+
+var (
+	nameRE   = regexp.MustCompile(`/[^\s/<>\[\]()]+`)
+	streamRE = regexp.MustCompile(`^\s*stream\s*$`)
+	psPage   = []byte("%%Page:")
+	endToken = []byte("endstream")
+)
+
+//Writer is an io.Writer that collects the fallback images in the output
+//written through it.
+//
+//A Writer may be used from multiple goroutines, but the output
+//must be written in order.
+type Writer struct {
+	mu     sync.Mutex
+	w      io.Writer
+	format Format
+	report Report
+
+	line []byte
+	long bool
+	page int
+
+	//PDF state
+	dict     []byte
+	inStream bool
+	flate    bool
+	image    bool
+	stream   []byte
+}
+
+//NewWriter returns a Writer that writes to w and scans the output as f.
+func NewWriter(w io.Writer, f Format) *Writer {
+	return &Writer{
+		w:      w,
+		format: f,
+	}
+}
+
+//Write writes p to the underlying Writer and scans what was written.
+func (w *Writer) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.mu.Lock()
+	w.scan(p[:n])
+	w.mu.Unlock()
+	return n, err
+}
+
+//Report returns the fallback images written so far.
+//
+//The report is only complete after all output has been written,
+//such as after the surface writing to w has been closed.
+func (w *Writer) Report() Report {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append(Report(nil), w.report...)
+}
+
+func (w *Writer) scan(p []byte) {
+	for len(p) > 0 {
+		if w.inStream {
+			p = w.scanStream(p)
+			continue
+		}
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.addLine(p)
+			return
+		}
+		w.addLine(p[:i])
+		p = p[i+1:]
+		if !w.long {
+			w.endLine(w.line)
+		}
+		w.line = w.line[:0]
+		w.long = false
+	}
+}
+
+func (w *Writer) addLine(p []byte) {
+	if w.long {
+		return
+	}
+	if len(w.line)+len(p) > maxLine {
+		w.long = true
+		w.line = w.line[:0]
+		return
+	}
+	w.line = append(w.line, p...)
+}
+
+//endLine handles a complete line outside of any PDF stream.
+func (w *Writer) endLine(line []byte) {
+	if w.format == PS {
+		if bytes.HasPrefix(line, psPage) {
+			w.page++
+		}
+		w.comment(line, w.page)
+		return
+	}
+
+	if streamRE.Match(line) {
+		w.inStream = true
+		w.flate = bytes.Contains(w.dict, []byte("/FlateDecode"))
+		w.image = bytes.Contains(w.dict, []byte("/Image"))
+		if pageContents(w.dict) {
+			w.page++
+		}
+		w.stream = w.stream[:0]
+		w.dict = w.dict[:0]
+		return
+	}
+	if bytes.HasSuffix(bytes.TrimSpace(line), []byte(" obj")) {
+		w.dict = w.dict[:0]
+	}
+	if len(w.dict) < maxLine {
+		w.dict = append(w.dict, line...)
+		w.dict = append(w.dict, '\n')
+	}
+}
+
+//pageContents reports whether dict is the dictionary of the content
+//stream of a page.
+//
+//Libcairo writes a content stream at the start of each page whose
+//dictionary has only a length and filter.
+//Every other stream it writes has a type or other entries,
+//except for those of fonts, which are written after every page.
+func pageContents(dict []byte) bool {
+	for _, name := range nameRE.FindAll(dict, -1) {
+		switch string(name) {
+		case "/Length", "/Filter", "/FlateDecode":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+//scanStream consumes the data of a PDF stream from p,
+//and returns the rest of p after the end of the stream.
+func (w *Writer) scanStream(p []byte) []byte {
+	start := len(w.stream)
+	if start > len(endToken) {
+		start -= len(endToken)
+	} else {
+		start = 0
+	}
+	w.stream = append(w.stream, p...)
+	i := bytes.Index(w.stream[start:], endToken)
+	if i < 0 {
+		if w.image && len(w.stream) > len(endToken) {
+			//image data is not scanned, so only keep enough to find the end
+			w.stream = append(w.stream[:0], w.stream[len(w.stream)-len(endToken):]...)
+		}
+		return nil
+	}
+	i += start
+	rest := p[len(p)-(len(w.stream)-i-len(endToken)):]
+	if !w.image {
+		w.contents(w.stream[:i])
+	}
+	w.inStream = false
+	w.stream = w.stream[:0]
+	return rest
+}
+
+//contents scans the data of a PDF content stream.
+func (w *Writer) contents(data []byte) {
+	if w.flate {
+		r, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		data, err = ioutil.ReadAll(r)
+		if err != nil && len(data) == 0 {
+			return
+		}
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		w.comment(line, w.page)
+	}
+}
+
+func (w *Writer) comment(line []byte, page int) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 || line[0] != '%' {
+		return
+	}
+	if img, ok := parse(line, page); ok {
+		w.report = append(w.report, img)
+	}
+}
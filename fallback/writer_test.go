@@ -0,0 +1,138 @@
+package fallback
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//write writes s to w in chunks of n bytes.
+func write(t *testing.T, w *Writer, s string, n int) {
+	for len(s) > 0 {
+		k := n
+		if k > len(s) {
+			k = len(s)
+		}
+		if _, err := w.Write([]byte(s[:k])); err != nil {
+			t.Fatal(err)
+		}
+		s = s[k:]
+	}
+}
+
+func TestParse(t *testing.T) {
+	img, ok := parse([]byte("% Fallback Image: x=100, y=150, w=350, h=250 res=300dpi size=4560834"), 2)
+	want := Image{2, cairo.RectWH(100, 150, 350, 250), 300, 4560834}
+	if !ok || img != want {
+		t.Errorf("got %v, %v want %v", img, ok, want)
+	}
+	img, ok = parse([]byte("% Fallback Image: x=1.5 y=2 w=3 h=4 res=72.000000dpi size=10"), 1)
+	want = Image{1, cairo.RectWH(1.5, 2, 3, 4), 72, 10}
+	if !ok || img != want {
+		t.Errorf("got %v, %v want %v", img, ok, want)
+	}
+	if _, ok := parse([]byte("%%Page: 1 1"), 1); ok {
+		t.Error("parsed a comment that is not a fallback image")
+	}
+}
+
+const psOut = `%!PS-Adobe-3.0
+%%Pages: (atend)
+%%EndComments
+%%Page: 1 1
+%%BeginPageSetup
+%%EndPageSetup
+0 0 m 10 10 l S
+%%Page: 2 2
+% Fallback Image: x=100, y=100, w=299, h=50 res=300dpi size=783750
+q 299 0 0 50 100 100 cm
+%%Page: 3 3
+% Fallback Image: x=0, y=0, w=10, h=10 res=150dpi size=1000
+% Fallback Image: x=10, y=10, w=10, h=10 res=150dpi size=1000
+%%EOF
+`
+
+func TestWriterPS(t *testing.T) {
+	want := Report{
+		{2, cairo.RectWH(100, 100, 299, 50), 300, 783750},
+		{3, cairo.RectWH(0, 0, 10, 10), 150, 1000},
+		{3, cairo.RectWH(10, 10, 10, 10), 150, 1000},
+	}
+	for _, n := range []int{1, 7, len(psOut)} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, PS)
+		write(t, w, psOut, n)
+		if buf.String() != psOut {
+			t.Errorf("chunks of %d: output changed", n)
+		}
+		if got := w.Report(); !reflect.DeepEqual(got, want) {
+			t.Errorf("chunks of %d: got\n%vwant\n%v", n, got, want)
+		}
+	}
+}
+
+func pdfStream(dict, data string, compress bool) string {
+	if compress {
+		var buf bytes.Buffer
+		z := zlib.NewWriter(&buf)
+		z.Write([]byte(data))
+		z.Close()
+		data = buf.String()
+		dict += " /Filter /FlateDecode"
+	}
+	return fmt.Sprintf("<< %s >>\nstream\n%s\nendstream\nendobj\n", dict, data)
+}
+
+func TestWriterPDF(t *testing.T) {
+	//pages are counted by their content streams, as newer versions of
+	//libcairo compress the page objects in an object stream
+	pages := "<< /Type /Page /Parent 20 0 R >>\n" +
+		"<< /Type /Page /Parent 20 0 R >>\n" +
+		"<< /Type /Page /Parent 20 0 R >>\n" +
+		"<< /Type /Pages /Count 3 >>\n"
+	out := "%PDF-1.5\n" +
+		"1 0 obj\n" + pdfStream("/Length 2 0 R", "q 0 0 m S Q\n", true) +
+		"3 0 obj\n" + pdfStream("/Length 4 0 R", "q\n/x5 Do\nQ\n", true) +
+		"5 0 obj\n" + pdfStream("/Type /XObject /Subtype /Form /BBox [ 0 0 10 10 ] /Length 6 0 R",
+		"q\n% Fallback Image: x=1 y=2 w=3 h=4 res=300dpi size=48\nQ\n", true) +
+		"7 0 obj\n" + pdfStream("/Type /XObject /Subtype /Image", "\x00\n% Fallback Image: x=0 y=0 w=0 h=0 res=1dpi size=1\n", false) +
+		"8 0 obj\n" + pdfStream("/Length 9 0 R", "% Fallback Image: x=5 y=6 w=7 h=8 res=72dpi size=99\n", false) +
+		"10 0 obj\n" + pdfStream("/Type /ObjStm /N 4 /First 20 /Length 11 0 R", pages, true) +
+		"%%EOF\n"
+	want := Report{
+		{2, cairo.RectWH(1, 2, 3, 4), 300, 48},
+		{3, cairo.RectWH(5, 6, 7, 8), 72, 99},
+	}
+	for _, n := range []int{1, 5, len(out)} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, PDF)
+		write(t, w, out, n)
+		if buf.String() != out {
+			t.Errorf("chunks of %d: output changed", n)
+		}
+		if got := w.Report(); !reflect.DeepEqual(got, want) {
+			t.Errorf("chunks of %d: got\n%vwant\n%v", n, got, want)
+		}
+		if w.page != 3 {
+			t.Errorf("chunks of %d: counted %d pages, want 3", n, w.page)
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	r := Report{{Page: 1, Size: 10}, {Page: 1, Size: 5}, {Page: 4, Size: 1}}
+	if s := r.Size(); s != 16 {
+		t.Errorf("Size: got %d, want 16", s)
+	}
+	if p := r.Pages(); !reflect.DeepEqual(p, []int{1, 4}) {
+		t.Errorf("Pages: got %v, want [1 4]", p)
+	}
+	var w *Writer
+	if w.Report() != nil {
+		t.Error("nil Writer has a report")
+	}
+}
@@ -0,0 +1,165 @@
+package cairo
+
+//#cgo pkg-config: cairo
+//#include "weak.h"
+//
+//extern void cairo_ps_surface_create_for_stream(void) __attribute__((weak));
+//extern void cairo_pdf_surface_create_for_stream(void) __attribute__((weak));
+//extern void cairo_svg_surface_create_for_stream(void) __attribute__((weak));
+//extern void cairo_script_create_for_stream(void) __attribute__((weak));
+//extern void cairo_tee_surface_create(void) __attribute__((weak));
+//extern void cairo_ft_font_face_create_for_ft_face(void) __attribute__((weak));
+//
+//enum {
+//	HAS_PS = 1 << 0,
+//	HAS_PDF = 1 << 1,
+//	HAS_SVG = 1 << 2,
+//	HAS_SCRIPT = 1 << 3,
+//	HAS_TEE = 1 << 4,
+//	HAS_FT = 1 << 5,
+//	HAS_MESH = 1 << 6,
+//	HAS_MAP_IMAGE = 1 << 7,
+//	HAS_DEVICE_SCALE = 1 << 8,
+//};
+//
+//static unsigned features(void) {
+//	unsigned f = 0;
+//	if (cairo_ps_surface_create_for_stream) f |= HAS_PS;
+//	if (cairo_pdf_surface_create_for_stream) f |= HAS_PDF;
+//	if (cairo_svg_surface_create_for_stream) f |= HAS_SVG;
+//	if (cairo_script_create_for_stream) f |= HAS_SCRIPT;
+//	if (cairo_tee_surface_create) f |= HAS_TEE;
+//	if (cairo_ft_font_face_create_for_ft_face) f |= HAS_FT;
+//	if (cairo_pattern_create_mesh) f |= HAS_MESH;
+//	if (cairo_surface_map_to_image) f |= HAS_MAP_IMAGE;
+//	if (cairo_surface_set_device_scale) f |= HAS_DEVICE_SCALE;
+//	return f;
+//}
+import "C"
+
+import "strings"
+
+//VersionNumber returns the version of libcairo encoded as an int,
+//suitable for comparison with VersionEncode.
+//
+//Originally cairo_version.
+func VersionNumber() int {
+	return int(C.cairo_version())
+}
+
+//VersionEncode encodes a libcairo version number as an int,
+//suitable for comparison with VersionNumber:
+//	if cairo.VersionNumber() >= cairo.VersionEncode(1, 14, 0) {
+//
+//Originally CAIRO_VERSION_ENCODE.
+func VersionEncode(major, minor, micro int) int {
+	return major*10000 + minor*100 + micro
+}
+
+//Feature is a set of optional parts of libcairo.
+//
+//A feature is either a backend, which libcairo may be compiled without,
+//or a group of functions added in a recent version of libcairo,
+//which may be older at run time than at compile time.
+//
+//A feature is supported if the libcairo that the program is linked against
+//has it, regardless of the headers the program was compiled against.
+type Feature uint
+
+//Optional parts of libcairo.
+const (
+	//FeaturePS is the PostScript backend, used by cairo/ps.
+	FeaturePS Feature = C.HAS_PS
+	//FeaturePDF is the PDF backend, used by cairo/pdf.
+	FeaturePDF Feature = C.HAS_PDF
+	//FeatureSVG is the SVG backend, used by cairo/svg.
+	FeatureSVG Feature = C.HAS_SVG
+	//FeatureScript is the script backend, used by cairo/script.
+	FeatureScript Feature = C.HAS_SCRIPT
+	//FeatureTee is the tee surface, used by cairo/tee.
+	FeatureTee Feature = C.HAS_TEE
+	//FeatureFreeType is the FreeType font backend, used by cairo/harfbuzz.
+	FeatureFreeType Feature = C.HAS_FT
+
+	//FeatureMesh is mesh patterns, used by NewMesh.
+	//It was added in libcairo 1.12.
+	FeatureMesh Feature = C.HAS_MESH
+	//FeatureMapImage is mapping surfaces to images, used by
+	//Surface.MapImage and Surface.CreateSimilarImage.
+	//It was added in libcairo 1.12.
+	FeatureMapImage Feature = C.HAS_MAP_IMAGE
+	//FeatureDeviceScale is the device scale of surfaces, used by
+	//Surface.SetDeviceScale.
+	//It was added in libcairo 1.14.
+	FeatureDeviceScale Feature = C.HAS_DEVICE_SCALE
+)
+
+var featureNames = []struct {
+	f    Feature
+	name string
+}{
+	{FeaturePS, "PS"},
+	{FeaturePDF, "PDF"},
+	{FeatureSVG, "SVG"},
+	{FeatureScript, "script"},
+	{FeatureTee, "tee"},
+	{FeatureFreeType, "FreeType"},
+	{FeatureMesh, "mesh"},
+	{FeatureMapImage, "map image"},
+	{FeatureDeviceScale, "device scale"},
+}
+
+var features = Feature(C.features())
+
+//Features reports the optional parts of libcairo that are supported.
+func Features() Feature {
+	return features
+}
+
+//Has reports whether every feature in g is in f.
+func (f Feature) Has(g Feature) bool {
+	return f&g == g
+}
+
+func (f Feature) String() string {
+	if f == 0 {
+		return "no features"
+	}
+	var names []string
+	for _, n := range featureNames {
+		if f.Has(n.f) {
+			names = append(names, n.name)
+			f &^= n.f
+		}
+	}
+	if f != 0 {
+		names = append(names, "unknown features")
+	}
+	return strings.Join(names, "|")
+}
+
+//NotSupportedError is returned by operations that require a Feature
+//that libcairo does not support.
+type NotSupportedError struct {
+	//Op is the function or method that reported the error.
+	Op string
+	//Feature is the missing feature.
+	Feature Feature
+}
+
+func (e *NotSupportedError) Error() string {
+	return "cairo: " + e.Op + ": " + e.Feature.String() + " not supported by libcairo " + Version()
+}
+
+//XtensionRequire returns a *NotSupportedError for op if libcairo does not
+//support every feature in f.
+//
+//Extensions for optional parts of libcairo should call it before calling
+//into libcairo, and declare the libcairo functions they use weak,
+//so that they still link against a libcairo without those parts.
+func XtensionRequire(op string, f Feature) error {
+	if missing := f &^ features; missing != 0 {
+		return &NotSupportedError{Op: op, Feature: missing}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package cairo
+
+import (
+	"errors"
+	"image"
+	"testing"
+)
+
+func TestFeatureString(t *testing.T) {
+	for _, test := range []struct {
+		f    Feature
+		want string
+	}{
+		{0, "no features"},
+		{FeaturePDF, "PDF"},
+		{FeatureSVG | FeaturePS, "PS|SVG"},
+		{FeatureTee | 1<<20, "tee|unknown features"},
+	} {
+		if got := test.f.String(); got != test.want {
+			t.Errorf("Feature(%d): got %q, want %q", uint(test.f), got, test.want)
+		}
+	}
+}
+
+func TestXtensionRequire(t *testing.T) {
+	defer func(f Feature) { features = f }(features)
+	features = FeaturePS | FeatureSVG
+
+	if !Features().Has(FeaturePS | FeatureSVG) {
+		t.Error("Has reports missing feature")
+	}
+	if Features().Has(FeaturePS | FeaturePDF) {
+		t.Error("Has ignores missing feature")
+	}
+
+	if err := XtensionRequire("svg.New", FeatureSVG); err != nil {
+		t.Errorf("supported feature: %v", err)
+	}
+	err := XtensionRequire("op", FeaturePS|FeaturePDF|FeatureTee)
+	var e *NotSupportedError
+	if !errors.As(err, &e) {
+		t.Fatalf("got %T, want *NotSupportedError", err)
+	}
+	if e.Op != "op" || e.Feature != FeaturePDF|FeatureTee {
+		t.Errorf("got %+v", *e)
+	}
+}
+
+func TestVersionEncode(t *testing.T) {
+	if VersionEncode(1, 12, 16) >= VersionEncode(1, 14, 0) {
+		t.Error("1.12.16 >= 1.14.0")
+	}
+	if got := VersionEncode(1, 14, 2); got != 11402 {
+		t.Errorf("got %d, want 11402", got)
+	}
+}
+
+func TestFunctionFeatures(t *testing.T) {
+	defer func(f Feature) { features = f }(features)
+	features = 0
+
+	var e *NotSupportedError
+	if _, err := NewMesh(&Patch{}); !errors.As(err, &e) || e.Feature != FeatureMesh {
+		t.Errorf("NewMesh: got %v", err)
+	}
+
+	s, err := NewImageSurface(FormatARGB32, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if err := s.SetDeviceScale(Pt(2, 2)); !errors.As(err, &e) || e.Feature != FeatureDeviceScale {
+		t.Errorf("SetDeviceScale: got %v", err)
+	}
+	if got := s.DeviceScale(); got != Pt(1, 1) {
+		t.Errorf("DeviceScale: got %v, want (1, 1)", got)
+	}
+	if _, err := s.MapImage(image.Rect(0, 0, 2, 2)); !errors.As(err, &e) || e.Feature != FeatureMapImage {
+		t.Errorf("MapImage: got %v", err)
+	}
+	if _, err := s.CreateSimilarImage(FormatARGB32, 2, 2); !errors.As(err, &e) || e.Feature != FeatureMapImage {
+		t.Errorf("CreateSimilarImage: got %v", err)
+	}
+}
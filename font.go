@@ -40,7 +40,7 @@ func (f *FontOptions) Close() error {
 	if f == nil || f.fo == nil {
 		return nil
 	}
-	err := f.Err()
+	err := f.err("FontOptions.Close")
 	C.cairo_font_options_destroy(f.fo)
 	f.fo = nil
 	runtime.SetFinalizer(f, nil)
@@ -51,10 +51,15 @@ func (f *FontOptions) Close() error {
 //
 //Originally cairo_font_options_status.
 func (f *FontOptions) Err() error {
+	return f.err("")
+}
+
+//err reports the current error state of f as reported by op.
+func (f *FontOptions) err(op string) error {
 	if f.fo == nil {
 		return ErrInvalidLibcairoHandle
 	}
-	return toerr(C.cairo_font_options_status(f.fo))
+	return toerr(C.cairo_font_options_status(f.fo), op, "font options", f.fo)
 }
 
 //Merge merges non-default options from o into f and return f.
@@ -82,7 +87,7 @@ func (f *FontOptions) Equal(o *FontOptions) bool {
 //SetAntialiasMode sets the antialiasing mode of f and returns f.
 //
 //Originally cairo_font_options_set_antialias.
-func (f *FontOptions) SetAntialiasMode(a antialias) *FontOptions {
+func (f *FontOptions) SetAntialiasMode(a Antialias) *FontOptions {
 	C.cairo_font_options_set_antialias(f.fo, C.cairo_antialias_t(a))
 	return f
 }
@@ -90,14 +95,14 @@ func (f *FontOptions) SetAntialiasMode(a antialias) *FontOptions {
 //AntialiasMode reports the antialiasing mode of f.
 //
 //Originally cairo_font_topns_get_antialias.
-func (f *FontOptions) AntialiasMode() antialias {
-	return antialias(C.cairo_font_options_get_antialias(f.fo))
+func (f *FontOptions) AntialiasMode() Antialias {
+	return Antialias(C.cairo_font_options_get_antialias(f.fo))
 }
 
 //SetSubpixelOrder sets the subpixel ordering of f and returns f.
 //
 //Originally cairo_font_options_set_subpixel_order.
-func (f *FontOptions) SetSubpixelOrder(s subpixelOrder) *FontOptions {
+func (f *FontOptions) SetSubpixelOrder(s SubpixelOrder) *FontOptions {
 	C.cairo_font_options_set_subpixel_order(f.fo, C.cairo_subpixel_order_t(s))
 	return f
 }
@@ -105,14 +110,14 @@ func (f *FontOptions) SetSubpixelOrder(s subpixelOrder) *FontOptions {
 //SubpixelOrder reports the subpixel ordering of f.
 //
 //Originally cairo_font_options_get_subpixel_order.
-func (f *FontOptions) SubpixelOrder() subpixelOrder {
-	return subpixelOrder(C.cairo_font_options_get_subpixel_order(f.fo))
+func (f *FontOptions) SubpixelOrder() SubpixelOrder {
+	return SubpixelOrder(C.cairo_font_options_get_subpixel_order(f.fo))
 }
 
 //SetHintStyle sets the hint style of f and returns f.
 //
 //Originally cairo_font_options_set_hint_style.
-func (f *FontOptions) SetHintStyle(h hintStyle) *FontOptions {
+func (f *FontOptions) SetHintStyle(h HintStyle) *FontOptions {
 	C.cairo_font_options_set_hint_style(f.fo, C.cairo_hint_style_t(h))
 	return f
 }
@@ -120,14 +125,14 @@ func (f *FontOptions) SetHintStyle(h hintStyle) *FontOptions {
 //HintStyle reports the hint style of f.
 //
 //Originally cairo_font_options_get_hint_style.
-func (f *FontOptions) HintStyle() hintStyle {
-	return hintStyle(C.cairo_font_options_get_hint_style(f.fo))
+func (f *FontOptions) HintStyle() HintStyle {
+	return HintStyle(C.cairo_font_options_get_hint_style(f.fo))
 }
 
 //SetHintMetrics sets the hint metrics of f and returns f.
 //
 //Originally cairo_font_options_set_hint_metrics.
-func (f *FontOptions) SetHintMetrics(h hintMetrics) *FontOptions {
+func (f *FontOptions) SetHintMetrics(h HintMetrics) *FontOptions {
 	C.cairo_font_options_set_hint_metrics(f.fo, C.cairo_hint_metrics_t(h))
 	return f
 }
@@ -135,8 +140,8 @@ func (f *FontOptions) SetHintMetrics(h hintMetrics) *FontOptions {
 //HintMetrics reports the hint metrics of f.
 //
 //Originally cairo_font_options_get_hint_metrics.
-func (f *FontOptions) HintMetrics() hintMetrics {
-	return hintMetrics(C.cairo_font_options_get_hint_metrics(f.fo))
+func (f *FontOptions) HintMetrics() HintMetrics {
+	return HintMetrics(C.cairo_font_options_get_hint_metrics(f.fo))
 }
 
 //FontExtents stores metric information for a font.
@@ -333,7 +338,7 @@ func (g Glyph) c() C.cairo_glyph_t {
 //
 //Originally cairo_font_face_t.
 type Font interface {
-	Type() fontType
+	Type() FontType
 	Close() error
 	Err() error
 	Subtype() string
@@ -355,11 +360,11 @@ func XtensionNewFont(f *C.cairo_font_face_t) *XtensionFont {
 	x := &XtensionFont{
 		f: f,
 	}
-	runtime.SetFinalizer(f, (*XtensionFont).Close)
+	runtime.SetFinalizer(x, (*XtensionFont).Close)
 	return x
 }
 
-var cfonttogofont = map[fontType]func(*C.cairo_font_face_t) (Font, error){
+var cfonttogofont = map[FontType]func(*C.cairo_font_face_t) (Font, error){
 	FontTypeToy:  newToyFont,
 	FontTypeUser: userFont,
 }
@@ -373,13 +378,15 @@ var cfonttogofont = map[fontType]func(*C.cairo_font_face_t) (Font, error){
 //for your font.
 //
 //For user fonts you must use XtensionRegisterUserAlienFontSubtype.
-func XtensionRegisterRawToFont(t fontType, f func(*C.cairo_font_face_t) (Font, error)) {
+func XtensionRegisterRawToFont(t FontType, f func(*C.cairo_font_face_t) (Font, error)) {
+	regmux.Lock()
+	defer regmux.Unlock()
 	cfonttogofont[t] = f
 }
 
 func userFont(f *C.cairo_font_face_t) (Font, error) {
 	id := fontGetSubtypeID(f)
-	t, ok := fontsubtypes[id]
+	t, ok := fontSubtype(id)
 	if !ok {
 		panic("user font subtype not registered")
 	}
@@ -394,9 +401,16 @@ func userFont(f *C.cairo_font_face_t) (Font, error) {
 	return F, nil
 }
 
+func fontConverter(t FontType) (func(*C.cairo_font_face_t) (Font, error), bool) {
+	regmux.RLock()
+	defer regmux.RUnlock()
+	f, ok := cfonttogofont[t]
+	return f, ok
+}
+
 func cFont(f *C.cairo_font_face_t) (Font, error) {
-	t := fontType(C.cairo_font_face_get_type(f))
-	fac, ok := cfonttogofont[t]
+	t := FontType(C.cairo_font_face_get_type(f))
+	fac, ok := fontConverter(t)
 	if !ok {
 		panic("No C → Go font converter registered for " + t.String())
 	}
@@ -414,8 +428,8 @@ func cFont(f *C.cairo_font_face_t) (Font, error) {
 //Type reports the type of the font.
 //
 //Originally cairo_font_face_get_type.
-func (f *XtensionFont) Type() fontType {
-	return fontType(C.cairo_font_face_get_type(f.f))
+func (f *XtensionFont) Type() FontType {
+	return FontType(C.cairo_font_face_get_type(f.f))
 }
 
 //Subtype reports the subtype of the font.
@@ -425,7 +439,8 @@ func (f *XtensionFont) Subtype() string {
 	if f.Type() != FontTypeUser {
 		return ""
 	}
-	return fontsubtypes[fontGetSubtypeID(f.f)].name
+	t, _ := fontSubtype(fontGetSubtypeID(f.f))
+	return t.name
 }
 
 //Close frees the resources used by this font.
@@ -435,7 +450,7 @@ func (f *XtensionFont) Close() error {
 	if f == nil || f.f == nil {
 		return nil
 	}
-	err := f.Err()
+	err := f.err("Font.Close")
 	C.cairo_font_face_destroy(f.f)
 	f.f = nil
 	runtime.SetFinalizer(f, nil)
@@ -446,10 +461,15 @@ func (f *XtensionFont) Close() error {
 //
 //Originally cairo_font_face_status.
 func (f *XtensionFont) Err() error {
+	return f.err("")
+}
+
+//err reports the current error state of f as reported by op.
+func (f *XtensionFont) err(op string) error {
 	if f.f == nil {
 		return ErrInvalidLibcairoHandle
 	}
-	return toerr(C.cairo_font_face_status(f.f))
+	return toerr(C.cairo_font_face_status(f.f), op, "font", f.f)
 }
 
 //XtensionRaw return the raw cairo_font_face_t pointer.
@@ -465,12 +485,12 @@ func (f *XtensionFont) XtensionRaw() *C.cairo_font_face_t {
 //See Context.SelectFace for more details.
 type ToyFont struct {
 	*XtensionFont
-	slant  slant
-	weight weight
+	slant  Slant
+	weight Weight
 	family string
 }
 
-func cNewToyFont(f *C.cairo_font_face_t, family string, s slant, w weight) ToyFont {
+func cNewToyFont(f *C.cairo_font_face_t, family string, s Slant, w Weight) ToyFont {
 	return ToyFont{
 		XtensionFont: XtensionNewFont(f),
 		slant:        s,
@@ -481,8 +501,8 @@ func cNewToyFont(f *C.cairo_font_face_t, family string, s slant, w weight) ToyFo
 
 func newToyFont(f *C.cairo_font_face_t) (Font, error) {
 	family := C.GoString(C.cairo_toy_font_face_get_family(f))
-	s := slant(C.cairo_toy_font_face_get_slant(f))
-	w := weight(C.cairo_toy_font_face_get_weight(f))
+	s := Slant(C.cairo_toy_font_face_get_slant(f))
+	w := Weight(C.cairo_toy_font_face_get_weight(f))
 	F := cNewToyFont(f, family, s, w)
 	return F, F.Err()
 }
@@ -495,7 +515,7 @@ func newToyFont(f *C.cairo_font_face_t) (Font, error) {
 //See Context.SelectFont for more details.
 //
 //Originally cairo_toy_font_face_create.
-func NewToyFont(family string, slant slant, weight weight) ToyFont {
+func NewToyFont(family string, slant Slant, weight Weight) ToyFont {
 	s := C.CString(family)
 	f := C.cairo_toy_font_face_create(s, slant.c(), weight.c())
 	C.free(unsafe.Pointer(s))
@@ -515,7 +535,7 @@ type ScaledFont struct {
 
 func cNewScaledFont(f *C.cairo_scaled_font_t) *ScaledFont {
 	s := &ScaledFont{f}
-	runtime.SetFinalizer(f, (*ScaledFont).Close)
+	runtime.SetFinalizer(s, (*ScaledFont).Close)
 	return s
 }
 
@@ -528,20 +548,28 @@ func cNewScaledFont(f *C.cairo_scaled_font_t) *ScaledFont {
 //
 //Originally cairo_scaled_font_create.
 func NewScaledFont(f Font, fontMatrix, CTM Matrix, opts *FontOptions) (*ScaledFont, error) {
-
+	if opts == nil {
+		opts = NewFontOptions()
+		defer opts.Close()
+	}
 	s := C.cairo_scaled_font_create(f.XtensionRaw(), &fontMatrix.m, &CTM.m, opts.fo)
 	S := cNewScaledFont(s)
-	return S, S.Err()
+	return S, S.err("NewScaledFont")
 }
 
 //Err reports any error on s.
 //
 //Originally cairo_scaled_font_status.
 func (s *ScaledFont) Err() error {
+	return s.err("")
+}
+
+//err reports the current error state of s as reported by op.
+func (s *ScaledFont) err(op string) error {
 	if s.f == nil {
 		return ErrInvalidLibcairoHandle
 	}
-	return toerr(C.cairo_scaled_font_status(s.f))
+	return toerr(C.cairo_scaled_font_status(s.f), op, "scaled font", s.f)
 }
 
 //Close frees the resources of s.
@@ -551,9 +579,10 @@ func (s *ScaledFont) Close() error {
 	if s == nil || s.f == nil {
 		return nil
 	}
-	err := s.Err()
+	err := s.err("ScaledFont.Close")
 	runtime.SetFinalizer(s, nil)
 	C.cairo_scaled_font_destroy(s.f)
+	s.f = nil
 	return err
 }
 
@@ -562,6 +591,111 @@ func (s *ScaledFont) Font() (Font, error) {
 	return cFont(C.cairo_scaled_font_get_font_face(s.f))
 }
 
+//Extents reports the extents of s.
+//
+//Originally cairo_scaled_font_extents.
+func (s *ScaledFont) Extents() FontExtents {
+	var f C.cairo_font_extents_t
+	C.cairo_scaled_font_extents(s.f, &f)
+	return XtensionFontExtentsCtoGo(f)
+}
+
+//TextExtents reports the extents of the glyphs of s, as if drawn by
+//Context.ShowText with s set as the scaled font.
+//
+//See Context.TextExtents for the meaning of the returned extents.
+//
+//Originally cairo_scaled_font_text_extents.
+func (s *ScaledFont) TextExtents(str string) TextExtents {
+	var t C.cairo_text_extents_t
+	cs := C.CString(str)
+	C.cairo_scaled_font_text_extents(s.f, cs, &t)
+	C.free(unsafe.Pointer(cs))
+	return XtensionNewTextExtents(t)
+}
+
+//GlyphExtents reports the extents of glyphs, as if drawn by
+//Context.ShowGlyphs with s set as the scaled font.
+//
+//See Context.GlyphExtents for the meaning of the returned extents.
+//
+//Originally cairo_scaled_font_glyph_extents.
+func (s *ScaledFont) GlyphExtents(glyphs []Glyph) TextExtents {
+	var t C.cairo_text_extents_t
+	gs, n := XtensionGlyphsGotoC(glyphs, false)
+	C.cairo_scaled_font_glyph_extents(s.f, gs, n, &t)
+	C.free(unsafe.Pointer(gs))
+	return XtensionNewTextExtents(t)
+}
+
+//TextToGlyphs converts str into glyphs positioned as they would be
+//by Context.ShowText with the first glyph at origin, along with
+//the clusters mapping the bytes of str to the glyphs.
+//
+//The returned values may be passed directly to Context.ShowTextGlyphs.
+//
+//Originally cairo_scaled_font_text_to_glyphs.
+func (s *ScaledFont) TextToGlyphs(origin Point, str string) (glyphs []Glyph, clusters []TextCluster, flags TextClusterFlags, err error) {
+	var (
+		gs     *C.cairo_glyph_t
+		ts     *C.cairo_text_cluster_t
+		gn, tn C.int
+		f      C.cairo_text_cluster_flags_t
+	)
+	x, y := origin.c()
+	cs := C.CString(str)
+	defer C.free(unsafe.Pointer(cs))
+	st := C.cairo_scaled_font_text_to_glyphs(s.f, x, y, cs, C.int(len(str)), &gs, &gn, &ts, &tn, &f)
+	if err = toerr(st, "ScaledFont.TextToGlyphs", "scaled font", s.f); err != nil {
+		return nil, nil, 0, err
+	}
+	glyphs = XtensionGlyphsCtoGo(gs, gn)
+	clusters = XtensionTextClustersCtoGo(ts, tn)
+	C.cairo_glyph_free(gs)
+	C.cairo_text_cluster_free(ts)
+	return glyphs, clusters, TextClusterFlags(f), nil
+}
+
+//FontMatrix returns the font matrix s was created with.
+//
+//Originally cairo_scaled_font_get_font_matrix.
+func (s *ScaledFont) FontMatrix() Matrix {
+	var m Matrix
+	C.cairo_scaled_font_get_font_matrix(s.f, &m.m)
+	return m
+}
+
+//CTM returns the coordinate transformation matrix s was created with.
+//
+//Originally cairo_scaled_font_get_ctm.
+func (s *ScaledFont) CTM() Matrix {
+	var m Matrix
+	C.cairo_scaled_font_get_ctm(s.f, &m.m)
+	return m
+}
+
+//ScaleMatrix returns the scale matrix of s.
+//
+//The scale matrix is the product of the font matrix and the CTM
+//associated with the scaled font, and hence is the matrix mapping
+//from font space to device space.
+//
+//Originally cairo_scaled_font_get_scale_matrix.
+func (s *ScaledFont) ScaleMatrix() Matrix {
+	var m Matrix
+	C.cairo_scaled_font_get_scale_matrix(s.f, &m.m)
+	return m
+}
+
+//FontOptions returns the font options s was created with.
+//
+//Originally cairo_scaled_font_get_font_options.
+func (s *ScaledFont) FontOptions() *FontOptions {
+	f := NewFontOptions()
+	C.cairo_scaled_font_get_font_options(s.f, f.fo)
+	return f
+}
+
 //XtensionRaw returns the underlying C value of s.
 func (s *ScaledFont) XtensionRaw() *C.cairo_scaled_font_t {
 	return s.f
@@ -0,0 +1,86 @@
+package cairo
+
+import (
+	"testing"
+)
+
+func TestScaledFont(t *testing.T) {
+	font := NewToyFont("sans-serif", SlantNormal, WeightNormal)
+	defer font.Close()
+
+	fm, ctm := NewScaleMatrix(Pt(12, 14)), NewScaleMatrix(Pt(2, 3))
+	opts := NewFontOptions().SetHintMetrics(HintMetricsOff)
+	defer opts.Close()
+	sf, err := NewScaledFont(font, fm, ctm, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sf.Close()
+
+	if got := sf.FontMatrix(); got != fm {
+		t.Errorf("FontMatrix: got %v, want %v", got, fm)
+	}
+	if got := sf.CTM(); got != ctm {
+		t.Errorf("CTM: got %v, want %v", got, ctm)
+	}
+	if got, want := sf.ScaleMatrix(), fm.Mul(ctm); got != want {
+		t.Errorf("ScaleMatrix: got %v, want %v", got, want)
+	}
+	fo := sf.FontOptions()
+	defer fo.Close()
+	if !fo.Equal(opts) {
+		t.Error("FontOptions: not the options the scaled font was created with")
+	}
+
+	fe := sf.Extents()
+	if fe.Ascent <= 0 || fe.Height <= 0 {
+		t.Errorf("Extents: got ascent %v and height %v, want both positive", fe.Ascent, fe.Height)
+	}
+
+	short, long := sf.TextExtents("Hi"), sf.TextExtents("Hi, world")
+	if short.AdvanceX <= 0 || long.AdvanceX <= short.AdvanceX {
+		t.Errorf("TextExtents: advance of longer text %v not greater than %v", long.AdvanceX, short.AdvanceX)
+	}
+
+	const text = "Hi, wörld"
+	glyphs, clusters, _, err := sf.TextToGlyphs(Pt(5, fe.Ascent), text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(glyphs) == 0 {
+		t.Fatal("TextToGlyphs: no glyphs")
+	}
+	if glyphs[0].Point != Pt(5, fe.Ascent) {
+		t.Errorf("TextToGlyphs: first glyph at %v, want origin %v", glyphs[0].Point, Pt(5, fe.Ascent))
+	}
+	var bytes, n int
+	for _, c := range clusters {
+		bytes += c.RuneLength
+		n += c.NumGlyphs
+	}
+	if bytes != len(text) {
+		t.Errorf("TextToGlyphs: clusters cover %d bytes, want %d", bytes, len(text))
+	}
+	if n != len(glyphs) {
+		t.Errorf("TextToGlyphs: clusters cover %d glyphs, want %d", n, len(glyphs))
+	}
+	if ge, te := sf.GlyphExtents(glyphs), sf.TextExtents(text); ge.Width != te.Width {
+		t.Errorf("GlyphExtents: got width %v, want TextExtents width %v", ge.Width, te.Width)
+	}
+
+	//nil options are the default options
+	def, err := NewScaledFont(font, fm, ctm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer def.Close()
+	dfo, want := def.FontOptions(), NewFontOptions()
+	defer dfo.Close()
+	defer want.Close()
+	if !dfo.Equal(want) {
+		t.Error("FontOptions of scaled font created with nil options: not the default options")
+	}
+	if got := def.Extents(); got.Height <= 0 {
+		t.Errorf("Extents with nil options: got height %v", got.Height)
+	}
+}
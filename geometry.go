@@ -3,6 +3,7 @@ package cairo
 import "C"
 
 import (
+	"image"
 	"math"
 	"strconv"
 )
@@ -131,7 +132,7 @@ func (p Point) In(r Rectangle) bool {
 
 //InCirc reports whether p falls in c.
 func (p Point) InCirc(c Circle) bool {
-	return p.Near(c.Center, c.Radius)
+	return p.Sub(c.Center).Mag() < c.Radius
 }
 
 //Mod returns the point q in r such that p.X-q.X is a multiple
@@ -244,7 +245,7 @@ func (r Rectangle) Intersect(s Rectangle) Rectangle {
 	if r.Min.Y < s.Min.Y {
 		r.Min.Y = s.Min.Y
 	}
-	if r.Max.X > s.Max.Y {
+	if r.Max.X > s.Max.X {
 		r.Max.X = s.Max.X
 	}
 	if r.Max.Y > s.Max.Y {
@@ -256,6 +257,66 @@ func (r Rectangle) Intersect(s Rectangle) Rectangle {
 	return r
 }
 
+//Union returns the smallest rectangle that contains both r and s.
+//
+//Empty rectangles are ignored.
+func (r Rectangle) Union(s Rectangle) Rectangle {
+	if r.Empty() {
+		return s
+	}
+	if s.Empty() {
+		return r
+	}
+	return Rectangle{
+		Pt(math.Min(r.Min.X, s.Min.X), math.Min(r.Min.Y, s.Min.Y)),
+		Pt(math.Max(r.Max.X, s.Max.X), math.Max(r.Max.Y, s.Max.Y)),
+	}
+}
+
+//Inset returns the rectangle r inset by n, which may be negative.
+//
+//If either of r's dimensions is less than 2*n, the returned rectangle
+//is collapsed to the center of r in that dimension.
+func (r Rectangle) Inset(n float64) Rectangle {
+	c := r.Center()
+	if r.Dx() < 2*n {
+		r.Min.X, r.Max.X = c.X, c.X
+	} else {
+		r.Min.X += n
+		r.Max.X -= n
+	}
+	if r.Dy() < 2*n {
+		r.Min.Y, r.Max.Y = c.Y, c.Y
+	} else {
+		r.Min.Y += n
+		r.Max.Y -= n
+	}
+	return r
+}
+
+//Outset returns the rectangle r outset by n.
+//
+//Outset(n) is Inset(-n).
+func (r Rectangle) Outset(n float64) Rectangle {
+	return r.Inset(-n)
+}
+
+//Center returns the point at the center of r.
+func (r Rectangle) Center() Point {
+	return r.Min.Add(r.Max).Div(2)
+}
+
+//Contains reports whether p is within ε of r, including r's boundary.
+//
+//Unlike p.In(r), Contains is suitable for testing points that are the result
+//of floating point calculations.
+func (r Rectangle) Contains(p Point, ε float64) bool {
+	return r.Min.X-ε <= p.X &&
+		p.X <= r.Max.X+ε &&
+		r.Min.Y-ε <= p.Y &&
+		p.Y <= r.Max.Y+ε
+}
+
 //Empty reports whether the rectangle contains no points.
 func (r Rectangle) Empty() bool {
 	return r.Min.X >= r.Max.X || r.Min.Y >= r.Max.Y
@@ -263,8 +324,8 @@ func (r Rectangle) Empty() bool {
 
 //Overlaps reports whether r and s have a non-empty intersection.
 func (r Rectangle) Overlaps(s Rectangle) bool {
-	return r.Min.X > s.Max.X &&
-		s.Min.X > r.Max.X &&
+	return r.Min.X < s.Max.X &&
+		s.Min.X < r.Max.X &&
 		r.Min.Y < s.Max.Y &&
 		s.Min.Y < r.Max.Y
 }
@@ -293,6 +354,65 @@ func (r Rectangle) Canon() Rectangle {
 	return r
 }
 
+//Rounding modes for Rectangle.ToImageRect.
+const (
+	//RoundOut returns the smallest integer rectangle containing
+	//the rectangle.
+	RoundOut Rounding = iota
+	//RoundIn returns the largest integer rectangle contained by
+	//the rectangle.
+	RoundIn
+	//RoundNearest rounds each coordinate to the nearest integer,
+	//with halves rounded away from zero.
+	RoundNearest
+)
+
+//Rounding is a mode for rounding a Rectangle to an image.Rectangle.
+type Rounding int
+
+func (r Rounding) String() string {
+	switch r {
+	case RoundOut:
+		return "RoundOut"
+	case RoundIn:
+		return "RoundIn"
+	case RoundNearest:
+		return "RoundNearest"
+	}
+	return "unknown rounding mode"
+}
+
+func (r Rounding) round(min, max float64) (int, int) {
+	switch r {
+	case RoundIn:
+		min, max = math.Ceil(min), math.Floor(max)
+		if max < min {
+			max = min
+		}
+	case RoundNearest:
+		min, max = math.Round(min), math.Round(max)
+	default:
+		min, max = math.Floor(min), math.Ceil(max)
+	}
+	return int(min), int(max)
+}
+
+//FromImageRect returns the Rectangle with the same coordinates as r.
+func FromImageRect(r image.Rectangle) Rectangle {
+	return Rect(float64(r.Min.X), float64(r.Min.Y), float64(r.Max.X), float64(r.Max.Y))
+}
+
+//ToImageRect returns the integer rectangle derived from r by rounding
+//with mode.
+//
+//If mode is RoundIn and no integer rectangle fits in r in a dimension,
+//the returned rectangle is empty in that dimension.
+func (r Rectangle) ToImageRect(mode Rounding) image.Rectangle {
+	x0, x1 := mode.round(r.Min.X, r.Max.X)
+	y0, y1 := mode.round(r.Min.Y, r.Max.Y)
+	return image.Rect(x0, y0, x1, y1)
+}
+
 //A Circle contains the points swept out by Radius from Center.
 //
 //It is well-formed if the Radius is nonnegative.
@@ -351,3 +471,68 @@ func (c Circle) Mul(k float64) Circle {
 func (c Circle) Empty() bool {
 	return c.Radius == 0
 }
+
+//Bounds returns the smallest rectangle containing c.
+func (c Circle) Bounds() Rectangle {
+	r := Pt(c.Radius, c.Radius)
+	return Rectangle{c.Center.Sub(r), c.Center.Add(r)}.Canon()
+}
+
+//PointAt returns the point on the circumference of c at the angle radians.
+func (c Circle) PointAt(radians float64) Point {
+	s, co := math.Sincos(radians)
+	return c.Center.Add(Pt(co, s).Mul(c.Radius))
+}
+
+//Overlaps reports whether c and d have a non-empty intersection.
+func (c Circle) Overlaps(d Circle) bool {
+	return c.Center.Sub(d.Center).Mag() < c.Radius+d.Radius
+}
+
+//Intersections returns the points where the circumferences
+//of c and d intersect.
+//
+//There are zero, one, or two points.
+//If c and d are the same circle, no points are returned.
+func (c Circle) Intersections(d Circle) []Point {
+	v := d.Center.Sub(c.Center)
+	dist := v.Mag()
+	if dist == 0 || dist > c.Radius+d.Radius || dist < math.Abs(c.Radius-d.Radius) {
+		return nil
+	}
+	//a is the distance from c's center to the chord between the points.
+	a := (c.Radius*c.Radius - d.Radius*d.Radius + dist*dist) / (2 * dist)
+	h2 := c.Radius*c.Radius - a*a
+	mid := c.Center.Add(v.Mul(a / dist))
+	if h2 <= 0 {
+		return []Point{mid}
+	}
+	h := math.Sqrt(h2) / dist
+	off := Pt(-v.Y*h, v.X*h)
+	return []Point{mid.Add(off), mid.Sub(off)}
+}
+
+//Tangents returns the points on the circumference of c where a line
+//through p would be tangent to c.
+//
+//If p is outside c, there are two points and ok is true.
+//If p is on the circumference of c, both points are p and ok is true.
+//Otherwise ok is false.
+func (c Circle) Tangents(p Point) (t1, t2 Point, ok bool) {
+	v := p.Sub(c.Center)
+	dist := v.Mag()
+	if dist < c.Radius || dist == 0 {
+		return ZP, ZP, false
+	}
+	//the points of tangency form a right triangle with c's center and p.
+	θ := math.Acos(c.Radius / dist)
+	φ := v.Angle()
+	return c.PointAt(φ + θ), c.PointAt(φ - θ), true
+}
+
+//TangentAt returns the unit vector tangent to the circumference of c
+//at the angle radians, pointing in the direction of increasing angle.
+func (c Circle) TangentAt(radians float64) Point {
+	s, co := math.Sincos(radians)
+	return Pt(-s, co)
+}
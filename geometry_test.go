@@ -0,0 +1,140 @@
+package cairo
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+func TestRectangle(t *testing.T) {
+	r, s := Rect(0, 0, 10, 10), Rect(5, 5, 20, 15)
+
+	if got, want := r.Intersect(s), Rect(5, 5, 10, 10); got != want {
+		t.Errorf("Intersect: got %v, want %v", got, want)
+	}
+	if got := r.Intersect(Rect(11, 11, 12, 12)); got != ZR {
+		t.Errorf("Intersect disjoint: got %v", got)
+	}
+	if !r.Overlaps(s) || r.Overlaps(Rect(10, 0, 20, 10)) {
+		t.Error("Overlaps")
+	}
+	if got, want := r.Union(s), Rect(0, 0, 20, 15); got != want {
+		t.Errorf("Union: got %v, want %v", got, want)
+	}
+	if got := r.Union(ZR); got != r {
+		t.Errorf("Union with empty: got %v, want %v", got, r)
+	}
+	if got, want := r.Inset(2), Rect(2, 2, 8, 8); got != want {
+		t.Errorf("Inset: got %v, want %v", got, want)
+	}
+	if got, want := r.Inset(6), Rect(5, 5, 5, 5); got != want {
+		t.Errorf("Inset too far: got %v, want %v", got, want)
+	}
+	if got, want := r.Outset(1), Rect(-1, -1, 11, 11); got != want {
+		t.Errorf("Outset: got %v, want %v", got, want)
+	}
+	if got, want := s.Center(), Pt(12.5, 10); got != want {
+		t.Errorf("Center: got %v, want %v", got, want)
+	}
+	if !r.Contains(Pt(10, 10), 0) || !r.Contains(Pt(10.05, 0), .1) || r.Contains(Pt(10.2, 0), .1) {
+		t.Error("Contains")
+	}
+
+	m := NewRotateMatrix(math.Pi / 2)
+	if got, want := r.Transform(m), Rect(-10, 0, 0, 10); !got.Min.Near(want.Min, 1e-9) || !got.Max.Near(want.Max, 1e-9) {
+		t.Errorf("Transform: got %v, want %v", got, want)
+	}
+}
+
+func TestImageRect(t *testing.T) {
+	r := Rect(.5, 1.2, 3.5, 4.7)
+	for _, tc := range []struct {
+		mode Rounding
+		want image.Rectangle
+	}{
+		{RoundOut, image.Rect(0, 1, 4, 5)},
+		{RoundIn, image.Rect(1, 2, 3, 4)},
+		{RoundNearest, image.Rect(1, 1, 4, 5)},
+	} {
+		if got := r.ToImageRect(tc.mode); got != tc.want {
+			t.Errorf("%v: got %v, want %v", tc.mode, got, tc.want)
+		}
+	}
+	if got := Rect(.2, .2, .8, .8).ToImageRect(RoundIn); !got.Empty() {
+		t.Errorf("RoundIn of small rectangle: got %v, want empty", got)
+	}
+
+	ir := image.Rect(1, 2, 3, 4)
+	if got := FromImageRect(ir); got != Rect(1, 2, 3, 4) {
+		t.Errorf("FromImageRect: got %v", got)
+	}
+	if got := FromImageRect(ir).ToImageRect(RoundNearest); got != ir {
+		t.Errorf("round trip: got %v, want %v", got, ir)
+	}
+}
+
+func TestCircle(t *testing.T) {
+	c := Circ(0, 0, 5)
+
+	if got, want := c.Add(Pt(1, 1)).Bounds(), Rect(-4, -4, 6, 6); got != want {
+		t.Errorf("Bounds: got %v, want %v", got, want)
+	}
+	if !Pt(3, 3).InCirc(c) || Pt(4, 4).InCirc(c) {
+		t.Error("InCirc")
+	}
+
+	pts := c.Intersections(Circ(8, 0, 5))
+	if len(pts) != 2 || !pts[0].Near(Pt(4, 3), 1e-9) || !pts[1].Near(Pt(4, -3), 1e-9) {
+		t.Errorf("Intersections: got %v", pts)
+	}
+	if pts := c.Intersections(Circ(10, 0, 5)); len(pts) != 1 || !pts[0].Near(Pt(5, 0), 1e-9) {
+		t.Errorf("Intersections touching: got %v", pts)
+	}
+	if pts := c.Intersections(Circ(20, 0, 5)); len(pts) != 0 {
+		t.Errorf("Intersections disjoint: got %v", pts)
+	}
+	if !c.Overlaps(Circ(8, 0, 5)) || c.Overlaps(Circ(10, 0, 5)) {
+		t.Error("Overlaps")
+	}
+
+	t1, t2, ok := c.Tangents(Pt(0, 10))
+	if !ok {
+		t.Fatal("Tangents: not ok")
+	}
+	for _, tp := range []Point{t1, t2} {
+		if math.Abs(tp.Mag()-5) > 1e-9 {
+			t.Errorf("tangent point %v not on circle", tp)
+		}
+		//the radius is perpendicular to the tangent line
+		if d := tp.Dot(Pt(0, 10).Sub(tp)); math.Abs(d) > 1e-9 {
+			t.Errorf("tangent point %v: not tangent", tp)
+		}
+	}
+	if _, _, ok := c.Tangents(Pt(1, 1)); ok {
+		t.Error("Tangents from inside circle")
+	}
+	if got := c.TangentAt(0); !got.Near(Pt(0, 1), 1e-9) {
+		t.Errorf("TangentAt: got %v", got)
+	}
+}
+
+func TestGeometryRegressions(t *testing.T) {
+	//Intersect clipped Max.X against s.Max.Y
+	if got, want := Rect(0, 0, 10, 10).Intersect(Rect(0, 0, 5, 20)), Rect(0, 0, 5, 10); got != want {
+		t.Errorf("Intersect: got %v, want %v", got, want)
+	}
+	//Overlaps required the rectangles to be disjoint in x
+	if !Rect(0, 0, 10, 10).Overlaps(Rect(5, 5, 15, 15)) {
+		t.Error("Overlaps: overlapping rectangles reported disjoint")
+	}
+	if Rect(0, 0, 10, 10).Overlaps(Rect(20, 0, 30, 10)) {
+		t.Error("Overlaps: disjoint rectangles reported overlapping")
+	}
+	//InCirc tested against the square circumscribing the circle
+	if Pt(4, 4).InCirc(Circ(0, 0, 5)) {
+		t.Error("InCirc: corner of circumscribing square reported in circle")
+	}
+	if !Pt(0, 4.9).InCirc(Circ(0, 0, 5)) {
+		t.Error("InCirc: point in circle reported outside")
+	}
+}
@@ -0,0 +1,367 @@
+package harfbuzz
+
+import (
+	"unicode"
+)
+
+//bidiClass is a bidirectional character type, as defined by UAX #9.
+//The explicit formatting types are not distinguished and are treated as BN.
+type bidiClass int
+
+const (
+	bidiL   bidiClass = iota //left to right
+	bidiR                    //right to left
+	bidiAL                   //Arabic letter
+	bidiEN                   //European number
+	bidiES                   //European number separator
+	bidiET                   //European number terminator
+	bidiAN                   //Arabic number
+	bidiCS                   //common number separator
+	bidiNSM                  //nonspacing mark
+	bidiBN                   //boundary neutral
+	bidiB                    //paragraph separator
+	bidiS                    //segment separator
+	bidiWS                   //whitespace
+	bidiON                   //other neutral
+)
+
+//rtlScripts are scripts whose letters are strong right to left.
+var rtlScripts = []*unicode.RangeTable{
+	unicode.Hebrew,
+	unicode.Samaritan,
+	unicode.Mandaic,
+	unicode.Nko,
+	unicode.Imperial_Aramaic,
+	unicode.Phoenician,
+	unicode.Kharoshthi,
+	unicode.Old_South_Arabian,
+	unicode.Avestan,
+	unicode.Inscriptional_Parthian,
+	unicode.Inscriptional_Pahlavi,
+	unicode.Old_Turkic,
+	unicode.Meroitic_Cursive,
+	unicode.Meroitic_Hieroglyphs,
+}
+
+//alScripts are scripts whose letters are Arabic letters.
+var alScripts = []*unicode.RangeTable{
+	unicode.Arabic,
+	unicode.Syriac,
+	unicode.Thaana,
+}
+
+func bidiClassOf(r rune) bidiClass {
+	switch r {
+	case '\n', '\r', 0x1c, 0x1d, 0x1e, 0x85, 0x2029:
+		return bidiB
+	case '\t', 0x0b, 0x1f:
+		return bidiS
+	case '\f', 0x2028:
+		return bidiWS
+	case 0x200e: //LRM
+		return bidiL
+	case 0x200f: //RLM
+		return bidiR
+	case 0x061c: //ALM
+		return bidiAL
+	case '+', '-', 0x207a, 0x207b, 0x208a, 0x208b, 0x2212, 0xfb29, 0xfe62, 0xfe63, 0xff0b, 0xff0d:
+		return bidiES
+	case '#', '%', 0xb0, 0xb1, 0x2030, 0x2031, 0x2032, 0x2033, 0x2034, 0x066a:
+		return bidiET
+	case ',', '.', '/', ':', 0xa0, 0x060c, 0x202f, 0x2044, 0xfe50, 0xfe52, 0xfe55, 0xff0c, 0xff0e, 0xff0f, 0xff1a:
+		return bidiCS
+	case 0x066b, 0x066c: //Arabic decimal and thousands separators
+		return bidiAN
+	}
+	switch {
+	case 0x0660 <= r && r <= 0x0669, 0x0600 <= r && r <= 0x0605, r == 0x06dd:
+		return bidiAN
+	case unicode.Is(unicode.Nd, r), unicode.Is(unicode.No, r) && unicode.Is(unicode.Common, r):
+		return bidiEN
+	case unicode.Is(unicode.Sc, r):
+		return bidiET
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return bidiNSM
+	case unicode.Is(unicode.Cf, r), unicode.Is(unicode.Cc, r):
+		return bidiBN
+	case unicode.Is(unicode.Zs, r):
+		return bidiWS
+	case unicode.In(r, alScripts...):
+		if unicode.In(r, unicode.L, unicode.Mc) {
+			return bidiAL
+		}
+	case unicode.In(r, rtlScripts...):
+		if unicode.In(r, unicode.L, unicode.Mc, unicode.N) {
+			return bidiR
+		}
+	}
+	if unicode.In(r, unicode.L, unicode.Mc, unicode.N) {
+		return bidiL
+	}
+	return bidiON
+}
+
+//bidiLevels returns the resolved embedding level of each rune of rs,
+//and the paragraph level.
+//
+//This implements rules P2–P3, W1–W7, N1–N2, I1–I2, and L1 of the Unicode
+//bidirectional algorithm, UAX #9, for a paragraph with no explicit
+//embeddings, overrides, or isolates.
+//Paragraph separators are treated as segment separators,
+//as the text is considered to be a single line.
+func bidiLevels(rs []rune, dir direction) (levels []int, base int) {
+	cls := make([]bidiClass, len(rs))
+	for i, r := range rs {
+		cls[i] = bidiClassOf(r)
+	}
+	orig := append([]bidiClass(nil), cls...)
+
+	//P2, P3
+	switch dir {
+	case DirectionRTL:
+		base = 1
+	case DirectionAuto:
+		for _, c := range cls {
+			if c == bidiL {
+				break
+			}
+			if c == bidiR || c == bidiAL {
+				base = 1
+				break
+			}
+		}
+	}
+	sos := bidiL
+	if base == 1 {
+		sos = bidiR
+	}
+
+	//W1, with BN following the rule for NSM (X9)
+	prev := sos
+	for i, c := range cls {
+		if c == bidiNSM || c == bidiBN {
+			cls[i] = prev
+		} else {
+			prev = c
+		}
+	}
+	//W2, W3
+	strong := sos
+	for i, c := range cls {
+		switch c {
+		case bidiL, bidiR, bidiAL:
+			strong = c
+		case bidiEN:
+			if strong == bidiAL {
+				cls[i] = bidiAN
+			}
+		}
+		if c == bidiAL {
+			cls[i] = bidiR
+		}
+	}
+	//W4
+	for i := 1; i+1 < len(cls); i++ {
+		a, c, b := cls[i-1], cls[i], cls[i+1]
+		switch {
+		case c == bidiES && a == bidiEN && b == bidiEN:
+			cls[i] = bidiEN
+		case c == bidiCS && a == b && (a == bidiEN || a == bidiAN):
+			cls[i] = a
+		}
+	}
+	//W5
+	for i := 0; i < len(cls); {
+		if cls[i] != bidiET {
+			i++
+			continue
+		}
+		j := i
+		for j < len(cls) && cls[j] == bidiET {
+			j++
+		}
+		if (i > 0 && cls[i-1] == bidiEN) || (j < len(cls) && cls[j] == bidiEN) {
+			for k := i; k < j; k++ {
+				cls[k] = bidiEN
+			}
+		}
+		i = j
+	}
+	//W6
+	for i, c := range cls {
+		switch c {
+		case bidiES, bidiET, bidiCS:
+			cls[i] = bidiON
+		}
+	}
+	//W7
+	strong = sos
+	for i, c := range cls {
+		switch c {
+		case bidiL, bidiR:
+			strong = c
+		case bidiEN:
+			if strong == bidiL {
+				cls[i] = bidiL
+			}
+		}
+	}
+	//N1, N2
+	neutral := func(c bidiClass) bool {
+		return c == bidiB || c == bidiS || c == bidiWS || c == bidiON
+	}
+	asStrong := func(c bidiClass) bidiClass {
+		if c == bidiEN || c == bidiAN {
+			return bidiR
+		}
+		return c
+	}
+	embedding := sos
+	for i := 0; i < len(cls); {
+		if !neutral(cls[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(cls) && neutral(cls[j]) {
+			j++
+		}
+		before, after := sos, sos //eos is the same as sos with no embeddings
+		if i > 0 {
+			before = asStrong(cls[i-1])
+		}
+		if j < len(cls) {
+			after = asStrong(cls[j])
+		}
+		c := embedding
+		if before == after {
+			c = before
+		}
+		for k := i; k < j; k++ {
+			cls[k] = c
+		}
+		i = j
+	}
+
+	//I1, I2
+	levels = make([]int, len(cls))
+	for i, c := range cls {
+		switch {
+		case base == 0 && c == bidiR:
+			levels[i] = 1
+		case base == 0 && (c == bidiAN || c == bidiEN):
+			levels[i] = 2
+		case base == 1 && (c == bidiL || c == bidiEN || c == bidiAN):
+			levels[i] = 2
+		default:
+			levels[i] = base
+		}
+	}
+
+	//L1, using the original classes
+	trailing := true
+	for i := len(orig) - 1; i >= 0; i-- {
+		switch orig[i] {
+		case bidiB, bidiS:
+			levels[i] = base
+			trailing = true
+		case bidiWS, bidiBN:
+			if trailing {
+				levels[i] = base
+			}
+		default:
+			trailing = false
+		}
+	}
+	return levels, base
+}
+
+//item is a range of text of a single direction and script.
+type item struct {
+	start, end int
+	rtl        bool
+	level      int
+}
+
+//itemize splits s into items and returns them in visual order.
+func itemize(s string, dir direction) []item {
+	if s == "" {
+		return nil
+	}
+	var rs []rune
+	var offs []int
+	for i, r := range s {
+		rs = append(rs, r)
+		offs = append(offs, i)
+	}
+	levels, _ := bidiLevels(rs, dir)
+
+	//split into items in logical order
+	var items []item
+	var cur *unicode.RangeTable
+	for i, r := range rs {
+		if i > 0 && levels[i] != levels[i-1] {
+			cur = nil
+		}
+		sc := scriptOf(r, cur)
+		if i == 0 || levels[i] != levels[i-1] || (sc != nil && cur != nil && sc != cur) {
+			if i > 0 {
+				items[len(items)-1].end = offs[i]
+			}
+			items = append(items, item{start: offs[i], level: levels[i], rtl: levels[i]%2 == 1})
+		}
+		if sc != nil {
+			cur = sc
+		}
+	}
+	items[len(items)-1].end = len(s)
+
+	//L2
+	max, minOdd := 0, -1
+	for _, it := range items {
+		if it.level > max {
+			max = it.level
+		}
+		if it.level%2 == 1 && (minOdd < 0 || it.level < minOdd) {
+			minOdd = it.level
+		}
+	}
+	if minOdd < 0 {
+		return items
+	}
+	for lvl := max; lvl >= minOdd; lvl-- {
+		for i := 0; i < len(items); {
+			if items[i].level < lvl {
+				i++
+				continue
+			}
+			j := i
+			for j < len(items) && items[j].level >= lvl {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				items[a], items[b] = items[b], items[a]
+			}
+			i = j
+		}
+	}
+	return items
+}
+
+//scriptOf returns the script of r, or nil if r is common to all scripts
+//or inherits the script of the preceding character.
+//prev is checked first, as it is the most likely match.
+func scriptOf(r rune, prev *unicode.RangeTable) *unicode.RangeTable {
+	if prev != nil && unicode.Is(prev, r) {
+		return prev
+	}
+	if unicode.In(r, unicode.Common, unicode.Inherited) {
+		return nil
+	}
+	for _, t := range unicode.Scripts {
+		if unicode.Is(t, r) {
+			return t
+		}
+	}
+	return nil
+}
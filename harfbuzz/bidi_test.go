@@ -0,0 +1,50 @@
+package harfbuzz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestItemize(t *testing.T) {
+	const (
+		shalom = "שלום"
+		salam  = "سلام"
+	)
+	type span struct {
+		text string
+		rtl  bool
+	}
+	for _, test := range []struct {
+		in   string
+		dir  direction
+		want []span
+	}{
+		{"", DirectionAuto, nil},
+		{"hello", DirectionAuto, []span{{"hello", false}}},
+		{shalom, DirectionAuto, []span{{shalom, true}}},
+		{"a " + shalom + " b", DirectionAuto, []span{
+			{"a ", false}, {shalom, true}, {" b", false},
+		}},
+		//the base direction is taken from the first strong character
+		{shalom + " a " + salam, DirectionAuto, []span{
+			{" " + salam, true}, {"a", false}, {shalom + " ", true},
+		}},
+		{"a", DirectionRTL, []span{{"a", false}}},
+		//numbers stay left to right in right to left text
+		{shalom + " 123", DirectionAuto, []span{{"123", false}, {shalom + " ", true}}},
+		//and digits after an Arabic letter are Arabic numbers
+		{salam + "12", DirectionAuto, []span{{"12", false}, {salam, true}}},
+		//trailing whitespace takes the paragraph direction
+		{shalom + "  ", DirectionLTR, []span{{shalom, true}, {"  ", false}}},
+		//scripts are split even in the same direction
+		{"abc नमस्ते", DirectionAuto, []span{{"abc ", false}, {"नमस्ते", false}}},
+	} {
+		var got []span
+		for _, it := range itemize(test.in, test.dir) {
+			got = append(got, span{test.in[it.start:it.end], it.rtl})
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("itemize(%q, %v): got %v, want %v", test.in, test.dir, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+//Package harfbuzz shapes text with HarfBuzz for display with cairo.
+//
+//Cairo's own text to glyph conversion maps each character to a single
+//glyph and so cannot produce correct output for scripts such as Arabic,
+//Devanagari, or Thai, or for fonts that rely on OpenType features like
+//ligatures and kerning.
+//This package loads a font with FreeType, shapes text with HarfBuzz, and
+//returns glyphs and text clusters in cairo user space, ready to be passed
+//to Context.ShowTextGlyphs.
+//
+//Bidirectional text is split into runs of a single direction and script,
+//which are shaped separately and returned in visual order.
+//
+//Libcairo must be compiled with
+//	CAIRO_HAS_FT_FONT
+//in addition to the requirements of cairo,
+//and FreeType and HarfBuzz must be installed.
+//If the linked libcairo is compiled without it,
+//New and Open return a *cairo.NotSupportedError.
+package harfbuzz
+
+//#cgo pkg-config: cairo cairo-ft freetype2 harfbuzz
+//#include <stdlib.h>
+//#include <string.h>
+//#include <pthread.h>
+//#include <cairo/cairo.h>
+//#include <cairo/cairo-ft.h>
+//#include <ft2build.h>
+//#include FT_FREETYPE_H
+//#include <hb.h>
+//#include <hb-ot.h>
+//
+//#pragma weak cairo_ft_font_face_create_for_ft_face
+//
+////FreeType requires that creating and destroying faces from the same
+////library be serialized, and faces are destroyed by callbacks from
+////cairo and HarfBuzz as well as from Go.
+//static pthread_mutex_t lock = PTHREAD_MUTEX_INITIALIZER;
+//static FT_Library library;
+//
+//static void free_data(void *obj) {
+//	FT_Face face = obj;
+//	free(face->generic.data);
+//}
+//
+//static void done_face(void *face) {
+//	pthread_mutex_lock(&lock);
+//	FT_Done_Face((FT_Face)face);
+//	pthread_mutex_unlock(&lock);
+//}
+//
+////new_face creates a face from a copy of data.
+////The copy is freed when the face is destroyed.
+//static FT_Error new_face(const void *data, long n, long index, FT_Face *face) {
+//	FT_Error err = 0;
+//	void *buf = malloc(n);
+//	if (buf == NULL) {
+//		return FT_Err_Out_Of_Memory;
+//	}
+//	memcpy(buf, data, n);
+//	pthread_mutex_lock(&lock);
+//	if (library == NULL) {
+//		err = FT_Init_FreeType(&library);
+//	}
+//	if (!err) {
+//		err = FT_New_Memory_Face(library, buf, n, index, face);
+//	}
+//	pthread_mutex_unlock(&lock);
+//	if (err) {
+//		free(buf);
+//		return err;
+//	}
+//	(*face)->generic.data = buf;
+//	(*face)->generic.finalizer = free_data;
+//	return 0;
+//}
+//
+////ref_face adds a reference to face and returns it.
+//static FT_Face ref_face(FT_Face face) {
+//	pthread_mutex_lock(&lock);
+//	FT_Reference_Face(face);
+//	pthread_mutex_unlock(&lock);
+//	return face;
+//}
+//
+//static cairo_user_data_key_t face_key;
+//
+////cairo_face creates a cairo font face that owns a reference to face.
+//static cairo_font_face_t *cairo_face(FT_Face face) {
+//	cairo_font_face_t *f = cairo_ft_font_face_create_for_ft_face(face, 0);
+//	if (cairo_font_face_status(f) != CAIRO_STATUS_SUCCESS) {
+//		return f;
+//	}
+//	if (cairo_font_face_set_user_data(f, &face_key, ref_face(face), done_face) != CAIRO_STATUS_SUCCESS) {
+//		done_face(face);
+//	}
+//	return f;
+//}
+//
+////hb_font creates a HarfBuzz font from the data backing face.
+////The font reads the data directly, so it does not contend with cairo for
+////face, but it owns a reference to face to keep the data alive.
+//static hb_font_t *hb_font(FT_Face face, long index) {
+//	hb_blob_t *blob = hb_blob_create(face->generic.data, face->stream->size,
+//		HB_MEMORY_MODE_READONLY, ref_face(face), done_face);
+//	hb_face_t *hface = hb_face_create(blob, index);
+//	hb_blob_destroy(blob);
+//	hb_font_t *font = hb_font_create(hface);
+//	hb_face_destroy(hface);
+//	hb_ot_font_set_funcs(font);
+//	return font;
+//}
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"runtime"
+	"unsafe"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//Font is a font loaded with FreeType that can be used both to shape text
+//with HarfBuzz and to draw it with cairo.
+//
+//A Font may be set on a Context with SetFont or used to create a
+//ScaledFont, like any other cairo.Font.
+type Font struct {
+	*cairo.XtensionFont
+	hb   *C.hb_font_t
+	upem float64
+}
+
+//Open loads the font with the given index from the font file at path.
+//Index is 0 unless the file is a collection containing multiple fonts.
+func Open(path string, index int) (*Font, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(data, index)
+}
+
+//New loads the font with the given index from the contents of a font file.
+//Index is 0 unless data is a collection containing multiple fonts.
+//
+//The data is copied and may be reused once New returns.
+func New(data []byte, index int) (*Font, error) {
+	if err := cairo.XtensionRequire("harfbuzz.New", cairo.FeatureFreeType); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("no font data")
+	}
+	var face C.FT_Face
+	if code := C.new_face(unsafe.Pointer(&data[0]), C.long(len(data)), C.long(index), &face); code != 0 {
+		return nil, ftErr(code)
+	}
+	//this reference is handed off to the cairo face and hb font
+	defer C.done_face(unsafe.Pointer(face))
+
+	cf := C.cairo_face(face)
+	x := cairo.XtensionNewFont(cf)
+	if err := x.Err(); err != nil {
+		x.Close()
+		return nil, err
+	}
+
+	hb := C.hb_font(face, C.long(index))
+	f := &Font{
+		XtensionFont: x,
+		hb:           hb,
+		upem:         float64(C.hb_face_get_upem(C.hb_font_get_face(hb))),
+	}
+	runtime.SetFinalizer(f, (*Font).Close)
+	return f, nil
+}
+
+func cNew(f *C.cairo_font_face_t) (cairo.Font, error) {
+	//Faces created by other means cannot be shaped,
+	//so they are only usable as plain fonts.
+	x := cairo.XtensionNewFont(f)
+	return x, x.Err()
+}
+
+func init() {
+	cairo.XtensionRegisterRawToFont(cairo.FontTypeFT, cNew)
+}
+
+//Close frees the resources used by this font.
+func (f *Font) Close() error {
+	if f == nil || f.hb == nil {
+		return nil
+	}
+	C.hb_font_destroy(f.hb)
+	f.hb = nil
+	runtime.SetFinalizer(f, nil)
+	return f.XtensionFont.Close()
+}
+
+func ftErr(code C.FT_Error) error {
+	return fmt.Errorf("FreeType error %d loading font", int(code))
+}
+
+//Version returns the version of HarfBuzz in use.
+//
+//Originally hb_version_string.
+func Version() string {
+	return C.GoString(C.hb_version_string())
+}
@@ -0,0 +1,237 @@
+package harfbuzz
+
+//#cgo pkg-config: harfbuzz
+//#include <stdlib.h>
+//#include <hb.h>
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//Directions specify the base direction of a paragraph.
+const (
+	//DirectionAuto uses the direction of the first strong character,
+	//or left to right if there is none.
+	DirectionAuto direction = iota
+	//DirectionLTR is left to right.
+	DirectionLTR
+	//DirectionRTL is right to left.
+	DirectionRTL
+)
+
+type direction int
+
+func (d direction) String() string {
+	switch d {
+	case DirectionAuto:
+		return "DirectionAuto"
+	case DirectionLTR:
+		return "DirectionLTR"
+	case DirectionRTL:
+		return "DirectionRTL"
+	}
+	return "unknown direction"
+}
+
+//Options control how text is shaped.
+type Options struct {
+	//Size is the em size of the font in user space units.
+	//If Size is not positive, 10 is used, matching cairo's default.
+	Size float64
+	//Language is the BCP 47 language tag of the text, such as "ar" or
+	//"hi".
+	//It selects language specific forms in fonts that have them.
+	//If empty, it is guessed from the script.
+	Language string
+	//Direction is the base direction of the text.
+	Direction direction
+	//Features are OpenType features to enable or disable, in the syntax
+	//accepted by hb_feature_from_string, such as "liga=0" or "+smcp".
+	Features []string
+}
+
+//Run is a sequence of glyphs shaped from text of a single direction and
+//script.
+//
+//The Text, Glyphs, Clusters, and Flags of a Run may be passed directly to
+//Context.ShowTextGlyphs.
+type Run struct {
+	//Text is the text of the run.
+	Text string
+	//Start is the byte offset of Text in the text that was shaped.
+	Start int
+	//Glyphs are the positioned glyphs, in user space and visual order.
+	Glyphs []cairo.Glyph
+	//Clusters map the bytes of Text to Glyphs.
+	Clusters []cairo.TextCluster
+	//Flags are the text cluster flags of Clusters.
+	//TextClusterBackward is set for right to left runs.
+	Flags cairo.TextClusterFlags
+	//RTL is set if the run is right to left.
+	RTL bool
+	//Advance is the horizontal distance from the start of the run
+	//to the start of the next.
+	Advance float64
+}
+
+//Shape shapes s, a single line of text, with origin as the point on the
+//baseline where the line starts.
+//
+//The returned runs are in visual order, left to right, and positioned one
+//after the other, so each may be drawn as is with Context.ShowTextGlyphs,
+//provided the context's font is f at opts.Size.
+//
+//Bidirectional text is resolved according to a subset of the Unicode
+//bidirectional algorithm, UAX #9, that omits explicit embeddings,
+//overrides, and isolates.
+//Those formatting characters are passed on to the font but otherwise
+//ignored.
+//
+//Originally hb_shape.
+func (f *Font) Shape(origin cairo.Point, s string, opts Options) ([]Run, error) {
+	if f == nil || f.hb == nil {
+		return nil, errors.New("harfbuzz: shaping with closed font")
+	}
+	if opts.Size <= 0 {
+		opts.Size = 10
+	}
+	features, err := parseFeatures(opts.Features)
+	if err != nil {
+		return nil, err
+	}
+	var feats *C.hb_feature_t
+	if len(features) > 0 {
+		feats = &features[0]
+	}
+	var lang C.hb_language_t
+	if opts.Language != "" {
+		cs := C.CString(opts.Language)
+		lang = C.hb_language_from_string(cs, -1)
+		C.free(unsafe.Pointer(cs))
+	}
+
+	cs := C.CString(s)
+	defer C.free(unsafe.Pointer(cs))
+
+	scale := opts.Size / f.upem
+	pen := origin
+	var runs []Run
+	for _, it := range itemize(s, opts.Direction) {
+		buf := C.hb_buffer_create()
+		C.hb_buffer_add_utf8(buf, cs, C.int(len(s)), C.uint(it.start), C.int(it.end-it.start))
+		if it.rtl {
+			C.hb_buffer_set_direction(buf, C.HB_DIRECTION_RTL)
+		} else {
+			C.hb_buffer_set_direction(buf, C.HB_DIRECTION_LTR)
+		}
+		if lang != nil {
+			C.hb_buffer_set_language(buf, lang)
+		}
+		C.hb_buffer_guess_segment_properties(buf)
+		if C.hb_buffer_allocation_successful(buf) == 0 {
+			C.hb_buffer_destroy(buf)
+			return nil, errors.New("harfbuzz: out of memory")
+		}
+		C.hb_shape(f.hb, buf, feats, C.uint(len(features)))
+
+		r := newRun(s, it, buf, pen, scale)
+		C.hb_buffer_destroy(buf)
+		pen.X += r.Advance
+		runs = append(runs, r)
+	}
+	return runs, nil
+}
+
+func parseFeatures(fs []string) ([]C.hb_feature_t, error) {
+	out := make([]C.hb_feature_t, len(fs))
+	for i, f := range fs {
+		cs := C.CString(f)
+		ok := C.hb_feature_from_string(cs, -1, &out[i])
+		C.free(unsafe.Pointer(cs))
+		if ok == 0 {
+			return nil, errors.New("harfbuzz: invalid feature: " + f)
+		}
+	}
+	return out, nil
+}
+
+//newRun converts the contents of a shaped buffer into a Run
+//starting at pen.
+func newRun(s string, it item, buf *C.hb_buffer_t, pen cairo.Point, scale float64) Run {
+	var n C.uint
+	infos := C.hb_buffer_get_glyph_infos(buf, &n)
+	poss := C.hb_buffer_get_glyph_positions(buf, &n)
+	N := int(n)
+	r := Run{
+		Text:   s[it.start:it.end],
+		Start:  it.start,
+		Glyphs: make([]cairo.Glyph, N),
+		RTL:    it.rtl,
+	}
+	if N == 0 {
+		return r
+	}
+	info := (*[1 << 30]C.hb_glyph_info_t)(unsafe.Pointer(infos))[:N:N]
+	pos := (*[1 << 30]C.hb_glyph_position_t)(unsafe.Pointer(poss))[:N:N]
+
+	p := pen
+	clusters := make([]int, N)
+	for i := range info {
+		r.Glyphs[i] = cairo.Glyph{
+			Index: uint64(info[i].codepoint),
+			Point: cairo.Pt(
+				p.X+float64(pos[i].x_offset)*scale,
+				p.Y-float64(pos[i].y_offset)*scale,
+			),
+		}
+		p.X += float64(pos[i].x_advance) * scale
+		p.Y -= float64(pos[i].y_advance) * scale
+		clusters[i] = int(info[i].cluster) - it.start
+	}
+	r.Advance = p.X - pen.X
+
+	if it.rtl {
+		r.Flags = cairo.TextClusterBackward
+		//clusters are in logical order, so walk the glyphs backward
+		for i, j := 0, N-1; i < j; i, j = i+1, j-1 {
+			clusters[i], clusters[j] = clusters[j], clusters[i]
+		}
+	}
+	r.Clusters = textClusters(clusters, len(r.Text))
+	return r
+}
+
+//textClusters converts the byte offsets of each glyph's cluster,
+//in logical order, into text clusters covering n bytes.
+//
+//HarfBuzz clusters are monotonic by default, but a glyph whose cluster
+//starts before that of the previous glyph is merged into the previous
+//cluster to keep the result valid regardless.
+func textClusters(offsets []int, n int) []cairo.TextCluster {
+	var out []cairo.TextCluster
+	for i := 0; i < len(offsets); {
+		start := offsets[i]
+		j := i + 1
+		for j < len(offsets) && offsets[j] <= start {
+			j++
+		}
+		end := n
+		if j < len(offsets) {
+			end = offsets[j]
+		}
+		out = append(out, cairo.TextCluster{
+			RuneLength: end - start,
+			NumGlyphs:  j - i,
+		})
+		i = j
+	}
+	//text before the first cluster belongs to it
+	if len(out) > 0 {
+		out[0].RuneLength += offsets[0]
+	}
+	return out
+}
@@ -0,0 +1,151 @@
+package harfbuzz
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+func tc(bytes, glyphs int) cairo.TextCluster {
+	return cairo.TextCluster{RuneLength: bytes, NumGlyphs: glyphs}
+}
+
+func TestTextClusters(t *testing.T) {
+	for _, test := range []struct {
+		offsets []int
+		n       int
+		want    []cairo.TextCluster
+	}{
+		{nil, 0, nil},
+		{[]int{0, 1, 2}, 3, []cairo.TextCluster{tc(1, 1), tc(1, 1), tc(1, 1)}},
+		//ligature of two characters
+		{[]int{0, 2}, 3, []cairo.TextCluster{tc(2, 1), tc(1, 1)}},
+		//base and mark in one cluster
+		{[]int{0, 0, 3}, 5, []cairo.TextCluster{tc(3, 2), tc(2, 1)}},
+		//out of order clusters are merged
+		{[]int{1, 0, 2}, 3, []cairo.TextCluster{tc(2, 2), tc(1, 1)}},
+	} {
+		got := textClusters(test.offsets, test.n)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("textClusters(%v, %d): got %v, want %v", test.offsets, test.n, got, test.want)
+		}
+	}
+}
+
+//systemFonts are the paths of common fonts with Latin and Hebrew glyphs.
+var systemFonts = []string{
+	"/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf",
+	"/usr/share/fonts/TTF/DejaVuSans.ttf",
+	"/usr/share/fonts/dejavu/DejaVuSans.ttf",
+	"/usr/share/fonts/dejavu-sans-fonts/DejaVuSans.ttf",
+	"/usr/local/share/fonts/dejavu/DejaVuSans.ttf",
+	"/usr/share/fonts/truetype/freefont/FreeSans.ttf",
+	"/Library/Fonts/Arial Unicode.ttf",
+	"/System/Library/Fonts/Supplemental/Arial Unicode.ttf",
+	`C:\Windows\Fonts\arial.ttf`,
+}
+
+//openSystemFont opens the first of systemFonts that exists
+//or skips the test if there is none.
+func openSystemFont(t *testing.T) *Font {
+	for _, path := range systemFonts {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		f, err := Open(path, 0)
+		if _, ok := err.(*cairo.NotSupportedError); ok {
+			t.Skip(err)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+	t.Skip("no system font found")
+	return nil
+}
+
+func TestShape(t *testing.T) {
+	f := openSystemFont(t)
+	defer f.Close()
+
+	const (
+		shalom = "שלום"
+		text   = "office " + shalom + " ffi"
+	)
+	origin := cairo.Pt(5, 20)
+	runs, err := f.Shape(origin, text, Options{Size: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		text string
+		rtl  bool
+	}{
+		{"office ", false},
+		{shalom, true},
+		{" ffi", false},
+	}
+	if len(runs) != len(want) {
+		t.Fatalf("got %d runs, want %d", len(runs), len(want))
+	}
+	pen := origin.X
+	for i, r := range runs {
+		if r.Text != want[i].text || r.RTL != want[i].rtl {
+			t.Errorf("run %d: got %q rtl=%v, want %q rtl=%v", i, r.Text, r.RTL, want[i].text, want[i].rtl)
+		}
+		if text[r.Start:r.Start+len(r.Text)] != r.Text {
+			t.Errorf("run %d: Start %d does not locate %q", i, r.Start, r.Text)
+		}
+		if backward := r.Flags&cairo.TextClusterBackward != 0; backward != r.RTL {
+			t.Errorf("run %d: TextClusterBackward is %v, want %v", i, backward, r.RTL)
+		}
+
+		var bytes, glyphs int
+		for _, c := range r.Clusters {
+			if c.RuneLength < 0 || c.NumGlyphs < 0 || (c.RuneLength == 0 && c.NumGlyphs == 0) {
+				t.Errorf("run %d: invalid cluster %v", i, c)
+			}
+			bytes += c.RuneLength
+			glyphs += c.NumGlyphs
+		}
+		if bytes != len(r.Text) {
+			t.Errorf("run %d: clusters cover %d bytes, want %d", i, bytes, len(r.Text))
+		}
+		if glyphs != len(r.Glyphs) {
+			t.Errorf("run %d: clusters cover %d glyphs, want %d", i, glyphs, len(r.Glyphs))
+		}
+
+		//runs are in visual order, each starting where the last ended
+		if len(r.Glyphs) == 0 {
+			t.Errorf("run %d: no glyphs", i)
+		} else if x := r.Glyphs[0].Point.X; x < pen-1e-9 {
+			t.Errorf("run %d: first glyph at x=%v, before the end of the previous run at %v", i, x, pen)
+		}
+		if r.Advance <= 0 {
+			t.Errorf("run %d: got advance %v, want positive", i, r.Advance)
+		}
+		pen += r.Advance
+
+		//none of these glyphs are offset vertically, so they are all on
+		//the baseline in user space
+		for j, g := range r.Glyphs {
+			if g.Point.Y != origin.Y {
+				t.Errorf("run %d glyph %d: y=%v, want baseline %v", i, j, g.Point.Y, origin.Y)
+			}
+		}
+	}
+
+	//with ligatures disabled, each letter of "ffi" is its own glyph
+	//and cluster
+	runs, err = f.Shape(origin, "ffi", Options{Features: []string{"liga=0"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 || len(runs[0].Glyphs) != 3 || len(runs[0].Clusters) != 3 {
+		t.Errorf("ffi without ligatures: got %+v", runs)
+	}
+}
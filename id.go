@@ -38,7 +38,12 @@ func (s subtypeID) c() unsafe.Pointer {
 
 var (
 	nextID uint64
-	idmux  = &sync.Mutex{}
+	//idmux guards nextID and the user font and raster pattern subtype
+	//registries.
+	idmux = &sync.Mutex{}
+	//regmux guards the registries of factories converting libcairo objects
+	//to Go values, which are read far more often than they are written.
+	regmux = &sync.RWMutex{}
 	idkey  = &C.cairo_user_data_key_t{}
 	stkey  = &C.cairo_user_data_key_t{}
 
@@ -122,7 +127,7 @@ func deviceGetID(d *C.cairo_device_t) id {
 }
 
 func fontSetSubtypeID(f *C.cairo_font_face_t, s subtypeID) {
-	if fontType(C.cairo_font_face_get_type(f)) != FontTypeUser {
+	if FontType(C.cairo_font_face_get_type(f)) != FontTypeUser {
 		panic("font is not a user font")
 	}
 	if C.cairo_font_face_get_user_data(f, stkey) != nil {
@@ -132,7 +137,7 @@ func fontSetSubtypeID(f *C.cairo_font_face_t, s subtypeID) {
 }
 
 func fontGetSubtypeID(f *C.cairo_font_face_t) subtypeID {
-	if fontType(C.cairo_font_face_get_type(f)) != FontTypeUser {
+	if FontType(C.cairo_font_face_get_type(f)) != FontTypeUser {
 		panic("font is not a user font")
 	}
 	p := C.cairo_font_face_get_user_data(f, stkey)
@@ -143,7 +148,7 @@ func fontGetSubtypeID(f *C.cairo_font_face_t) subtypeID {
 }
 
 func patternSetSubtypeID(p *C.cairo_pattern_t, s subtypeID) {
-	if fontType(C.cairo_pattern_get_type(p)) != PatternTypeRasterSource {
+	if PatternType(C.cairo_pattern_get_type(p)) != PatternTypeRasterSource {
 		panic("pattern is not a raster pattern")
 	}
 	if C.cairo_pattern_get_user_data(p, stkey) != nil {
@@ -153,7 +158,7 @@ func patternSetSubtypeID(p *C.cairo_pattern_t, s subtypeID) {
 }
 
 func patternGetSubtypeID(p *C.cairo_pattern_t) subtypeID {
-	if fontType(C.cairo_pattern_get_type(p)) != PatternTypeRasterSource {
+	if PatternType(C.cairo_pattern_get_type(p)) != PatternTypeRasterSource {
 		panic("pattern is not a raster pattern")
 	}
 	ptr := C.cairo_pattern_get_user_data(p, stkey)
@@ -216,9 +221,20 @@ func XtensionRegisterAlienUserFontSubtype(name string, fac func(*C.cairo_font_fa
 //
 //The subtype must be registered XtensionRegisterAlienUserFontSubtype.
 func XtensionRegisterAlienUserFont(subtype string, f *C.cairo_font_face_t) (Font, error) {
+	idmux.Lock()
 	id := fontsubtypenames[subtype]
+	fac := fontsubtypes[id]
+	idmux.Unlock()
 	fontSetSubtypeID(f, id)
-	return fontsubtypes[id].fac(f)
+	return fac.fac(f)
+}
+
+//fontSubtype returns the user font subtype registered as id.
+func fontSubtype(id subtypeID) (*fontsubfac, bool) {
+	idmux.Lock()
+	defer idmux.Unlock()
+	t, ok := fontsubtypes[id]
+	return t, ok
 }
 
 type rastersubfac struct {
@@ -265,7 +281,18 @@ func XtensionRegisterAlienRasterPatternSubtype(name string, fac func(*C.cairo_pa
 //The subtype must be registered
 //with XtensionRegisterAlienRasterPatternSubtype.
 func XtensionRegisterAlienRasterPattern(subtype string, p *C.cairo_pattern_t) (Pattern, error) {
+	idmux.Lock()
 	id := rastersubtypenames[subtype]
+	fac := rastersubtypes[id]
+	idmux.Unlock()
 	patternSetSubtypeID(p, id)
-	return rastersubtypes[id].fac(p)
+	return fac.fac(p)
+}
+
+//rasterSubtype returns the raster pattern subtype registered as id.
+func rasterSubtype(id subtypeID) (*rastersubfac, bool) {
+	idmux.Lock()
+	defer idmux.Unlock()
+	t, ok := rastersubtypes[id]
+	return t, ok
 }
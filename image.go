@@ -2,11 +2,12 @@ package cairo
 
 //#cgo pkg-config: cairo
 //#include <stdlib.h>
-//#include <cairo/cairo.h>
+//#include "weak.h"
 import "C"
 
 import (
 	"image"
+	"math"
 	"runtime"
 	"sync"
 	"unsafe"
@@ -63,14 +64,21 @@ func init() {
 	}
 }
 
-//FromImage copies an image into a surface.
+//FromImage copies an image into a surface with the given device scale.
 //
 //The created image surface will have the same size as img,
 //the optimal stride for img's width, and FormatARGB32.
 //
+//The scale is the number of pixels of img per unit of user space.
+//For example, an image created for a high DPI display at twice the normal
+//resolution should be given a scale of (2, 2), so that it is the same size as
+//its normal resolution counterpart when used as a source.
+//If scale is the zero Point, (1, 1) is used.
+//See SetDeviceScale.
+//
 //Originally cairo_image_surface_create_for_data and
 //cairo_format_stride_for_width.
-func FromImage(img image.Image) (ImageSurface, error) {
+func FromImage(img image.Image, scale Point) (ImageSurface, error) {
 	f := FormatARGB32.c()
 	b := img.Bounds()
 	w, h := b.Dx(), b.Dy()
@@ -96,16 +104,75 @@ func FromImage(img image.Image) (ImageSurface, error) {
 	is := C.cairo_image_surface_create_for_data(data, f, C.int(w), C.int(h), C.int(s))
 	C.cairo_surface_set_user_data(is, imgKey, unsafe.Pointer(data), free)
 
-	return newImg(is, FormatARGB32, w, h, s)
+	S, err := newImg(is, FormatARGB32, w, h, s)
+	if err != nil {
+		return S, err
+	}
+	if scale != ZP {
+		if err = S.SetDeviceScale(scale); err != nil {
+			S.Close()
+			return ImageSurface{}, err
+		}
+	}
+	return S, S.err("FromImage")
 }
 
-//ToImage returns a copy of the surface as an image.
+//ToImage returns a copy of the surface as an image at the given scale.
+//
+//The scale is the number of pixels of the returned image per unit of user
+//space.
+//If scale is the zero Point or the device scale of the surface,
+//the pixels of the surface are copied exactly.
+//Otherwise, the surface is resampled so that the size of the image is the
+//size of the surface in user space multiplied by scale.
+//For example, a surface with a device scale of (2, 2) that has been drawn
+//on for a high DPI display can be returned at normal resolution
+//with a scale of (1, 1).
 //
 //Originally cairo_image_surface_get_data.
-func (is ImageSurface) ToImage() (*image.RGBA, error) {
-	if err := is.Err(); err != nil {
+func (is ImageSurface) ToImage(scale Point) (*image.RGBA, error) {
+	if err := is.err("ImageSurface.ToImage"); err != nil {
+		return nil, err
+	}
+	ds := is.DeviceScale()
+	if scale == ZP || scale == ds {
+		return is.toImage(), nil
+	}
+
+	w, h := scaledSize(is.width, is.height, ds, scale)
+	dst, err := NewImageSurface(FormatARGB32, w, h)
+	if err != nil {
+		return nil, err
+	}
+	defer dst.Close()
+	if err = dst.SetDeviceScale(scale); err != nil {
 		return nil, err
 	}
+
+	c, err := New(dst)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	if err = c.SetSourceSurface(is, ZP); err != nil {
+		return nil, err
+	}
+	c.Paint()
+	if err = c.err("ImageSurface.ToImage"); err != nil {
+		return nil, err
+	}
+	return dst.toImage(), nil
+}
+
+//scaledSize returns the size in pixels of a w×h surface with device scale
+//from after resampling to device scale to.
+func scaledSize(w, h int, from, to Point) (int, int) {
+	sw := math.Ceil(float64(w) / from.X * to.X)
+	sh := math.Ceil(float64(h) / from.Y * to.Y)
+	return int(sw), int(sh)
+}
+
+func (is ImageSurface) toImage() *image.RGBA {
 	C.cairo_surface_flush(is.s)
 
 	data := C.cairo_image_surface_get_data(is.s)
@@ -125,7 +192,7 @@ func (is ImageSurface) ToImage() (*image.RGBA, error) {
 		img.Pix[i+3] = uint8(pseudoslice[i+oA])
 	}
 
-	return img, nil
+	return img
 }
 
 //Format reports the format of the surface.
@@ -214,11 +281,14 @@ func (m MappedImageSurface) Close() error {
 //
 //Originally cairo_surface_unmap.
 func (m MappedImageSurface) Unmap() error {
-	err := m.Err()
+	err := m.err("MappedImageSurface.Unmap")
 	mismux.Lock()
 	defer mismux.Unlock()
-	from := mis[m.id()]
+	id := m.id()
+	from := mis[id]
+	delete(mis, id)
 	C.cairo_surface_unmap_image(from, m.s)
+	C.cairo_surface_destroy(from)
 	m.s = nil
 	return err
 }
@@ -0,0 +1,145 @@
+package cairo
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"testing"
+)
+
+func TestScaledSize(t *testing.T) {
+	for _, test := range []struct {
+		w, h     int
+		from, to Point
+		W, H     int
+	}{
+		{10, 20, Pt(1, 1), Pt(1, 1), 10, 20},
+		{10, 20, Pt(1, 1), Pt(2, 2), 20, 40},
+		{20, 40, Pt(2, 2), Pt(1, 1), 10, 20},
+		{15, 15, Pt(2, 2), Pt(1, 1), 8, 8},
+		{10, 10, Pt(1, 2), Pt(3, 1), 30, 5},
+	} {
+		W, H := scaledSize(test.w, test.h, test.from, test.to)
+		if W != test.W || H != test.H {
+			t.Errorf("scaledSize(%d, %d, %v, %v): got %d×%d, want %d×%d",
+				test.w, test.h, test.from, test.to, W, H, test.W, test.H)
+		}
+	}
+}
+
+//Render the same icon for normal and high DPI displays.
+func ExampleXtensionSurface_SetDeviceScale() {
+	const size = 16 //size of the icon in logical units
+	icons := map[string]*bytes.Buffer{}
+	for _, scale := range []float64{1, 2} {
+		//the surface is created in pixels
+		px := int(size * scale)
+		s, err := NewImageSurface(FormatARGB32, px, px)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		//but drawn on in logical units
+		if err := s.SetDeviceScale(Pt(scale, scale)); err != nil {
+			log.Fatalln(err)
+		}
+		c, err := New(s)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		c.Circle(Circ(size/2, size/2, size/2-1)).
+			SetSourceColor(Blue).
+			Fill()
+		if err := c.Close(); err != nil {
+			log.Fatalln(err)
+		}
+
+		img, err := s.ToImage(Pt(scale, scale))
+		if err != nil {
+			log.Fatalln(err)
+		}
+		s.Close()
+		name := fmt.Sprintf("icon@%gx.png", scale)
+		icons[name] = &bytes.Buffer{}
+		if err := png.Encode(icons[name], img); err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Println(name, img.Bounds().Size())
+	}
+	// Output:
+	// icon@1x.png (16,16)
+	// icon@2x.png (32,32)
+}
+
+func TestCreateSimilarImageScale(t *testing.T) {
+	s, err := NewImageSurface(FormatARGB32, 8, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if err := s.SetDeviceScale(Pt(2, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	//the size is in pixels and the device scale is not inherited
+	sim, err := s.CreateSimilarImage(FormatARGB32, 6, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Close()
+	if w, h := sim.Width(), sim.Height(); w != 6 || h != 4 {
+		t.Errorf("got %d×%d, want 6×4", w, h)
+	}
+	if got := sim.DeviceScale(); got != Pt(1, 1) {
+		t.Errorf("got device scale %v, want (1, 1)", got)
+	}
+}
+
+func TestMapImageScale(t *testing.T) {
+	s, err := NewImageSurface(FormatARGB32, 8, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if err := s.SetDeviceScale(Pt(2, 2)); err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//2×2 logical units is 4×4 pixels
+	c.SetSourceColor(Red).Rectangle(RectWH(0, 0, 2, 2)).Fill()
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	//the rectangle is in pixels
+	m, err := s.MapImage(image.Rect(0, 0, 4, 8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, h := m.Width(), m.Height(); w != 4 || h != 8 {
+		t.Errorf("got %d×%d, want 4×8", w, h)
+	}
+	img, err := m.ToImage(ZP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 4; x++ {
+			want := color.RGBA{}
+			if y < 4 {
+				want = color.RGBA{R: 0xff, A: 0xff}
+			}
+			if got := img.RGBAAt(x, y); got != want {
+				t.Errorf("pixel (%d, %d): got %v, want %v", x, y, got, want)
+			}
+		}
+	}
+	if err := m.Unmap(); err != nil {
+		t.Error(err)
+	}
+}
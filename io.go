@@ -58,13 +58,12 @@ func (w *writer) write(p []byte) error {
 		return w.err
 	}
 	n, err := w.w.Write(p)
-	if err != nil {
+	switch {
+	case err != nil:
 		w.err = err
+	case n != len(p):
+		w.err = io.ErrShortWrite
 	}
-	if n == len(p) {
-		return w.err
-	}
-	w.err = io.ErrShortWrite
 	return w.err
 }
 
@@ -97,8 +96,8 @@ func go_write_callback_reaper(w unsafe.Pointer) {
 	defer mux.Unlock()
 	delete(wmap, W.id)
 
+	//err is kept so that it may be reported by the Close that caused this
 	W.w = nil
-	W.err = nil
 }
 
 func storeWriter(W *writer) {
@@ -107,6 +106,30 @@ func storeWriter(W *writer) {
 	wmap[W.id] = W
 }
 
+//writerFor returns the writer registered with the object with the given id,
+//or nil if there is none.
+func writerFor(i id) *writer {
+	mux.Lock()
+	defer mux.Unlock()
+	return wmap[i]
+}
+
+//writeErr returns the first error returned by W's io.Writer, if any,
+//otherwise it returns err.
+//The writer's error takes precedence as libcairo only reports
+//ErrWriteError.
+func writeErr(W *writer, err error) error {
+	if W == nil {
+		return err
+	}
+	mux.Lock()
+	defer mux.Unlock()
+	if W.err != nil {
+		return W.err
+	}
+	return err
+}
+
 //XtensionRegisterWriter registers the writer wrapped by XtensionWrapWriter
 //with the surface so that it does not get garbage collected until libcairo
 //releases the surface.
@@ -115,6 +138,7 @@ func storeWriter(W *writer) {
 func (s *XtensionSurface) XtensionRegisterWriter(w unsafe.Pointer) {
 	if err := s.Err(); err != nil {
 		go_write_callback_reaper(w)
+		return
 	}
 	W := (*writer)(w)
 	W.id = s.id()
@@ -130,6 +154,7 @@ func (s *XtensionSurface) XtensionRegisterWriter(w unsafe.Pointer) {
 func (d *XtensionDevice) XtensionRegisterWriter(w unsafe.Pointer) {
 	if err := d.Err(); err != nil {
 		go_write_callback_reaper(w)
+		return
 	}
 	W := (*writer)(w)
 	W.id = d.id()
@@ -0,0 +1,59 @@
+package cairo
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"unsafe"
+)
+
+type failWriter struct {
+	n   int
+	err error
+}
+
+func (f failWriter) Write(p []byte) (int, error) {
+	if f.n < len(p) {
+		return f.n, f.err
+	}
+	return len(p), nil
+}
+
+func TestWriteErr(t *testing.T) {
+	errDisk := errors.New("disk full")
+	errCairo := errors.New("error while writing to output stream")
+
+	if err := writeErr(nil, errCairo); err != errCairo {
+		t.Errorf("nil writer: got %v, want %v", err, errCairo)
+	}
+
+	W := &writer{w: failWriter{n: 10}}
+	if err := W.write(make([]byte, 5)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeErr(W, nil); err != nil {
+		t.Errorf("successful write: got %v, want nil", err)
+	}
+
+	//a short write with no error from the writer
+	if err := W.write(make([]byte, 20)); err != io.ErrShortWrite {
+		t.Errorf("short write: got %v, want %v", err, io.ErrShortWrite)
+	}
+
+	W = &writer{w: failWriter{err: errDisk}}
+	W.write([]byte("x"))
+	//the writer's error is sticky
+	W.w = failWriter{n: 10}
+	if err := W.write([]byte("x")); err != errDisk {
+		t.Errorf("after failure: got %v, want %v", err, errDisk)
+	}
+	//and takes precedence over libcairo's
+	if err := writeErr(W, errCairo); err != errDisk {
+		t.Errorf("got %v, want %v", err, errDisk)
+	}
+	//even after the writer is reaped
+	go_write_callback_reaper(unsafe.Pointer(W))
+	if err := writeErr(W, errCairo); err != errDisk {
+		t.Errorf("after reaping: got %v, want %v", err, errDisk)
+	}
+}
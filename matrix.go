@@ -4,6 +4,11 @@ package cairo
 //#include <cairo/cairo.h>
 import "C"
 
+import (
+	"encoding/json"
+	"math"
+)
+
 //Matrix is used throughout cairo to convert between different coordinate
 //spaces.
 //
@@ -126,12 +131,16 @@ func (m Matrix) Rotate(radians float64) Matrix {
 	return m
 }
 
-//Invert inverts A and returns itself.
+//Invert inverts m and returns itself.
+//
+//If m is not invertible, m is returned unchanged along with ErrInvalidMatrix.
 //
 //Originally cairo_matrix_invert.
-func (m Matrix) Invert() Matrix {
-	C.cairo_matrix_invert(&m.m)
-	return m
+func (m Matrix) Invert() (Matrix, error) {
+	if C.cairo_matrix_invert(&m.m) != errSuccess {
+		return m, ErrInvalidMatrix
+	}
+	return m, nil
 }
 
 //Mul multiples m by n and returns the new result r, such that r = m*n.
@@ -143,6 +152,137 @@ func (m Matrix) Mul(n Matrix) Matrix {
 	return r
 }
 
+//Determinant returns the determinant of the linear part of m.
+func (m Matrix) Determinant() float64 {
+	return float64(m.m.xx*m.m.yy - m.m.yx*m.m.xy)
+}
+
+//IsIdentity reports whether m is exactly the identity matrix.
+func (m Matrix) IsIdentity() bool {
+	return m.IsTranslation() && m.m.x0 == 0 && m.m.y0 == 0
+}
+
+//IsInvertible reports whether m has an inverse.
+func (m Matrix) IsInvertible() bool {
+	d := m.Determinant()
+	return d != 0 && !math.IsInf(d, 0) && !math.IsNaN(d)
+}
+
+//IsTranslation reports whether m does nothing but translate.
+func (m Matrix) IsTranslation() bool {
+	return m.m.xx == 1 && m.m.yx == 0 && m.m.xy == 0 && m.m.yy == 1
+}
+
+//IsScaleTranslate reports whether m does nothing but scale and translate.
+func (m Matrix) IsScaleTranslate() bool {
+	return m.m.yx == 0 && m.m.xy == 0
+}
+
+//Decomposition is an affine transformation broken into simple parts.
+//
+//The parts are applied to a point in the order
+//Scale, Skew, Rotate, and then Translate.
+type Decomposition struct {
+	//Translate is the translation vector.
+	Translate Point
+	//Rotate is the rotation in radians.
+	Rotate float64
+	//Scale is the scale vector.
+	//A reflection is represented by a negative Scale.Y.
+	Scale Point
+	//Skew is the angle of a shear parallel to the x-axis, in radians.
+	Skew float64
+}
+
+//Matrix returns the matrix that d is a decomposition of.
+func (d Decomposition) Matrix() Matrix {
+	s, c := math.Sincos(d.Rotate)
+	k := math.Tan(d.Skew)
+	return NewMatrix(
+		c*d.Scale.X,
+		s*d.Scale.X,
+		(c*k-s)*d.Scale.Y,
+		(s*k+c)*d.Scale.Y,
+		d.Translate.X,
+		d.Translate.Y,
+	)
+}
+
+//Decompose breaks m into a translation, rotation, scale, and skew.
+//
+//For any invertible m, m.Decompose().Matrix() is m, up to rounding error.
+//The decomposition of a matrix that is not invertible is not unique
+//and may not reproduce m.
+func (m Matrix) Decompose() Decomposition {
+	xx, yx := float64(m.m.xx), float64(m.m.yx)
+	xy, yy := float64(m.m.xy), float64(m.m.yy)
+	d := Decomposition{
+		Translate: Pt(float64(m.m.x0), float64(m.m.y0)),
+	}
+
+	//Factor the linear part into a rotation times
+	//an upper triangular matrix.
+	sx := math.Hypot(xx, yx)
+	r := Pt(1, 0)
+	if sx != 0 {
+		r = Pt(xx/sx, yx/sx)
+		d.Rotate = math.Atan2(yx, xx)
+	}
+	col := Pt(xy, yy)
+	shear := r.Dot(col)
+	sy := r.X*yy - r.Y*xy
+
+	d.Scale = Pt(sx, sy)
+	if sy != 0 {
+		d.Skew = math.Atan(shear / sy)
+	}
+	return d
+}
+
+//Interpolate returns the matrix that is t of the way from a to b,
+//where t = 0 is a and t = 1 is b.
+//
+//Rather than interpolating the components of the matrices directly,
+//a and b are decomposed, as by Decompose, and each part of the
+//decomposition is interpolated separately.
+//Rotations are interpolated in whichever direction is shortest.
+//This keeps the intermediate transformations rigid,
+//which is generally what is desired for animation.
+func Interpolate(a, b Matrix, t float64) Matrix {
+	da, db := a.Decompose(), b.Decompose()
+	lerp := func(x, y float64) float64 {
+		return x + (y-x)*t
+	}
+	lerpPt := func(p, q Point) Point {
+		return Pt(lerp(p.X, q.X), lerp(p.Y, q.Y))
+	}
+
+	//take the short way around
+	Δ := math.Remainder(db.Rotate-da.Rotate, 2*math.Pi)
+
+	return Decomposition{
+		Translate: lerpPt(da.Translate, db.Translate),
+		Rotate:    da.Rotate + Δ*t,
+		Scale:     lerpPt(da.Scale, db.Scale),
+		Skew:      lerp(da.Skew, db.Skew),
+	}.Matrix()
+}
+
+//MarshalJSON encodes m as the array [xx, yx, xy, yy, x0, y0].
+func (m Matrix) MarshalJSON() ([]byte, error) {
+	return json.Marshal([6]float64{m.XX(), m.YX(), m.XY(), m.YY(), m.X0(), m.Y0()})
+}
+
+//UnmarshalJSON decodes an array [xx, yx, xy, yy, x0, y0] into m.
+func (m *Matrix) UnmarshalJSON(b []byte) error {
+	var v [6]float64
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*m = NewMatrix(v[0], v[1], v[2], v[3], v[4], v[5])
+	return nil
+}
+
 //XtensionRaw returns the raw C value of m.
 func (m Matrix) XtensionRaw() C.cairo_matrix_t {
 	return m.m
@@ -169,3 +309,15 @@ func (p Point) Transform(m Matrix) Point {
 	C.cairo_matrix_transform_point(&m.m, &x, &y)
 	return Point{float64(x), float64(y)}
 }
+
+//Transform returns the smallest rectangle containing r transformed by m.
+func (r Rectangle) Transform(m Matrix) Rectangle {
+	a, b, c, d := r.Verts()
+	a, b, c, d = a.Transform(m), b.Transform(m), c.Transform(m), d.Transform(m)
+	return Rect(
+		math.Min(math.Min(a.X, b.X), math.Min(c.X, d.X)),
+		math.Min(math.Min(a.Y, b.Y), math.Min(c.Y, d.Y)),
+		math.Max(math.Max(a.X, b.X), math.Max(c.X, d.X)),
+		math.Max(math.Max(a.Y, b.Y), math.Max(c.Y, d.Y)),
+	)
+}
@@ -0,0 +1,108 @@
+package cairo
+
+import (
+	"math"
+	"testing"
+)
+
+func matrixNear(m, n Matrix) bool {
+	const ε = 1e-9
+	for _, d := range []float64{
+		m.XX() - n.XX(), m.YX() - n.YX(),
+		m.XY() - n.XY(), m.YY() - n.YY(),
+		m.X0() - n.X0(), m.Y0() - n.Y0(),
+	} {
+		if math.Abs(d) > ε {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMatrixDecompose(t *testing.T) {
+	for _, m := range []Matrix{
+		NewIdentityMatrix(),
+		NewTranslateMatrix(Pt(3, -4)),
+		NewScaleMatrix(Pt(2, 5)),
+		NewScaleMatrix(Pt(-1, 1)),
+		NewRotateMatrix(2),
+		NewMatrix(1, 0, 1, 1, 0, 0),
+		NewTranslateMatrix(Pt(10, 20)).Rotate(-1).Scale(Pt(3, -2)),
+		NewMatrix(2, 1, -3, 4, 5, 6),
+	} {
+		if n := m.Decompose().Matrix(); !matrixNear(m, n) {
+			t.Errorf("%v decomposed to %+v recomposes to %v", m, m.Decompose(), n)
+		}
+	}
+
+	d := NewTranslateMatrix(Pt(1, 2)).Rotate(math.Pi / 4).Scale(Pt(3, 4)).Decompose()
+	want := Decomposition{Translate: Pt(1, 2), Rotate: math.Pi / 4, Scale: Pt(3, 4)}
+	if !d.Translate.Near(want.Translate, 1e-9) || !d.Scale.Near(want.Scale, 1e-9) ||
+		math.Abs(d.Rotate-want.Rotate) > 1e-9 || math.Abs(d.Skew) > 1e-9 {
+		t.Errorf("got %+v, want %+v", d, want)
+	}
+}
+
+func TestMatrixInterpolate(t *testing.T) {
+	a := NewTranslateMatrix(Pt(0, 0)).Rotate(3)
+	b := NewTranslateMatrix(Pt(10, 0)).Rotate(-3).Scale(Pt(3, 3))
+
+	if m := Interpolate(a, b, 0); !matrixNear(m, a) {
+		t.Errorf("t=0: got %v, want %v", m, a)
+	}
+	if m := Interpolate(a, b, 1); !matrixNear(m, b) {
+		t.Errorf("t=1: got %v, want %v", m, b)
+	}
+	//the short way from 3 to -3 is through π
+	want := NewTranslateMatrix(Pt(5, 0)).Rotate(math.Pi).Scale(Pt(2, 2))
+	if m := Interpolate(a, b, .5); !matrixNear(m, want) {
+		t.Errorf("t=.5: got %v, want %v", m, want)
+	}
+}
+
+func TestMatrixPredicates(t *testing.T) {
+	I := NewIdentityMatrix()
+	T := NewTranslateMatrix(Pt(1, 1))
+	S := NewScaleMatrix(Pt(2, 3)).Translate(Pt(1, 1))
+	R := NewRotateMatrix(1)
+	Z := NewScaleMatrix(Pt(0, 1))
+
+	for _, tc := range []struct {
+		m                          Matrix
+		id, inv, trans, scaleTrans bool
+	}{
+		{I, true, true, true, true},
+		{T, false, true, true, true},
+		{S, false, true, false, true},
+		{R, false, true, false, false},
+		{Z, false, false, false, true},
+	} {
+		if got := tc.m.IsIdentity(); got != tc.id {
+			t.Errorf("%v IsIdentity = %v", tc.m, got)
+		}
+		if got := tc.m.IsInvertible(); got != tc.inv {
+			t.Errorf("%v IsInvertible = %v", tc.m, got)
+		}
+		if got := tc.m.IsTranslation(); got != tc.trans {
+			t.Errorf("%v IsTranslation = %v", tc.m, got)
+		}
+		if got := tc.m.IsScaleTranslate(); got != tc.scaleTrans {
+			t.Errorf("%v IsScaleTranslate = %v", tc.m, got)
+		}
+	}
+
+	if d := S.Determinant(); d != 6 {
+		t.Errorf("determinant %g, want 6", d)
+	}
+
+	if _, err := Z.Invert(); err != ErrInvalidMatrix {
+		t.Errorf("inverting singular matrix: got %v, want ErrInvalidMatrix", err)
+	}
+	inv, err := S.Invert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := S.Mul(inv); !matrixNear(m, I) {
+		t.Errorf("S * S⁻¹ = %v", m)
+	}
+}
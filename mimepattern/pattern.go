@@ -37,7 +37,7 @@ func URL(URL *url.URL, r io.Reader) (cairo.Pattern, error) {
 		return nil, err
 	}
 
-	is, err := cairo.FromImage(img)
+	is, err := cairo.FromImage(img, cairo.Pt(1, 1))
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +74,7 @@ func New(mime mime, r io.Reader) (cairo.Pattern, error) {
 		return nil, err
 	}
 
-	is, err := cairo.FromImage(img)
+	is, err := cairo.FromImage(img, cairo.Pt(1, 1))
 	if err != nil {
 		return nil, err
 	}
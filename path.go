@@ -33,12 +33,16 @@ package cairo
 //}
 import "C"
 
-func cPath(p *C.cairo_path_t) (path Path, err error) {
+import (
+	"encoding/json"
+)
+
+func cPath(p *C.cairo_path_t, op, kind string, obj interface{}) (path Path, err error) {
 	if p == nil {
 		err = ErrInvalidPathData
 		return
 	}
-	if err = toerr(p.status); err != nil {
+	if err = toerr(p.status, op, kind, obj); err != nil {
 		return
 	}
 
@@ -197,6 +201,44 @@ func (p *Path) ClosePath() {
 	p.append(PathClosePath)
 }
 
+type jsonPathElement struct {
+	Type   pathDataType
+	Points []Point `json:",omitempty"`
+}
+
+//MarshalJSON encodes p as an array of objects with fields Type and Points.
+//
+//If p is not valid, ErrInvalidPathData is returned.
+func (p Path) MarshalJSON() ([]byte, error) {
+	if !p.valid() {
+		return nil, ErrInvalidPathData
+	}
+	out := make([]jsonPathElement, 0, len(p))
+	for _, pe := range p {
+		out = append(out, jsonPathElement{pe.Type(), pe.pts()})
+	}
+	return json.Marshal(out)
+}
+
+//UnmarshalJSON decodes a path encoded by MarshalJSON.
+//
+//If the decoded path is not valid, ErrInvalidPathData is returned.
+func (p *Path) UnmarshalJSON(b []byte) error {
+	var in []jsonPathElement
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+	path := make(Path, 0, len(in))
+	for _, pe := range in {
+		path.append(pe.Type, pe.Points...)
+	}
+	if !path.valid() {
+		return ErrInvalidPathData
+	}
+	*p = path
+	return nil
+}
+
 func (p Path) sizeMult() (sz int) {
 	for _, pe := range p {
 		sz += 1 + pe.len()
@@ -1,17 +1,18 @@
 package cairo
 
 //#cgo pkg-config: cairo
-//#include <cairo/cairo.h>
+//#include "weak.h"
 import "C"
 
 import (
+	"encoding/json"
 	"errors"
 	"image/color"
 	"runtime"
 )
 
-func getPatternType(p *C.cairo_pattern_t) patternType {
-	return patternType(C.cairo_pattern_get_type(p))
+func getPatternType(p *C.cairo_pattern_t) PatternType {
+	return PatternType(C.cairo_pattern_get_type(p))
 }
 
 func cPattern(p *C.cairo_pattern_t) (Pattern, error) {
@@ -28,7 +29,7 @@ func cPattern(p *C.cairo_pattern_t) (Pattern, error) {
 		return cNewMesh(p), nil
 	case PatternTypeRasterSource:
 		id := patternGetSubtypeID(p)
-		t, ok := rastersubtypes[id]
+		t, ok := rasterSubtype(id)
 		if !ok {
 			panic("raster pattern subtype not registered")
 		}
@@ -49,14 +50,14 @@ func cPattern(p *C.cairo_pattern_t) (Pattern, error) {
 //
 //Originally cairo_pattern_t.
 type Pattern interface {
-	Type() patternType
+	Type() PatternType
 	Err() error
 	Close() error
 
-	SetExtend(extend)
-	Extend() extend
-	SetFilter(filter)
-	Filter() filter
+	SetExtend(Extend)
+	Extend() Extend
+	SetFilter(Filter)
+	Filter() Filter
 	SetMatrix(Matrix)
 	Matrix() Matrix
 
@@ -85,7 +86,7 @@ func (p *XtensionPattern) XtensionRaw() *C.cairo_pattern_t {
 //Type returns the type of the pattern.
 //
 //Originally cairo_pattern_get_type.
-func (p *XtensionPattern) Type() patternType {
+func (p *XtensionPattern) Type() PatternType {
 	return getPatternType(p.p)
 }
 
@@ -93,10 +94,15 @@ func (p *XtensionPattern) Type() patternType {
 //
 //Originally cairo_pattern_status.
 func (p *XtensionPattern) Err() error {
+	return p.err("")
+}
+
+//err reports the current error state of p as reported by op.
+func (p *XtensionPattern) err(op string) error {
 	if p.p == nil {
 		return ErrInvalidLibcairoHandle
 	}
-	return toerr(C.cairo_pattern_status(p.p))
+	return toerr(C.cairo_pattern_status(p.p), op, "pattern", p.p)
 }
 
 //Close releases the pattern's resources.
@@ -106,7 +112,7 @@ func (p *XtensionPattern) Close() error {
 	if p.p == nil {
 		return nil
 	}
-	err := p.Err()
+	err := p.err("Pattern.Close")
 	runtime.SetFinalizer(p, nil)
 	C.cairo_pattern_destroy(p.p)
 	p.p = nil
@@ -116,29 +122,29 @@ func (p *XtensionPattern) Close() error {
 //SetExtend sets the mode used for drawing outside the area of this pattern.
 //
 //Originally cairo_pattern_set_extend.
-func (p *XtensionPattern) SetExtend(e extend) {
+func (p *XtensionPattern) SetExtend(e Extend) {
 	C.cairo_pattern_set_extend(p.p, e.c())
 }
 
 //Extend reports the mode used for drawing outside the area of this pattern.
 //
 //Originally cairo_pattern_get_extend.
-func (p *XtensionPattern) Extend() extend {
-	return extend(C.cairo_pattern_get_extend(p.p))
+func (p *XtensionPattern) Extend() Extend {
+	return Extend(C.cairo_pattern_get_extend(p.p))
 }
 
 //SetFilter sets the filter used when resizing this pattern.
 //
 //Originally cairo_pattern_set_filter.
-func (p *XtensionPattern) SetFilter(f filter) {
+func (p *XtensionPattern) SetFilter(f Filter) {
 	C.cairo_pattern_set_filter(p.p, f.c())
 }
 
 //Filter returns the filter used when resizing this pattern.
 //
 //Originally cairo_pattern_get_filter.
-func (p *XtensionPattern) Filter() filter {
-	return filter(C.cairo_pattern_get_filter(p.p))
+func (p *XtensionPattern) Filter() Filter {
+	return Filter(C.cairo_pattern_get_filter(p.p))
 }
 
 //SetMatrix sets the pattern's transformation matrix.
@@ -224,14 +230,14 @@ func NewSurfacePattern(s Surface) (sp SurfacePattern, err error) {
 		XtensionPattern: XtensionNewPattern(p),
 		s:               s,
 	}
-	return sp, sp.Err()
+	return sp, sp.err("NewSurfacePattern")
 }
 
 func cNewSurfacePattern(p *C.cairo_pattern_t) (Pattern, error) {
 	var s *C.cairo_surface_t
 	C.cairo_pattern_get_surface(p, &s)
 	C.cairo_surface_reference(s) //returned surface does not up libcairo refcount
-	S, err := XtensionRevivifySurface(p)
+	S, err := XtensionRevivifySurface(s)
 	if err != nil {
 		return nil, err
 	}
@@ -264,6 +270,26 @@ type ColorStop struct {
 	Color  color.Color
 }
 
+type jsonColorStop struct {
+	Offset float64
+	Color  AlphaColor
+}
+
+//MarshalJSON encodes c with its Color converted to an AlphaColor.
+func (c ColorStop) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonColorStop{c.Offset, colorToAlpha(c.Color)})
+}
+
+//UnmarshalJSON decodes c, setting its Color to an AlphaColor.
+func (c *ColorStop) UnmarshalJSON(b []byte) error {
+	var j jsonColorStop
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	c.Offset, c.Color = j.Offset, j.Color
+	return nil
+}
+
 func (c ColorStop) c() (o, r, g, b, a C.double) {
 	o = C.double(clamp01(c.Offset))
 	r, g, b, a = colorToAlpha(c.Color).c()
@@ -347,6 +373,14 @@ func cNewLinearGradient(p *C.cairo_pattern_t) Pattern {
 	}
 }
 
+//Clone returns a new linear gradient with the same line, color stops,
+//matrix, extend, and filter as l.
+func (l LinearGradient) Clone() LinearGradient {
+	c := NewLinearGradient(l.start, l.end, l.ColorStops()...)
+	copyPatternAttrs(c.XtensionPattern, l.XtensionPattern)
+	return c
+}
+
 //Line returns the start and end points of this linear gradient.
 //
 //Originally cairo_pattern_get_linear_points.
@@ -387,10 +421,18 @@ func cNewRadialGradient(p *C.cairo_pattern_t) Pattern {
 			XtensionPattern: XtensionNewPattern(p),
 		},
 		start: cCirc(x0, y0, r0),
-		end:   cCirc(x0, y0, r0),
+		end:   cCirc(x1, y1, r1),
 	}
 }
 
+//Clone returns a new radial gradient with the same circles, color stops,
+//matrix, extend, and filter as r.
+func (r RadialGradient) Clone() RadialGradient {
+	c := NewRadialGradient(r.start, r.end, r.ColorStops()...)
+	copyPatternAttrs(c.XtensionPattern, r.XtensionPattern)
+	return c
+}
+
 //RadialCircles reports the gradient endpoints.
 //
 //Originally cairo_pattern_get_radial_circles.
@@ -571,6 +613,37 @@ func (p *Patch) SetCornerColors(cs ...color.Color) {
 	p.Colors = cs
 }
 
+type jsonPatch struct {
+	Controls []Point      `json:",omitempty"`
+	Colors   []AlphaColor `json:",omitempty"`
+	Path     Path
+}
+
+//MarshalJSON encodes p with its Colors converted to AlphaColor.
+func (p Patch) MarshalJSON() ([]byte, error) {
+	j := jsonPatch{
+		Controls: p.Controls,
+		Path:     p.Path,
+	}
+	for _, c := range p.Colors {
+		j.Colors = append(j.Colors, colorToAlpha(c))
+	}
+	return json.Marshal(j)
+}
+
+//UnmarshalJSON decodes p, setting its Colors to AlphaColor.
+func (p *Patch) UnmarshalJSON(b []byte) error {
+	var j jsonPatch
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	p.Controls, p.Path, p.Colors = j.Controls, j.Path, nil
+	for _, c := range j.Colors {
+		p.Colors = append(p.Colors, c)
+	}
+	return nil
+}
+
 func (p *Patch) apply(m Mesh) error {
 	if len(p.Controls) > 4 {
 		return errors.New("a Patch cannot have more than 4 control points")
@@ -578,7 +651,7 @@ func (p *Patch) apply(m Mesh) error {
 	if len(p.Colors) > 4 {
 		return errors.New("a Patch cannot have more than 4 corner colors")
 	}
-	C.cairo_mesh_pattern_end_patch(m.p)
+	C.cairo_mesh_pattern_begin_patch(m.p)
 	for i, c := range p.Controls {
 		x, y := c.c()
 		C.cairo_mesh_pattern_set_control_point(m.p, C.uint(i), x, y)
@@ -605,7 +678,7 @@ func (p *Patch) apply(m Mesh) error {
 		}
 	}
 	C.cairo_mesh_pattern_end_patch(m.p)
-	return m.Err()
+	return m.err("NewMesh")
 }
 
 func cPatch(m Mesh, n C.uint) (*Patch, error) {
@@ -615,20 +688,18 @@ func cPatch(m Mesh, n C.uint) (*Patch, error) {
 
 	p := &Patch{}
 	for i := C.uint(0); i < 4; i++ {
-		var x, y, r, g, b, a *C.double
-		C.cairo_mesh_pattern_get_control_point(m.p, n, i, x, y)
-		C.cairo_mesh_pattern_get_corner_color_rgba(m.p, n, i, r, g, b, a)
-		if x != nil {
-			p.Controls = append(p.Controls, cPt(*x, *y))
+		var x, y, r, g, b, a C.double
+		if C.cairo_mesh_pattern_get_control_point(m.p, n, i, &x, &y) == errSuccess {
+			p.Controls = append(p.Controls, cPt(x, y))
 		}
-		if r != nil {
-			p.Colors = append(p.Colors, cColor(*r, *g, *b, *a))
+		if C.cairo_mesh_pattern_get_corner_color_rgba(m.p, n, i, &r, &g, &b, &a) == errSuccess {
+			p.Colors = append(p.Colors, cColor(r, g, b, a))
 		}
 	}
 
 	path := C.cairo_mesh_pattern_get_path(m.p, n)
 	defer C.cairo_path_destroy(path)
-	Path, err := cPath(path)
+	Path, err := cPath(path, "Mesh.Patches", "pattern", m.p)
 	if err != nil {
 		return nil, err
 	}
@@ -639,6 +710,9 @@ func cPatch(m Mesh, n C.uint) (*Patch, error) {
 //NewMesh creates a new mesh pattern with patches.
 //There must be at least one patch.
 //
+//If libcairo does not support FeatureMesh,
+//a *NotSupportedError is returned.
+//
 //Originally cairo_pattern_create_mesh,
 //cairo_mesh_pattern_begin_patch,
 //cairo_mesh_pattern_end_patch,
@@ -651,6 +725,9 @@ func NewMesh(patches ...*Patch) (Mesh, error) {
 	if len(patches) == 0 {
 		return Mesh{}, errors.New("no patches defined on mesh pattern")
 	}
+	if err := XtensionRequire("NewMesh", FeatureMesh); err != nil {
+		return Mesh{}, err
+	}
 	p := C.cairo_pattern_create_mesh()
 	m := cNewMesh(p)
 	for _, patch := range patches {
@@ -678,7 +755,7 @@ func (m Mesh) Patches() (patches []*Patch, err error) {
 	var n C.uint
 	_ = C.cairo_mesh_pattern_get_patch_count(m.p, &n)
 	for i := C.uint(0); i < n; i++ {
-		patch, err := cPatch(m, n)
+		patch, err := cPatch(m, i)
 		if err != nil {
 			return nil, err
 		}
@@ -686,3 +763,18 @@ func (m Mesh) Patches() (patches []*Patch, err error) {
 	}
 	return
 }
+
+//Clone returns a new mesh with the same patches, matrix, extend,
+//and filter as m.
+func (m Mesh) Clone() (Mesh, error) {
+	patches, err := m.Patches()
+	if err != nil {
+		return Mesh{}, err
+	}
+	c, err := NewMesh(patches...)
+	if err != nil {
+		return Mesh{}, err
+	}
+	copyPatternAttrs(c.XtensionPattern, m.XtensionPattern)
+	return c, nil
+}
@@ -1,5 +1,13 @@
 package cairo
 
+import (
+	"encoding/json"
+	"image/color"
+	"log"
+	"reflect"
+	"testing"
+)
+
 func ExamplePatch_coons() {
 	coons := &Patch{}
 	coons.MoveTo(ZP)
@@ -16,3 +24,285 @@ func ExamplePatch_gouraudShadedTriangle() {
 	gst.LineTo(Pt(130, 70))
 	gst.SetCornerColors(Red, Green, Blue)
 }
+
+func ExamplePatternDescription() {
+	g := NewLinearGradient(ZP, Pt(0, 100),
+		ColorStop{0, Red},
+		ColorStop{1, Blue},
+	)
+	defer g.Close()
+
+	d, err := g.Describe()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	theme, err := json.Marshal(d)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	//later, reload the pattern from the theme
+	var d2 PatternDescription
+	if err := json.Unmarshal(theme, &d2); err != nil {
+		log.Fatalln(err)
+	}
+	p, err := NewPatternFromDescription(d2)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer p.Close()
+}
+
+func TestPatchJSON(t *testing.T) {
+	p := &Patch{}
+	p.MoveTo(ZP)
+	p.LineTo(Pt(10, 0))
+	p.CurveTo(Pt(10, 5), Pt(5, 10), Pt(0, 10))
+	p.SetControlPoints(Pt(1, 1), Pt(2, 2))
+	p.SetCornerColors(Red, Blue.Alpha(.5))
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var q Patch
+	if err := json.Unmarshal(b, &q); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(q.Path, p.Path) || !reflect.DeepEqual(q.Controls, p.Controls) {
+		t.Errorf("got %+v, want %+v", q, p)
+	}
+	want := []color.Color{Red.Alpha(1), Blue.Alpha(.5)}
+	if !reflect.DeepEqual(q.Colors, want) {
+		t.Errorf("got colors %v, want %v", q.Colors, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Path":[{"Type":0}]}`), &q); err != ErrInvalidPathData {
+		t.Errorf("invalid path: got %v, want ErrInvalidPathData", err)
+	}
+}
+
+func TestPatternRegressions(t *testing.T) {
+	s, err := NewImageSurface(FormatARGB32, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	c, err := New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	//the end circle of a revived radial gradient was its start circle
+	start, end := Circ(0, 0, 1), Circ(2, 2, 3)
+	rg := NewRadialGradient(start, end)
+	defer rg.Close()
+	p, err := c.SetSource(rg).Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if s0, e0 := p.(RadialGradient).RadialCircles(); s0 != start || e0 != end {
+		t.Errorf("RadialCircles: got %v %v, want %v %v", s0, e0, start, end)
+	}
+
+	//surface patterns were revived from the pattern, not its surface
+	sp, err := NewSurfacePattern(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sp.Close()
+	p, err = c.SetSource(sp).Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if _, ok := p.(SurfacePattern).Surface().(ImageSurface); !ok {
+		t.Errorf("surface of surface pattern: got %T", p.(SurfacePattern).Surface())
+	}
+
+	//patches were ended before they were begun,
+	//and read back through nil pointers from the wrong index
+	var patches []*Patch
+	for _, col := range []color.Color{Red, Blue} {
+		patch := &Patch{}
+		patch.MoveTo(ZP)
+		patch.LineTo(Pt(4, 0))
+		patch.LineTo(Pt(4, 4))
+		patch.LineTo(Pt(0, 4))
+		patch.SetCornerColors(col)
+		patches = append(patches, patch)
+	}
+	m, err := NewMesh(patches...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	got, err := m.Patches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Patches: got %d patches, want 2", len(got))
+	}
+	for i, want := range []AlphaColor{Red.Alpha(1), Blue.Alpha(1)} {
+		if len(got[i].Colors) == 0 || got[i].Colors[0] != want {
+			t.Errorf("patch %d: got colors %v, want first %v", i, got[i].Colors, want)
+		}
+		if len(got[i].Controls) != 4 {
+			t.Errorf("patch %d: got %d control points, want 4", i, len(got[i].Controls))
+		}
+	}
+}
+
+func TestDescribeRoundTrip(t *testing.T) {
+	s, err := NewImageSurface(FormatARGB32, 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	patch := &Patch{}
+	patch.MoveTo(ZP)
+	patch.LineTo(Pt(4, 0))
+	patch.CurveTo(Pt(4, 1), Pt(3, 4), Pt(4, 4))
+	patch.LineTo(Pt(0, 4))
+	patch.SetCornerColors(Red, Green, Blue, Color{R: 1, G: 1})
+
+	newPatterns := map[string]func() (Pattern, error){
+		"linear": func() (Pattern, error) {
+			return NewLinearGradient(ZP, Pt(0, 100),
+				ColorStop{0, Red},
+				ColorStop{.5, Green.Alpha(.5)},
+				ColorStop{1, Blue},
+			), nil
+		},
+		"radial": func() (Pattern, error) {
+			return NewRadialGradient(Circ(1, 2, 3), Circ(4, 5, 6),
+				ColorStop{0, Red},
+				ColorStop{1, Blue},
+			), nil
+		},
+		"mesh": func() (Pattern, error) {
+			return NewMesh(patch)
+		},
+		"surface": func() (Pattern, error) {
+			return NewSurfacePattern(s)
+		},
+	}
+	for name, newPattern := range newPatterns {
+		p, err := newPattern()
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		p.SetMatrix(NewScaleMatrix(Pt(2, 3)))
+		p.SetExtend(ExtendReflect)
+		p.SetFilter(FilterNearest)
+
+		d, err := p.(Describer).Describe()
+		if err != nil {
+			t.Errorf("%s: Describe: %v", name, err)
+			p.Close()
+			continue
+		}
+		b, err := json.Marshal(d)
+		if err != nil {
+			t.Errorf("%s: Marshal: %v", name, err)
+		}
+		var d2 PatternDescription
+		if err := json.Unmarshal(b, &d2); err != nil {
+			t.Errorf("%s: Unmarshal: %v", name, err)
+		}
+		//the surface is not encoded, so it must be supplied again
+		d2.Surface = d.Surface
+
+		p2, err := NewPatternFromDescription(d2)
+		if err != nil {
+			t.Errorf("%s: NewPatternFromDescription: %v", name, err)
+		} else {
+			d3, err := p2.(Describer).Describe()
+			if err != nil {
+				t.Errorf("%s: Describe after round trip: %v", name, err)
+			}
+			if d.Surface != nil {
+				if d3.Surface == nil || d3.Surface.XtensionRaw() != d.Surface.XtensionRaw() {
+					t.Errorf("%s: round trip changed surface", name)
+				}
+				if d3.Surface != nil {
+					d3.Surface.Close()
+				}
+				d3.Surface = d.Surface
+			}
+			if !reflect.DeepEqual(d, d3) {
+				t.Errorf("%s: round trip\n got %#v\nwant %#v", name, d3, d)
+			}
+			p2.Close()
+		}
+		if d.Surface != nil {
+			d.Surface.Close()
+		}
+		p.Close()
+	}
+}
+
+func TestCloneIndependent(t *testing.T) {
+	stops := []ColorStop{{0, Red}, {1, Blue}}
+	extra := ColorStop{.5, Green}
+
+	l := NewLinearGradient(ZP, Pt(10, 10), stops...)
+	defer l.Close()
+	lc := l.Clone()
+	defer lc.Close()
+	lc.addColorStops([]ColorStop{extra})
+	lc.SetExtend(ExtendReflect)
+	if got := len(l.ColorStops()); got != len(stops) {
+		t.Errorf("linear: adding a stop to the clone left %d stops on the original, want %d", got, len(stops))
+	}
+	if got := len(lc.ColorStops()); got != len(stops)+1 {
+		t.Errorf("linear: clone has %d stops, want %d", got, len(stops)+1)
+	}
+	if l.Extend() == ExtendReflect {
+		t.Error("linear: setting the extend of the clone changed the original")
+	}
+
+	r := NewRadialGradient(Circ(0, 0, 1), Circ(0, 0, 5), stops...)
+	defer r.Close()
+	rc := r.Clone()
+	defer rc.Close()
+	rc.addColorStops([]ColorStop{extra})
+	if got := len(r.ColorStops()); got != len(stops) {
+		t.Errorf("radial: adding a stop to the clone left %d stops on the original, want %d", got, len(stops))
+	}
+	if s, e := rc.RadialCircles(); s != Circ(0, 0, 1) || e != Circ(0, 0, 5) {
+		t.Errorf("radial: clone has circles %v %v", s, e)
+	}
+
+	patch := &Patch{}
+	patch.MoveTo(ZP)
+	patch.LineTo(Pt(4, 0))
+	patch.LineTo(Pt(4, 4))
+	patch.SetCornerColors(Red, Green, Blue)
+	m, err := NewMesh(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	m.SetFilter(FilterBest)
+	mc, err := m.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mc.Close()
+	if mc.Filter() != FilterBest {
+		t.Errorf("mesh: clone has filter %v, want %v", mc.Filter(), FilterBest)
+	}
+	mc.SetMatrix(NewTranslateMatrix(Pt(1, 1)))
+	if m.Matrix() != NewIdentityMatrix() {
+		t.Errorf("mesh: setting the matrix of the clone changed the original to %v", m.Matrix())
+	}
+	if mc.XtensionRaw() == m.XtensionRaw() {
+		t.Error("mesh: clone shares its libcairo pattern with the original")
+	}
+}
@@ -1,20 +1,20 @@
 //Package pdf implements the PDF backend for libcairo rendering.
 //
-//Libcairo must be compiled with
+//If libcairo is not compiled with
 //	CAIRO_HAS_PDF_SURFACE
-//in addition to the requirements of cairo.
+//this package still builds, but New returns a *cairo.NotSupportedError.
 package pdf
 
 //#cgo pkg-config: cairo
 //#include <stdlib.h>
-//#include <cairo/cairo.h>
-//#include <cairo/cairo-pdf.h>
+//#include "weak.h"
 import "C"
 
 import (
 	"io"
 
 	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/fallback"
 )
 
 //Surface is a PDF surface.
@@ -22,6 +22,7 @@ import (
 //Surface implements cairo.PagedVectorSurface.
 type Surface struct {
 	cairo.XtensionPagedVectorSurface
+	fallbacks *fallback.Writer
 }
 
 func news(s *C.cairo_surface_t) (Surface, error) {
@@ -46,13 +47,30 @@ func init() {
 //
 //Originally cairo_pdf_surface_create_for_stream.
 func New(w io.Writer, width, height float64) (Surface, error) {
-	wp := cairo.XtensionWrapWriter(w)
+	if err := cairo.XtensionRequire("pdf.New", cairo.FeaturePDF); err != nil {
+		return Surface{}, err
+	}
+	fw := fallback.NewWriter(w, fallback.PDF)
+	wp := cairo.XtensionWrapWriter(fw)
 	pdf := C.cairo_pdf_surface_create_for_stream(cairo.XtensionCairoWriteFuncT, wp, C.double(width), C.double(height))
 	S, err := news(pdf)
+	S.fallbacks = fw
 	S.XtensionRegisterWriter(wp)
 	return S, err
 }
 
+//FallbackReport returns the fallback images written by s.
+//
+//When libcairo draws something that cannot be represented natively in PDF,
+//it is rasterized and embedded as a fallback image,
+//which can greatly increase the size of the output.
+//
+//The report is only complete after s has been closed.
+//If s was not created by New, it is always empty.
+func (s Surface) FallbackReport() fallback.Report {
+	return s.fallbacks.Report()
+}
+
 //RestrictTo restricts the generated PDF to the specified verison.
 //
 //This method should only be called before any drawing operations have been
@@ -1,12 +1,13 @@
 package pdf
 
 //#cgo pkg-config: cairo
-//#include <cairo/cairo.h>
-//#include <cairo/cairo-pdf.h>
+//#include "weak.h"
 import "C"
 
 import (
 	"unsafe"
+
+	"github.com/jimmyfrasche/cairo"
 )
 
 //cairo_pdf_version_t
@@ -24,6 +25,9 @@ const (
 )
 
 func (p version) String() string {
+	if !cairo.Features().Has(cairo.FeaturePDF) {
+		return "unknown PDF version"
+	}
 	v := C.cairo_pdf_version_to_string(C.cairo_pdf_version_t(p))
 	if v == nil {
 		return "unknown PDF version"
@@ -33,8 +37,14 @@ func (p version) String() string {
 
 //Versions reports the supported PDF versions.
 //
+//If libcairo does not support PDF, Versions returns nil.
+//
 //Originally cairo_pdf_get_versions.
 func Versions() (versions []version) {
+	if !cairo.Features().Has(cairo.FeaturePDF) {
+		return nil
+	}
+
 	var vs *C.cairo_pdf_version_t
 	var N C.int
 
@@ -0,0 +1,25 @@
+//Declarations of the libcairo PDF functions used by this package.
+//They are weak, so that this package links against a libcairo compiled
+//without the PDF backend, and New can report that it is not supported.
+#include <cairo/cairo.h>
+
+#if CAIRO_HAS_PDF_SURFACE
+#include <cairo/cairo-pdf.h>
+#else
+typedef enum _cairo_pdf_version {
+	CAIRO_PDF_VERSION_1_4,
+	CAIRO_PDF_VERSION_1_5
+} cairo_pdf_version_t;
+
+cairo_surface_t *cairo_pdf_surface_create_for_stream(cairo_write_func_t write_func, void *closure, double width_in_points, double height_in_points);
+void cairo_pdf_surface_restrict_to_version(cairo_surface_t *surface, cairo_pdf_version_t version);
+void cairo_pdf_get_versions(cairo_pdf_version_t const **versions, int *num_versions);
+const char *cairo_pdf_version_to_string(cairo_pdf_version_t version);
+void cairo_pdf_surface_set_size(cairo_surface_t *surface, double width_in_points, double height_in_points);
+#endif
+
+#pragma weak cairo_pdf_surface_create_for_stream
+#pragma weak cairo_pdf_surface_restrict_to_version
+#pragma weak cairo_pdf_get_versions
+#pragma weak cairo_pdf_version_to_string
+#pragma weak cairo_pdf_surface_set_size
@@ -10,6 +10,8 @@ import (
 type comment struct {
 	raw             bool
 	key, value, out string
+	sections        Section
+	err             error
 }
 
 func (c comment) String() string {
@@ -97,6 +99,9 @@ func (c comment) Err() (err error) {
 	if c.raw {
 		return nil
 	}
+	if c.err != nil {
+		return c.err
+	}
 	if err = c.invalidKey(); err != nil {
 		return err
 	}
@@ -135,6 +140,20 @@ func (c Comments) Err() error {
 	return nil
 }
 
+//errIn is Err, but also returns an error if any comment does not belong
+//in section s.
+func (c Comments) errIn(s Section) error {
+	if err := c.Err(); err != nil {
+		return err
+	}
+	for _, c := range c {
+		if c.Section()&s == 0 {
+			return fmt.Errorf("%s comment cannot be used in the %s section", c.name(), s)
+		}
+	}
+	return nil
+}
+
 //Comment specifies a PostScript Document Structuring Comment (DSC).
 //
 //The returned comment has String and Err methods.
@@ -173,13 +192,51 @@ func (c Comments) Err() error {
 //
 //Even if a comment does not result in an error, that does not mean it produces
 //the desires effect, only that it is not invalid.
+//
+//If key is one of the DSC keys below, the comment belongs in the sections
+//the DSC places it in:
+//	Header:            Title For Routing Copyright Version Orientation
+//	                   PageOrder ProofMode Requirements DocumentMedia
+//	                   DocumentNeededResources DocumentSuppliedResources
+//	Setup, PageSetup:  IncludeFeature
+//	PageSetup:         PageOrientation PageMedia PageRequirements
+//Otherwise, the comment may be used in any section.
+//
+//The typed comments, such as Title or PageMedia, should be preferred for the
+//comments they create.
 func Comment(key, value string) comment {
+	key = strings.TrimSpace(key)
+	sections, ok := knownSections[key]
+	if !ok {
+		sections = AnySection
+	}
 	return comment{
-		key:   strings.TrimSpace(key),
-		value: strings.TrimSpace(value),
+		key:      key,
+		value:    strings.TrimSpace(value),
+		sections: sections,
 	}
 }
 
+//knownSections is the sections of the DSC keys that Comment knows.
+var knownSections = map[string]Section{
+	"Title":                     Header,
+	"For":                       Header,
+	"Routing":                   Header,
+	"Copyright":                 Header,
+	"Version":                   Header,
+	"Orientation":               Header,
+	"PageOrder":                 Header,
+	"ProofMode":                 Header,
+	"Requirements":              Header,
+	"DocumentMedia":             Header,
+	"DocumentNeededResources":   Header,
+	"DocumentSuppliedResources": Header,
+	"IncludeFeature":            Setup | PageSetup,
+	"PageOrientation":           PageSetup,
+	"PageMedia":                 PageSetup,
+	"PageRequirements":          PageSetup,
+}
+
 //Commentf is a convenience function for
 //	Comment(key, fmt.Sprintf(value, vars...))
 //
@@ -194,7 +251,8 @@ func Commentf(key, value string, vars ...interface{}) comment {
 //Use at your own peril.
 func RawComment(s string) comment {
 	return comment{
-		raw:   true,
-		value: s,
+		raw:      true,
+		value:    s,
+		sections: AnySection,
 	}
 }
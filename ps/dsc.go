@@ -0,0 +1,346 @@
+package ps
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+//Section is a set of sections of a PostScript document that comments
+//may be added to.
+type Section int
+
+//The sections of a PostScript document that comments may be added to.
+const (
+	//Header is the header section, set by the header argument of New.
+	Header Section = 1 << iota
+	//Setup is the setup section, set by the setup argument of New.
+	Setup
+	//PageSetup is the page setup section of each page,
+	//set by Surface.AddComments.
+	PageSetup
+	//AnySection is every section.
+	AnySection = Header | Setup | PageSetup
+)
+
+var sectionNames = []struct {
+	s    Section
+	name string
+}{
+	{Header, "header"},
+	{Setup, "setup"},
+	{PageSetup, "page setup"},
+}
+
+func (s Section) String() string {
+	var names []string
+	for _, n := range sectionNames {
+		if s&n.s != 0 {
+			names = append(names, n.name)
+		}
+	}
+	if len(names) == 0 {
+		return "no section"
+	}
+	return strings.Join(names, "|")
+}
+
+//Section reports the sections the comment belongs in.
+func (c comment) Section() Section {
+	return c.sections
+}
+
+//name returns the DSC key of c, for errors.
+func (c comment) name() string {
+	if c.raw {
+		return "raw"
+	}
+	return "%%" + c.key
+}
+
+//typed returns a comment with key and value that belongs in sections s.
+func typed(s Section, key, value string) comment {
+	c := Comment(key, value)
+	c.sections = s
+	return c
+}
+
+//typedErr returns a comment with key that is invalid because of err.
+func typedErr(key string, err error) comment {
+	return comment{
+		key:      key,
+		sections: AnySection,
+		err:      fmt.Errorf("%%%%%s: %s", key, err),
+	}
+}
+
+//text returns s formatted as DSC text,
+//enclosed in parentheses if it is empty or contains whitespace.
+func text(s string) string {
+	if s == "" || strings.IndexFunc(s, unicode.IsSpace) != -1 {
+		r := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+		return "(" + r.Replace(s) + ")"
+	}
+	return s
+}
+
+//word validates that s is non-empty with no whitespace.
+func word(what, s string) error {
+	if s == "" {
+		return fmt.Errorf("%s is empty", what)
+	}
+	if strings.IndexFunc(s, unicode.IsSpace) != -1 {
+		return fmt.Errorf("%s cannot contain whitespace, got: %s", what, s)
+	}
+	return nil
+}
+
+//Title is the %%Title comment, naming the document.
+//
+//It belongs in the Header section.
+func Title(title string) comment {
+	if strings.TrimSpace(title) == "" {
+		return typedErr("Title", errors.New("title is empty"))
+	}
+	return typed(Header, "Title", title)
+}
+
+//For is the %%For comment, naming the person or entity the document is
+//printed for.
+//
+//It belongs in the Header section.
+func For(name string) comment {
+	if strings.TrimSpace(name) == "" {
+		return typedErr("For", errors.New("name is empty"))
+	}
+	return typed(Header, "For", name)
+}
+
+//Media describes a medium, such as a size of paper, that a document is
+//printed on.
+type Media struct {
+	//Name is the name of the media, such as "A4".
+	//It cannot contain whitespace.
+	Name string
+	//Width and Height are the size of the media in points.
+	Width, Height float64
+	//Weight is the weight of the media in grams per square meter,
+	//or 0 if it does not matter.
+	Weight float64
+	//Color is the color of the media, such as "white",
+	//or "" if it does not matter.
+	Color string
+	//Type is the type of the media, such as "transparency",
+	//or "" if it does not matter.
+	Type string
+}
+
+func (m Media) String() string {
+	return fmt.Sprintf("%s %g %g %g %s %s", m.Name, m.Width, m.Height, m.Weight, text(m.Color), text(m.Type))
+}
+
+//Err reports whether m is invalid.
+func (m Media) Err() error {
+	if err := word("media name", m.Name); err != nil {
+		return err
+	}
+	if m.Width <= 0 || m.Height <= 0 {
+		return fmt.Errorf("media %s has invalid size %gx%g", m.Name, m.Width, m.Height)
+	}
+	if m.Weight < 0 {
+		return fmt.Errorf("media %s has negative weight %g", m.Name, m.Weight)
+	}
+	return nil
+}
+
+//DocumentMedia is the %%DocumentMedia comment, listing the media used by
+//the document.
+//The first medium is the default.
+//
+//Each medium after the first is listed in a %%+ continuation comment,
+//so the result should be appended to the other comments of the Header section:
+//	header := ps.Comments{ps.Title("Report")}
+//	header = append(header, ps.DocumentMedia(a4, letter)...)
+//
+//Use PageMedia to select the medium of a page by name.
+//
+//It belongs in the Header section.
+func DocumentMedia(media ...Media) Comments {
+	if len(media) == 0 {
+		return Comments{typedErr("DocumentMedia", errors.New("no media"))}
+	}
+	cs := make(Comments, 0, len(media))
+	for i, m := range media {
+		if err := m.Err(); err != nil {
+			return Comments{typedErr("DocumentMedia", err)}
+		}
+		if i == 0 {
+			cs = append(cs, typed(Header, "DocumentMedia", m.String()))
+			continue
+		}
+		c := typed(Header, "+", m.String())
+		c.out = "%%+ " + c.value
+		cs = append(cs, c)
+	}
+	return cs
+}
+
+//Orientation is the orientation of a page.
+//
+//It is also used by cairo/document for the pages of any surface.
+type Orientation int
+
+//The orientations of a page.
+const (
+	//Portrait pages are taller than they are wide.
+	Portrait Orientation = iota
+	//Landscape pages are wider than they are tall.
+	Landscape
+)
+
+func (o Orientation) String() string {
+	switch o {
+	case Portrait:
+		return "Portrait"
+	case Landscape:
+		return "Landscape"
+	}
+	return "unknown orientation"
+}
+
+//PageOrientation is the %%PageOrientation comment, telling viewers the
+//orientation of the page.
+//
+//See the landscape example for how to draw a landscape page.
+//
+//It belongs in the PageSetup section.
+//The DSC also allows it as a default for every page, but only in a
+//defaults section, which libcairo does not write.
+func PageOrientation(o Orientation) comment {
+	if o != Portrait && o != Landscape {
+		return typedErr("PageOrientation", fmt.Errorf("invalid orientation %d", int(o)))
+	}
+	return typed(PageSetup, "PageOrientation", o.String())
+}
+
+//PageMedia is the %%PageMedia comment, selecting the medium,
+//by the name given to DocumentMedia, to print the page on.
+//
+//It belongs in the PageSetup section.
+//The DSC also allows it as a default for every page, but only in a
+//defaults section, which libcairo does not write.
+func PageMedia(name string) comment {
+	if err := word("media name", name); err != nil {
+		return typedErr("PageMedia", err)
+	}
+	return typed(PageSetup, "PageMedia", name)
+}
+
+//IncludeFeature is the %%IncludeFeature comment, requesting the printer
+//feature with the PostScript Printer Description keyword, such as
+//"*Duplex", set to option.
+//The leading * of the keyword is optional.
+//
+//It belongs in the Setup section, for the whole document,
+//or the PageSetup section.
+func IncludeFeature(keyword, option string) comment {
+	keyword = strings.TrimSpace(keyword)
+	option = strings.TrimSpace(option)
+	if !strings.HasPrefix(keyword, "*") {
+		keyword = "*" + keyword
+	}
+	if err := word("feature keyword", keyword[1:]); err != nil {
+		return typedErr("IncludeFeature", err)
+	}
+	if err := word("feature option", option); err != nil {
+		return typedErr("IncludeFeature", err)
+	}
+	return typed(Setup|PageSetup, "IncludeFeature", keyword+" "+option)
+}
+
+//DuplexMode is an option of the *Duplex feature.
+type DuplexMode string
+
+//The standard duplex modes.
+const (
+	//Simplex prints on one side of the paper.
+	Simplex DuplexMode = "None"
+	//DuplexLongEdge prints on both sides of the paper,
+	//to be bound on the long edge.
+	DuplexLongEdge DuplexMode = "DuplexNoTumble"
+	//DuplexShortEdge prints on both sides of the paper,
+	//to be bound on the short edge.
+	DuplexShortEdge DuplexMode = "DuplexTumble"
+)
+
+//Duplex is shorthand for
+//	IncludeFeature("*Duplex", string(mode))
+func Duplex(mode DuplexMode) comment {
+	return IncludeFeature("*Duplex", string(mode))
+}
+
+//Tray is shorthand for
+//	IncludeFeature("*InputSlot", slot)
+//
+//The names of the input slots are specific to each printer.
+func Tray(slot string) comment {
+	return IncludeFeature("*InputSlot", slot)
+}
+
+var requirements = []string{
+	"collate",
+	"color",
+	"duplex",
+	"faceup",
+	"fax",
+	"fold",
+	"jog",
+	"manualfeed",
+	"numcopies",
+	"punch",
+	"resolution",
+	"rollfed",
+	"staple",
+}
+
+//Requirements is the %%Requirements comment, listing the features
+//the document requires of the printer, such as "duplex", "collate",
+//or, with an option in parentheses, "numcopies(2)".
+//
+//The requirements are checked against the keywords of the
+//Document Structuring Conventions:
+//	collate color duplex faceup fax fold jog
+//	manualfeed numcopies punch resolution rollfed staple
+//
+//It belongs in the Header section.
+func Requirements(reqs ...string) comment {
+	if len(reqs) == 0 {
+		return typedErr("Requirements", errors.New("no requirements"))
+	}
+	for _, r := range reqs {
+		if err := requirement(r); err != nil {
+			return typedErr("Requirements", err)
+		}
+	}
+	return typed(Header, "Requirements", strings.Join(reqs, " "))
+}
+
+func requirement(r string) error {
+	if err := word("requirement", r); err != nil {
+		return err
+	}
+	kw := r
+	if i := strings.IndexByte(r, '('); i >= 0 {
+		if !strings.HasSuffix(r, ")") {
+			return fmt.Errorf("unbalanced parentheses in requirement %s", r)
+		}
+		kw = r[:i]
+	}
+	for _, k := range requirements {
+		if k == kw {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown requirement %s", r)
+}
@@ -0,0 +1,98 @@
+package ps
+
+import "testing"
+
+func TestTypedComments(t *testing.T) {
+	a4 := Media{Name: "A4", Width: 595, Height: 842}
+	letter := Media{Name: "Letter", Width: 612, Height: 792, Weight: 75, Color: "white", Type: "plain paper"}
+	for _, test := range []struct {
+		c       comment
+		want    string
+		section Section
+	}{
+		{Title("Quarterly report"), "%%Title: Quarterly report", Header},
+		{For("Accounts"), "%%For: Accounts", Header},
+		{PageOrientation(Landscape), "%%PageOrientation: Landscape", PageSetup},
+		{PageMedia("A4"), "%%PageMedia: A4", PageSetup},
+		{Duplex(DuplexLongEdge), "%%IncludeFeature: *Duplex DuplexNoTumble", Setup | PageSetup},
+		{Tray("Lower"), "%%IncludeFeature: *InputSlot Lower", Setup | PageSetup},
+		{IncludeFeature("Collate", "True"), "%%IncludeFeature: *Collate True", Setup | PageSetup},
+		{Requirements("duplex", "numcopies(2)"), "%%Requirements: duplex numcopies(2)", Header},
+		{Comment("Custom", "x"), "%%Custom: x", AnySection},
+		//known keys
+		{Comment(" Title ", "x"), "%%Title: x", Header},
+		{Comment("PageOrientation", "Portrait"), "%%PageOrientation: Portrait", PageSetup},
+		{Commentf("IncludeFeature", "*%s True", "Collate"), "%%IncludeFeature: *Collate True", Setup | PageSetup},
+	} {
+		if err := test.c.Err(); err != nil {
+			t.Errorf("%s: %v", test.want, err)
+			continue
+		}
+		if got := test.c.String(); got != test.want {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+		if s := test.c.Section(); s != test.section {
+			t.Errorf("%s: section %s, want %s", test.want, s, test.section)
+		}
+	}
+
+	dm := DocumentMedia(a4, letter)
+	if err := dm.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"%%DocumentMedia: A4 595 842 0 () ()",
+		"%%+ Letter 612 792 75 white (plain paper)",
+	}
+	if len(dm) != len(want) {
+		t.Fatalf("DocumentMedia: got %d comments, want %d", len(dm), len(want))
+	}
+	for i, c := range dm {
+		if c.String() != want[i] {
+			t.Errorf("DocumentMedia %d: got %q, want %q", i, c, want[i])
+		}
+	}
+}
+
+func TestTypedCommentErrors(t *testing.T) {
+	for i, c := range []comment{
+		Title(" "),
+		For(""),
+		PageOrientation(Orientation(7)),
+		PageMedia("US Letter"),
+		Tray(""),
+		IncludeFeature("*", "x"),
+		Requirements(),
+		Requirements("duplex", "teleport"),
+		Requirements("numcopies(2"),
+		DocumentMedia(Media{Name: "A4"})[0],
+		DocumentMedia()[0],
+	} {
+		if c.Err() == nil {
+			t.Errorf("%d: %s: expected error", i, c)
+		}
+	}
+}
+
+func TestSections(t *testing.T) {
+	header := Comments{Title("t"), Comment("Custom", "x")}
+	setup := Comments{Duplex(Simplex), Tray("Upper")}
+	if err := errChk(header, setup); err != nil {
+		t.Errorf("valid sections: %v", err)
+	}
+	if err := errChk(setup, nil); err == nil {
+		t.Error("setup comments accepted in header")
+	}
+	if err := errChk(nil, header); err == nil {
+		t.Error("header comments accepted in setup")
+	}
+	if err := (Comments{Title("t")}).errIn(PageSetup); err == nil {
+		t.Error("header comment accepted in page setup")
+	}
+	if err := errChk(nil, Comments{PageMedia("A4")}); err == nil {
+		t.Error("page setup comment accepted in setup")
+	}
+	if err := errChk(Comments{Comment("Title", "t")}, Comments{Comment("PageMedia", "A4")}); err == nil {
+		t.Error("untyped page setup comment accepted in setup")
+	}
+}
@@ -0,0 +1,81 @@
+package ps
+
+import (
+	"errors"
+	"io"
+	"math"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/recording"
+)
+
+//ErrNoInk is returned by WriteEPS when nothing is drawn.
+var ErrNoInk = errors.New("ps: nothing drawn")
+
+//WriteEPS writes the drawing of draw to w as Encapsulated PostScript,
+//sized to the ink drawn.
+//
+//The drawing is recorded on an unbounded recording.Surface, measured with
+//InkExtents, rounded out to whole points, and replayed with its top left
+//corner at the origin of the EPS.
+//
+//Header is any DSC comments to apply to the header section,
+//as with New.
+//
+//If nothing is drawn, ErrNoInk is returned and nothing is written to w.
+func WriteEPS(w io.Writer, draw func(*cairo.Context) error, header Comments) error {
+	if err := header.errIn(Header); err != nil {
+		return err
+	}
+
+	r := recording.New(cairo.ContentColorAlpha, cairo.Rectangle{})
+	defer r.Close()
+	if err := r.Err(); err != nil {
+		return err
+	}
+	if err := drawOn(r, draw); err != nil {
+		return err
+	}
+
+	ink := inkBox(r.InkExtents())
+	if ink.Empty() {
+		return ErrNoInk
+	}
+	size := ink.Size()
+
+	s, err := New(w, size.X, size.Y, true, header, nil)
+	if err != nil {
+		s.Close()
+		return err
+	}
+	err = r.Replay(s, cairo.NewTranslateMatrix(ink.Min.Mul(-1)), cairo.Rectangle{})
+	if cerr := s.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+//inkBox returns ink rounded out to whole points.
+func inkBox(ink cairo.Rectangle) cairo.Rectangle {
+	if ink.Empty() {
+		return cairo.Rectangle{}
+	}
+	return cairo.Rect(
+		math.Floor(ink.Min.X), math.Floor(ink.Min.Y),
+		math.Ceil(ink.Max.X), math.Ceil(ink.Max.Y),
+	)
+}
+
+//drawOn calls draw with a new Context for s.
+func drawOn(s cairo.Surface, draw func(*cairo.Context) error) error {
+	c, err := cairo.New(s)
+	if err != nil {
+		c.Close()
+		return err
+	}
+	if err := draw(c); err != nil {
+		c.Close()
+		return err
+	}
+	return c.Close()
+}
@@ -0,0 +1,71 @@
+package ps
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+func TestInkBox(t *testing.T) {
+	for _, test := range []struct {
+		ink, want cairo.Rectangle
+	}{
+		{cairo.Rectangle{}, cairo.Rectangle{}},
+		{cairo.Rect(1, 2, 3, 4), cairo.Rect(1, 2, 3, 4)},
+		{cairo.Rect(-1.5, 0.25, 10.1, 20.9), cairo.Rect(-2, 0, 11, 21)},
+	} {
+		if got := inkBox(test.ink); got != test.want {
+			t.Errorf("inkBox(%v) = %v, want %v", test.ink, got, test.want)
+		}
+	}
+}
+
+var boundingBox = regexp.MustCompile(`(?m)^%%BoundingBox: (.*)$`)
+
+func TestWriteEPS(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteEPS(&buf, func(c *cairo.Context) error {
+		c.Rectangle(cairo.Rect(10.5, 20, 40, 60)).Fill()
+		return c.Err()
+	}, Comments{Title("box")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	//the ink, rounded out to (10, 20) to (40, 60), is moved to the origin,
+	//so it covers the whole EPS
+	m := boundingBox.FindStringSubmatch(out)
+	if m == nil {
+		t.Fatalf("no %%%%BoundingBox in\n%s", out)
+	}
+	if got, want := m[1], "0 0 30 40"; got != want {
+		t.Errorf("got %%%%BoundingBox: %s, want %s", got, want)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("\n%%Title: box\n")) {
+		t.Error("no %%Title in header")
+	}
+}
+
+func TestWriteEPSErrors(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteEPS(&buf, func(c *cairo.Context) error {
+		return c.Err()
+	}, nil)
+	if err != ErrNoInk {
+		t.Errorf("nothing drawn: got %v, want ErrNoInk", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("nothing drawn: wrote %d bytes", buf.Len())
+	}
+
+	err = WriteEPS(&buf, func(c *cairo.Context) error {
+		c.Paint()
+		return c.Err()
+	}, Comments{PageMedia("A4")})
+	if err == nil {
+		t.Error("PageMedia in header: no error")
+	}
+}
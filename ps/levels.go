@@ -1,11 +1,13 @@
 package ps
 
 //#cgo pkg-config: cairo
-//#include <cairo/cairo-ps.h>
+//#include "weak.h"
 import "C"
 
 import (
 	"unsafe"
+
+	"github.com/jimmyfrasche/cairo"
 )
 
 //cairo_ps_level_t
@@ -34,6 +36,9 @@ func (l level) c() C.cairo_ps_level_t {
 }
 
 func (l level) String() string {
+	if !cairo.Features().Has(cairo.FeaturePS) {
+		return "unknown PS level"
+	}
 	v := C.cairo_ps_level_to_string(C.cairo_ps_level_t(l))
 	if v == nil {
 		return "unknown PS level"
@@ -43,8 +48,14 @@ func (l level) String() string {
 
 //Levels reports the supported language levels.
 //
+//If libcairo does not support PostScript, Levels returns nil.
+//
 //Originally cairo_ps_get_levels.
 func Levels() (levels []level) {
+	if !cairo.Features().Has(cairo.FeaturePS) {
+		return nil
+	}
+
 	var lvls *C.cairo_ps_level_t
 	var N C.int
 
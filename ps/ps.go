@@ -4,6 +4,8 @@
 //
 //EPS files must contain only one page.
 //
+//WriteEPS creates an EPS file sized to fit its drawing exactly.
+//
 //Note that libcairo does not include the device independent preview.
 //
 //The Encapsulated PostScript Specfication† describes how to embed EPS
@@ -37,6 +39,9 @@
 //	output.ps:% Fallback Image: x=100, y=150, w=350, h=250 res=300dpi size=4560834
 //	output.ps:% Fallback Image: x=150, y=400, w=299, h=50 res=300dpi size=783750
 //
+//The FallbackReport method of Surface collects these comments as they are
+//written.
+//
 //Supported Features
 //
 //The following tables lists all features natively supported by the PostScript
@@ -58,15 +63,14 @@
 //
 //Requirements
 //
-//Libcairo must be compiled with
+//If libcairo is not compiled with
 //	CAIRO_HAS_PS_SURFACE
-//in addition to the requirements of cairo.
+//this package still builds, but New returns a *cairo.NotSupportedError.
 package ps
 
 //#cgo pkg-config: cairo
 //#include <stdlib.h>
-//#include <cairo/cairo.h>
-//#include <cairo/cairo-ps.h>
+//#include "weak.h"
 import "C"
 
 import (
@@ -74,6 +78,7 @@ import (
 	"unsafe"
 
 	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/fallback"
 )
 
 //Surface is a PostScript surface.
@@ -81,7 +86,8 @@ import (
 //Surface implements cairo.PagedVectorSurface
 type Surface struct {
 	cairo.XtensionPagedVectorSurface
-	eps bool
+	eps       bool
+	fallbacks *fallback.Writer
 }
 
 func news(s *C.cairo_surface_t, eps bool) (Surface, error) {
@@ -132,10 +138,10 @@ func cfgSurf(ps *C.cairo_surface_t, eps bool, header, setup Comments) {
 }
 
 func errChk(header, setup Comments) (err error) {
-	if err = header.Err(); err != nil {
+	if err = header.errIn(Header); err != nil {
 		return
 	}
-	return setup.Err()
+	return setup.errIn(Setup)
 }
 
 //New creates a new PostScript of the specified size.
@@ -146,22 +152,29 @@ func errChk(header, setup Comments) (err error) {
 //Eps specifies whether this will be Encapsulated PostScript.
 //Header is any DSC comments to apply to the header section.
 //Setup is any DSC comment to apply to the setup section.
+//It is an error for a comment in header or setup not to belong in that
+//section.
 //
 //Originally cairo_ps_surface_create_for_stream
 //and cairo_ps_surface_set_eps and cairo_ps_surface_dsc_comment
 //and cairo_ps_surface_dsc_begin_setup and
 //cairo_ps_surface_dsc_begin_page_setup.
 func New(w io.Writer, width, height float64, eps bool, header, setup Comments) (S Surface, err error) {
+	if err = cairo.XtensionRequire("ps.New", cairo.FeaturePS); err != nil {
+		return
+	}
 	if err = errChk(header, setup); err != nil {
 		return
 	}
 
-	wp := cairo.XtensionWrapWriter(w)
+	fw := fallback.NewWriter(w, fallback.PS)
+	wp := cairo.XtensionWrapWriter(fw)
 	ps := C.cairo_ps_surface_create_for_stream(cairo.XtensionCairoWriteFuncT, wp, C.double(width), C.double(height))
 
 	cfgSurf(ps, eps, header, setup)
 
 	S, err = news(ps, eps)
+	S.fallbacks = fw
 
 	S.XtensionRegisterWriter(wp)
 
@@ -199,11 +212,24 @@ func (s Surface) EPS() bool {
 	return s.eps
 }
 
+//FallbackReport returns the fallback images written by s.
+//
+//The report is only complete after s has been closed.
+//If s was not created by New, it is always empty.
+//
+//See the section on fallback images in the package documentation.
+func (s Surface) FallbackReport() fallback.Report {
+	return s.fallbacks.Report()
+}
+
 //AddComments adds comments to the PageSetup sections.
 //
+//It is an error to add a comment that does not belong in the PageSetup
+//section.
+//
 //Originally cairo_ps_surface_dsc_comment.
 func (s Surface) AddComments(comments Comments) (err error) {
-	if err = comments.Err(); err != nil {
+	if err = comments.errIn(PageSetup); err != nil {
 		return
 	}
 
@@ -223,5 +249,5 @@ func (s Surface) AddComment(key, value string) error {
 
 //AddCommentf is shorthand for adding a single formatted comment.
 func (s Surface) AddCommentf(key, value string, vars ...interface{}) error {
-	return s.AddComments(Comments{Comment(key, value)})
+	return s.AddComments(Comments{Commentf(key, value, vars...)})
 }
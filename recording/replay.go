@@ -0,0 +1,79 @@
+package recording
+
+import (
+	"math"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//Replay draws the operations recorded on s onto target.
+//
+//The matrix m transforms the coordinates of s into the coordinates
+//of target.
+//If m is the zero Matrix, the identity is used.
+//
+//If clip is not empty, only the part of target within clip,
+//in the coordinates of target, is drawn on.
+func (s Surface) Replay(target cairo.Surface, m cairo.Matrix, clip cairo.Rectangle) error {
+	c, err := cairo.New(target)
+	if err != nil {
+		c.Close()
+		return err
+	}
+	if clip = clip.Canon(); !clip.Empty() {
+		c.Rectangle(clip).Clip()
+	}
+	if m != (cairo.Matrix{}) {
+		c.Transform(m)
+	}
+	if err := c.SetSourceSurface(s, cairo.ZP); err != nil {
+		c.Close()
+		return err
+	}
+	c.Paint()
+	return c.Close()
+}
+
+//ReplayFit draws the operations recorded on s onto c, scaled and centered
+//to fit box, in the user space of c.
+//
+//The drawing is measured by InkExtents.
+//If preserveAspect is true, the drawing is scaled by the same amount
+//in both directions, so that it fits box without distortion.
+//Otherwise, it is stretched to fill box.
+//
+//Nothing is drawn outside box.
+//If nothing has been drawn on s, or box is empty, ReplayFit does nothing.
+//Otherwise, the current path of c is cleared,
+//and the rest of the state of c is unchanged.
+func (s Surface) ReplayFit(c *cairo.Context, box cairo.Rectangle, preserveAspect bool) error {
+	box = box.Canon()
+	ink := s.InkExtents()
+	if ink.Empty() || box.Empty() {
+		return nil
+	}
+	scale, offset := fit(ink, box, preserveAspect)
+	return c.SaveRestore(func(c *cairo.Context) error {
+		c.NewPath().Rectangle(box).Clip()
+		c.Translate(offset).Scale(scale)
+		if err := c.SetSourceSurface(s, cairo.ZP); err != nil {
+			return err
+		}
+		c.Paint()
+		return c.Err()
+	})
+}
+
+//fit returns the scale and then translation that fit r, centered, into box.
+func fit(r, box cairo.Rectangle, preserveAspect bool) (scale, offset cairo.Point) {
+	scale = cairo.Pt(box.Dx()/r.Dx(), box.Dy()/r.Dy())
+	if preserveAspect {
+		s := math.Min(scale.X, scale.Y)
+		scale = cairo.Pt(s, s)
+	}
+	offset = cairo.Pt(
+		box.Min.X+(box.Dx()-r.Dx()*scale.X)/2-r.Min.X*scale.X,
+		box.Min.Y+(box.Dy()-r.Dy()*scale.Y)/2-r.Min.Y*scale.Y,
+	)
+	return scale, offset
+}
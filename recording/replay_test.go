@@ -0,0 +1,119 @@
+package recording
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+func TestFit(t *testing.T) {
+	for _, test := range []struct {
+		r, box         cairo.Rectangle
+		preserveAspect bool
+		scale, offset  cairo.Point
+	}{
+		{cairo.Rect(0, 0, 10, 10), cairo.Rect(0, 0, 10, 10), true, cairo.Pt(1, 1), cairo.ZP},
+		{cairo.Rect(5, 5, 15, 15), cairo.Rect(0, 0, 20, 20), true, cairo.Pt(2, 2), cairo.Pt(-10, -10)},
+		//letterboxed
+		{cairo.Rect(0, 0, 20, 10), cairo.Rect(0, 0, 10, 10), true, cairo.Pt(.5, .5), cairo.Pt(0, 2.5)},
+		//stretched
+		{cairo.Rect(0, 0, 20, 10), cairo.Rect(0, 0, 10, 10), false, cairo.Pt(.5, 1), cairo.ZP},
+		{cairo.Rect(-10, 0, 10, 10), cairo.Rect(100, 100, 140, 140), true, cairo.Pt(2, 2), cairo.Pt(120, 110)},
+	} {
+		scale, offset := fit(test.r, test.box, test.preserveAspect)
+		if scale != test.scale || offset != test.offset {
+			t.Errorf("fit(%v, %v, %v): got %v, %v; want %v, %v",
+				test.r, test.box, test.preserveAspect, scale, offset, test.scale, test.offset)
+		}
+		//the corners of r must land in box
+		for _, p := range []cairo.Point{test.r.Min, test.r.Max} {
+			q := cairo.Pt(p.X*scale.X+offset.X, p.Y*scale.Y+offset.Y)
+			if q.X < test.box.Min.X || q.X > test.box.Max.X || q.Y < test.box.Min.Y || q.Y > test.box.Max.Y {
+				t.Errorf("fit(%v, %v, %v): %v maps outside box to %v", test.r, test.box, test.preserveAspect, p, q)
+			}
+		}
+	}
+}
+
+//record returns a recording of a red square at (10, 10) to (20, 20).
+func record(t *testing.T) Surface {
+	r := New(cairo.ContentColorAlpha, cairo.Rectangle{})
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	c, err := cairo.New(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetSourceColor(cairo.Red).Rectangle(cairo.Rect(10, 10, 20, 20)).Fill()
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+//checkRed checks that the pixels of s in red are opaque red
+//and the rest transparent.
+func checkRed(t *testing.T, s cairo.ImageSurface, red image.Rectangle) {
+	img, err := s.ToImage(cairo.ZP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			want := color.RGBA{}
+			if image.Pt(x, y).In(red) {
+				want = color.RGBA{0xff, 0, 0, 0xff}
+			}
+			if got := img.RGBAAt(x, y); got != want {
+				t.Fatalf("(%d, %d): got %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	r := record(t)
+	defer r.Close()
+	s, err := cairo.NewImageSurface(cairo.FormatARGB32, 40, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	//moved by (10, 0) and clipped to its left half
+	m := cairo.NewTranslateMatrix(cairo.Pt(10, 0))
+	if err := r.Replay(s, m, cairo.Rect(0, 0, 25, 40)); err != nil {
+		t.Fatal(err)
+	}
+	checkRed(t, s, image.Rect(20, 10, 25, 20))
+}
+
+func TestReplayFit(t *testing.T) {
+	r := record(t)
+	defer r.Close()
+	s, err := cairo.NewImageSurface(cairo.FormatARGB32, 40, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	c, err := cairo.New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	//a path left on c must not become part of the clip:
+	//under the even-odd rule it would cancel out the box
+	c.SetFillRule(cairo.FillRuleEvenOdd).Rectangle(cairo.Rect(0, 0, 40, 40))
+	if err := r.ReplayFit(c, cairo.Rect(20, 0, 40, 40), true); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Err(); err != nil {
+		t.Fatal(err)
+	}
+	checkRed(t, s, image.Rect(20, 10, 40, 30))
+}
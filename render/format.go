@@ -0,0 +1,99 @@
+package render
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+//Format is a file format that can be rendered to.
+type Format int
+
+//The file formats.
+const (
+	//PNG is an image rendered with an ImageSurface.
+	PNG Format = iota + 1
+	//PDF is rendered with cairo/pdf.
+	PDF
+	//SVG is rendered with cairo/svg.
+	SVG
+	//PS is PostScript, rendered with cairo/ps.
+	PS
+	//EPS is Encapsulated PostScript, rendered with cairo/ps.
+	EPS
+)
+
+var formats = []struct {
+	f   Format
+	ext string
+}{
+	{PNG, ".png"},
+	{PDF, ".pdf"},
+	{SVG, ".svg"},
+	{PS, ".ps"},
+	{EPS, ".eps"},
+}
+
+func (f Format) String() string {
+	for _, x := range formats {
+		if x.f == f {
+			return strings.ToUpper(x.ext[1:])
+		}
+	}
+	return "unknown format"
+}
+
+//ErrFormat is returned when a file's extension does not name a Format.
+var ErrFormat = errors.New("render: unknown file format")
+
+//FormatOf returns the Format named by the extension of path,
+//ignoring case.
+func FormatOf(path string) (Format, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, x := range formats {
+		if x.ext == ext {
+			return x.f, nil
+		}
+	}
+	return 0, ErrFormat
+}
+
+//paged reports whether f can hold more than one page in a file.
+func (f Format) paged() bool {
+	return f == PDF || f == PS
+}
+
+//Errors is a list of errors from rendering and closing the surfaces
+//and files rendered to.
+type Errors []error
+
+func (e Errors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+//Unwrap returns e, so that errors.Is and errors.As examine each error.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+//add appends err to e if it is not nil.
+func (e *Errors) add(err error) {
+	if err != nil {
+		*e = append(*e, err)
+	}
+}
+
+//err returns e, or nil if e is empty.
+func (e Errors) err() error {
+	switch len(e) {
+	case 0:
+		return nil
+	case 1:
+		return e[0]
+	}
+	return e
+}
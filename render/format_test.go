@@ -0,0 +1,72 @@
+package render
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestFormatOf(t *testing.T) {
+	for _, test := range []struct {
+		path string
+		want Format
+		err  error
+	}{
+		{"out.png", PNG, nil},
+		{"dir.pdf/Figure.PDF", PDF, nil},
+		{"a.b.svg", SVG, nil},
+		{"out.ps", PS, nil},
+		{"out.eps", EPS, nil},
+		{"out", 0, ErrFormat},
+		{"out.jpg", 0, ErrFormat},
+	} {
+		got, err := FormatOf(test.path)
+		if got != test.want || err != test.err {
+			t.Errorf("FormatOf(%q): got %v, %v; want %v, %v", test.path, got, err, test.want, test.err)
+		}
+	}
+}
+
+func TestPagePath(t *testing.T) {
+	if got := pagePath("dir/slides.png", 0); got != "dir/slides-1.png" {
+		t.Errorf("got %q", got)
+	}
+	if got := pagePath("slides", 9); got != "slides-10" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestPagesErrors(t *testing.T) {
+	if err := Pages("out.eps", 10, 10, 2, nil); err != ErrPages {
+		t.Errorf("two EPS pages: got %v, want %v", err, ErrPages)
+	}
+	if err := Pages("out.png", 10, 10, 2, nil, As(SVG)); err != ErrPages {
+		t.Errorf("two SVG pages: got %v, want %v", err, ErrPages)
+	}
+	if err := Pages("out.pdf", 10, 10, 0, nil); err == nil {
+		t.Error("no pages: no error")
+	}
+	if err := File("out.tiff", 10, 10, nil); err != ErrFormat {
+		t.Errorf("got %v, want %v", err, ErrFormat)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	var errs Errors
+	if errs.err() != nil {
+		t.Error("empty Errors is not nil")
+	}
+	errs.add(nil)
+	errs.add(os.ErrNotExist)
+	if err := errs.err(); err != os.ErrNotExist {
+		t.Errorf("one error: got %v", err)
+	}
+	errs.add(ErrPages)
+	err := errs.err()
+	if !errors.Is(err, os.ErrNotExist) || !errors.Is(err, ErrPages) {
+		t.Errorf("errors.Is does not find each error in %v", err)
+	}
+	if got, want := err.Error(), os.ErrNotExist.Error()+"; "+ErrPages.Error(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,226 @@
+//Package render draws to files, choosing the surface from the file's
+//extension.
+//
+//It imports every backend package, so it is not part of cairo itself.
+//A backend that libcairo does not support is reported by a
+//*cairo.NotSupportedError when it is rendered to.
+package render
+
+import (
+	"errors"
+	"fmt"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/pdf"
+	"github.com/jimmyfrasche/cairo/ps"
+	"github.com/jimmyfrasche/cairo/svg"
+)
+
+//ErrPages is returned when rendering more than one page to a Format that
+//holds a single page, such as EPS and SVG.
+var ErrPages = errors.New("render: format holds a single page")
+
+//Option configures rendering.
+type Option func(*options)
+
+type options struct {
+	format Format
+	scale  float64
+}
+
+//As renders to f, regardless of the extension of the file.
+func As(f Format) Option {
+	return func(o *options) {
+		o.format = f
+	}
+}
+
+//Scale sets the number of pixels per unit of user space in PNG files,
+//which is 1 by default.
+//It is ignored by other formats.
+//See cairo.XtensionSurface.SetDeviceScale.
+func Scale(s float64) Option {
+	return func(o *options) {
+		o.scale = s
+	}
+}
+
+//File renders one page with draw to the file at path.
+//
+//The width and height are in points for vector formats,
+//and in pixels, before Scale, for PNG.
+//
+//If there is an error, it is returned and the file is removed.
+func File(path string, width, height float64, draw func(*cairo.Context) error, opts ...Option) error {
+	return Pages(path, width, height, 1, func(_ int, c *cairo.Context) error {
+		return draw(c)
+	}, opts...)
+}
+
+//Pages renders n pages to the file at path.
+//Draw is called for each page, with page set to the index of the page
+//from 0, and a new Context, so that no state is carried between pages.
+//
+//PDF and PS files contain every page.
+//When rendering more than one page to PNG, each page is written to its own
+//file, with its number, from 1, inserted before the extension:
+//out.png becomes out-1.png, out-2.png, and so on.
+//Other formats return ErrPages when n is greater than 1.
+//
+//See File for the units of width and height.
+//If there is an error, it is returned and every file is removed,
+//including the PNG files of pages before the page that failed.
+func Pages(path string, width, height float64, n int, draw func(page int, c *cairo.Context) error, opts ...Option) error {
+	o := options{scale: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.format == 0 {
+		f, err := FormatOf(path)
+		if err != nil {
+			return err
+		}
+		o.format = f
+	}
+
+	if n < 1 {
+		return fmt.Errorf("render: cannot render %d pages", n)
+	}
+	if n > 1 && !o.format.paged() && o.format != PNG {
+		return ErrPages
+	}
+
+	if o.format == PNG {
+		var written []string
+		for i := 0; i < n; i++ {
+			p := path
+			if n > 1 {
+				p = pagePath(path, i)
+			}
+			if err := renderPNG(p, width, height, o.scale, i, draw); err != nil {
+				//remove the pages before the one that failed
+				for _, p := range written {
+					os.Remove(p)
+				}
+				return err
+			}
+			written = append(written, p)
+		}
+		return nil
+	}
+	return renderVector(path, o.format, width, height, n, draw)
+}
+
+//pagePath returns path with the number of page inserted before
+//its extension.
+func pagePath(path string, page int) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), page+1, ext)
+}
+
+func newVector(f Format, file *os.File, width, height float64) (cairo.Surface, error) {
+	switch f {
+	case PDF:
+		return pdf.New(file, width, height)
+	case SVG:
+		return svg.New(file, width, height)
+	case PS, EPS:
+		return ps.New(file, width, height, f == EPS, nil, nil)
+	}
+	return nil, fmt.Errorf("render: cannot render to %s", f)
+}
+
+func renderVector(path string, f Format, width, height float64, n int, draw func(int, *cairo.Context) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	var errs Errors
+	s, err := newVector(f, file, width, height)
+	if err != nil {
+		errs.add(err)
+		if s != nil {
+			s.Close()
+		}
+	} else {
+		for i := 0; i < n && len(errs) == 0; i++ {
+			errs.add(drawPage(s, i, draw))
+			if p, ok := s.(cairo.Paged); ok && len(errs) == 0 {
+				p.ShowPage()
+				errs.add(s.Err())
+			}
+		}
+		errs.add(s.Close())
+	}
+	errs.add(file.Close())
+
+	if err := errs.err(); err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+func renderPNG(path string, width, height, scale float64, page int, draw func(int, *cairo.Context) error) error {
+	w := int(math.Ceil(width * scale))
+	h := int(math.Ceil(height * scale))
+	s, err := cairo.NewImageSurface(cairo.FormatARGB32, w, h)
+	if err != nil {
+		s.Close()
+		return err
+	}
+	if err = s.SetDeviceScale(cairo.Pt(scale, scale)); err != nil {
+		s.Close()
+		return err
+	}
+
+	var errs Errors
+	errs.add(drawPage(s, page, draw))
+	if len(errs) == 0 {
+		errs.add(writePNG(path, s))
+	}
+	errs.add(s.Close())
+	return errs.err()
+}
+
+func writePNG(path string, s cairo.ImageSurface) error {
+	img, err := s.ToImage(cairo.ZP)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	var errs Errors
+	errs.add(png.Encode(file, img))
+	errs.add(file.Close())
+	if err := errs.err(); err != nil {
+		os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+//drawPage calls draw with a new Context for s.
+func drawPage(s cairo.Surface, page int, draw func(int, *cairo.Context) error) error {
+	c, err := cairo.New(s)
+	if err != nil {
+		c.Close()
+		return err
+	}
+	var errs Errors
+	errs.add(draw(page, c))
+	if len(errs) == 0 {
+		errs.add(c.Err())
+	}
+	c.Close()
+	return errs.err()
+}
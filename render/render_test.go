@@ -0,0 +1,137 @@
+package render
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+func fill(c *cairo.Context) error {
+	c.SetSourceColor(cairo.Blue).Paint()
+	return nil
+}
+
+func fillPage(_ int, c *cairo.Context) error {
+	return fill(c)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestFilePNGScale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.png")
+	if err := File(path, 10, 20, fill, Scale(2)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	cfg, err := png.DecodeConfig(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Width != 20 || cfg.Height != 40 {
+		t.Errorf("got %dx%d, want 20x40", cfg.Width, cfg.Height)
+	}
+}
+
+func TestPagesPNG(t *testing.T) {
+	dir := t.TempDir()
+	if err := Pages(filepath.Join(dir, "out.png"), 10, 10, 2, fillPage); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"out-1.png", "out-2.png"} {
+		if !exists(filepath.Join(dir, name)) {
+			t.Errorf("%s not written", name)
+		}
+	}
+	if exists(filepath.Join(dir, "out.png")) {
+		t.Error("out.png written when rendering two pages")
+	}
+}
+
+//pdfPages counts the page objects in a PDF file,
+//including those in compressed object streams.
+func pdfPages(t *testing.T, path string) int {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := regexp.MustCompile(`/Type\s*/Page\b`)
+	n := len(page.FindAll(b, -1))
+	stream := regexp.MustCompile(`(?s)stream\r?\n(.*?)endstream`)
+	for _, m := range stream.FindAllSubmatch(b, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(m[1]))
+		if err != nil {
+			continue
+		}
+		//streams may be truncated by the line ending before endstream
+		d, _ := ioutil.ReadAll(r)
+		n += len(page.FindAll(d, -1))
+	}
+	return n
+}
+
+func TestPagesPDF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.pdf")
+	err := Pages(path, 10, 10, 3, fillPage)
+	if _, ok := err.(*cairo.NotSupportedError); ok {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := pdfPages(t, path); n != 3 {
+		t.Errorf("got %d pages, want 3", n)
+	}
+}
+
+func TestFileRemovedOnError(t *testing.T) {
+	dir := t.TempDir()
+	errDraw := errors.New("draw failed")
+	fail := func(*cairo.Context) error {
+		return errDraw
+	}
+
+	for _, name := range []string{"out.png", "out.pdf"} {
+		path := filepath.Join(dir, name)
+		err := File(path, 10, 10, fail)
+		if _, ok := err.(*cairo.NotSupportedError); ok {
+			continue
+		}
+		if !errors.Is(err, errDraw) {
+			t.Errorf("%s: got %v, want %v", name, err, errDraw)
+		}
+		if exists(path) {
+			t.Errorf("%s: not removed", name)
+		}
+	}
+
+	//pages written before the failing page are removed too
+	err := Pages(filepath.Join(dir, "pages.png"), 10, 10, 3, func(page int, c *cairo.Context) error {
+		if page == 2 {
+			return errDraw
+		}
+		return fill(c)
+	})
+	if !errors.Is(err, errDraw) {
+		t.Errorf("pages: got %v, want %v", err, errDraw)
+	}
+	for _, name := range []string{"pages-1.png", "pages-2.png", "pages-3.png"} {
+		if exists(filepath.Join(dir, name)) {
+			t.Errorf("%s: not removed", name)
+		}
+	}
+}
@@ -1,15 +1,17 @@
 //Package script implements a device and surface for writing drawing operations
 //to a file for debugging purposes.
 //
-//Libcairo must be compiled with
+//Traces written in ASCII mode may be read by cairo/script/trace,
+//and replayed by cairo/script/interpreter.
+//
+//If libcairo is not compiled with
 //	CAIRO_HAS_SCRIPT_SURFACE
-//in addition to the requirements of cairo.
+//this package still builds, but New returns a *cairo.NotSupportedError.
 package script
 
 //#cgo pkg-config: cairo
 //#include <stdlib.h>
-//#include <cairo/cairo.h>
-//#include <cairo/cairo-script.h>
+//#include "weak.h"
 import "C"
 
 import (
@@ -36,10 +38,14 @@ func cNew(d *C.cairo_device_t, m mode) (Device, error) {
 
 //New creates a script device from writer in mode.
 //
-//Originally cairo_script_create_for_stream.
+//Originally cairo_script_create_for_stream and cairo_script_set_mode.
 func New(w io.Writer, mode mode) (Device, error) {
+	if err := cairo.XtensionRequire("script.New", cairo.FeatureScript); err != nil {
+		return Device{}, err
+	}
 	wp := cairo.XtensionWrapWriter(w)
 	d := C.cairo_script_create_for_stream(cairo.XtensionCairoWriteFuncT, wp)
+	C.cairo_script_set_mode(d, mode.c())
 	D, err := cNew(d, mode)
 	D.XtensionRegisterWriter(wp)
 	return D, err
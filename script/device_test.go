@@ -0,0 +1,36 @@
+package script
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+func TestNewMode(t *testing.T) {
+	for _, m := range []mode{ASCII, Binary} {
+		var buf bytes.Buffer
+		d, err := New(&buf, m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := d.NewSurface(cairo.ContentColorAlpha, 1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		//the Device of s is revived from libcairo's mode
+		D, err := s.Device()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := D.(Device).Mode(); got != m {
+			t.Errorf("got mode %v, want %v", got, m)
+		}
+		if err := s.Close(); err != nil {
+			t.Error(err)
+		}
+		if err := d.Close(); err != nil {
+			t.Error(err)
+		}
+	}
+}
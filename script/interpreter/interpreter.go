@@ -0,0 +1,161 @@
+//Package interpreter reads cairo scripts, such as those written
+//by cairo/script, back into recording surfaces.
+//
+//Libcairo must be compiled with
+//	CAIRO_HAS_SCRIPT_SURFACE
+//and libcairo-script-interpreter must be installed.
+package interpreter
+
+//#cgo pkg-config: cairo cairo-script-interpreter
+//#include <stdlib.h>
+//#include <cairo/cairo.h>
+//#include <cairo/cairo-script-interpreter.h>
+//
+//typedef struct {
+//	cairo_surface_t *first;
+//	int has_extents;
+//	int bounded;
+//	cairo_rectangle_t extents;
+//} loader_t;
+//
+//static cairo_surface_t *surface_create(void *closure, cairo_content_t content, double width, double height, long uid) {
+//	loader_t *l = closure;
+//	cairo_rectangle_t r = {0, 0, width, height};
+//	cairo_rectangle_t *e = width < 0 || height < 0 ? NULL : &r;
+//	cairo_surface_t *s;
+//
+//	if (l->first != NULL) {
+//		return cairo_recording_surface_create(content, e);
+//	}
+//	//the first surface is the one recorded
+//	if (l->has_extents) {
+//		e = l->bounded ? &l->extents : NULL;
+//	}
+//	s = cairo_recording_surface_create(content, e);
+//	l->first = cairo_surface_reference(s);
+//	return s;
+//}
+//
+//static cairo_status_t load(loader_t *l, const char *data, int len) {
+//	cairo_script_interpreter_hooks_t hooks = {0};
+//	cairo_script_interpreter_t *csi;
+//	cairo_status_t st, st2;
+//
+//	hooks.closure = l;
+//	hooks.surface_create = surface_create;
+//
+//	csi = cairo_script_interpreter_create();
+//	cairo_script_interpreter_install_hooks(csi, &hooks);
+//	st = cairo_script_interpreter_feed_string(csi, data, len);
+//	st2 = cairo_script_interpreter_destroy(csi);
+//	if (st == CAIRO_STATUS_SUCCESS) {
+//		st = st2;
+//	}
+//	return st;
+//}
+import "C"
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/recording"
+	"github.com/jimmyfrasche/cairo/script"
+)
+
+//ErrNoSurface is returned by ReadRecording when the script does not
+//create a surface.
+var ErrNoSurface = errors.New("interpreter: script creates no surface")
+
+//ReadRecording runs the script read from r and returns a recording surface
+//holding the operations drawn on the first surface the script creates.
+//
+//If the script was written by script.WriteRecording, the recording surface
+//has the same extents as the one written.
+//Otherwise, it has the size given in the script, at the origin.
+//
+//Originally cairo_script_interpreter_feed_string.
+func ReadRecording(r io.Reader) (recording.Surface, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return recording.Surface{}, err
+	}
+
+	var l C.loader_t
+	if e, ok, err := findExtents(data); err != nil {
+		return recording.Surface{}, err
+	} else if ok {
+		l.has_extents = 1
+		if !e.Empty() {
+			l.bounded = 1
+			l.extents = C.cairo_rectangle_t{
+				x:      C.double(e.Min.X),
+				y:      C.double(e.Min.Y),
+				width:  C.double(e.Dx()),
+				height: C.double(e.Dy()),
+			}
+		}
+	}
+
+	cdata := C.CBytes(data)
+	st := C.load(&l, (*C.char)(cdata), C.int(len(data)))
+	C.free(cdata)
+
+	if l.first == nil {
+		if st != C.CAIRO_STATUS_SUCCESS {
+			return recording.Surface{}, readErr(st)
+		}
+		return recording.Surface{}, ErrNoSurface
+	}
+	s, err := cairo.XtensionRevivifySurface(l.first)
+	if err != nil {
+		return recording.Surface{}, err
+	}
+	rs := s.(recording.Surface)
+	if st != C.CAIRO_STATUS_SUCCESS {
+		rs.Close()
+		return recording.Surface{}, readErr(st)
+	}
+	return rs, nil
+}
+
+func readErr(st C.cairo_status_t) error {
+	return &cairo.Error{
+		Op:     "interpreter.ReadRecording",
+		Status: cairo.Status(st),
+	}
+}
+
+//findExtents looks for the comment written by script.WriteRecording
+//and returns the extents it holds.
+//An empty Rectangle means the recording is unbounded.
+func findExtents(data []byte) (e cairo.Rectangle, ok bool, err error) {
+	prefix := "% " + script.ExtentsComment
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := sc.Text()
+		if !strings.HasPrefix(line, "%") {
+			//the comment is written before any operation
+			break
+		}
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		line = strings.TrimPrefix(line, prefix)
+		if line == "unbounded" {
+			return cairo.Rectangle{}, true, nil
+		}
+		var x, y, w, h float64
+		if _, err := fmt.Sscan(line, &x, &y, &w, &h); err != nil {
+			return e, false, fmt.Errorf("interpreter: malformed extents %q: %v", line, err)
+		}
+		return cairo.RectWH(x, y, w, h), true, nil
+	}
+	return e, false, nil
+}
@@ -0,0 +1,107 @@
+package interpreter
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/recording"
+	"github.com/jimmyfrasche/cairo/script"
+)
+
+func TestFindExtents(t *testing.T) {
+	for _, test := range []struct {
+		script string
+		e      cairo.Rectangle
+		ok     bool
+	}{
+		{"%!CairoScript\n% recording extents 10 20 300 400.5\n<< /content //COLOR_ALPHA", cairo.RectWH(10, 20, 300, 400.5), true},
+		{"%!CairoScript\n% recording extents unbounded\n", cairo.Rectangle{}, true},
+		{"%!CairoScript\n<< /content //COLOR_ALPHA /width 10 /height 10 >> surface\n% recording extents 1 1 1 1\n", cairo.Rectangle{}, false},
+		{"", cairo.Rectangle{}, false},
+	} {
+		e, ok, err := findExtents([]byte(test.script))
+		if err != nil {
+			t.Errorf("%q: %v", test.script, err)
+		}
+		if e != test.e || ok != test.ok {
+			t.Errorf("%q: got %v, %v; want %v, %v", test.script, e, ok, test.e, test.ok)
+		}
+	}
+
+	if _, _, err := findExtents([]byte("% recording extents 1 2 three\n")); err == nil {
+		t.Error("malformed extents: no error")
+	}
+}
+
+func TestReadRecording(t *testing.T) {
+	for _, extents := range []cairo.Rectangle{
+		cairo.RectWH(-5, 10, 30, 20),
+		{}, //unbounded
+	} {
+		rs := recording.New(cairo.ContentColorAlpha, extents)
+		if err := rs.Err(); err != nil {
+			t.Fatal(err)
+		}
+		c, err := cairo.New(rs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.SetSourceColor(cairo.Red).Rectangle(cairo.RectWH(0, 10, 10, 10)).Fill()
+		if err := c.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		if err := script.WriteRecording(&buf, rs, script.ASCII); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ReadRecording(&buf)
+		if err != nil {
+			t.Fatalf("%v: %v", extents, err)
+		}
+		if e := got.Extents(); e != extents {
+			t.Errorf("%v: got extents %v", extents, e)
+		}
+		if u := got.Unbounded(); u != extents.Empty() {
+			t.Errorf("%v: got unbounded %v", extents, u)
+		}
+		checkReplay(t, got)
+		got.Close()
+		rs.Close()
+	}
+}
+
+//checkReplay checks that rs replays the red square
+//at (0, 10) to (10, 20), and nothing else.
+func checkReplay(t *testing.T, rs recording.Surface) {
+	s, err := cairo.NewImageSurface(cairo.FormatARGB32, 20, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if err := rs.Replay(s, cairo.Matrix{}, cairo.Rectangle{}); err != nil {
+		t.Fatal(err)
+	}
+	img, err := s.ToImage(cairo.ZP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	for _, p := range []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{5, 15, red},
+		{0, 10, red},
+		{9, 19, red},
+		{5, 5, color.RGBA{}},
+		{15, 15, color.RGBA{}},
+		{5, 25, color.RGBA{}},
+	} {
+		if got := img.RGBAAt(p.x, p.y); got != p.want {
+			t.Errorf("(%d, %d): got %v, want %v", p.x, p.y, got, p.want)
+		}
+	}
+}
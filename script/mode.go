@@ -1,8 +1,7 @@
 package script
 
 //#cgo pkg-config: cairo
-//#include <cairo/cairo.h>
-//#include <cairo/cairo-script.h>
+//#include "weak.h"
 import "C"
 
 //cairo_script_mode_t
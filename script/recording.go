@@ -0,0 +1,40 @@
+package script
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/recording"
+)
+
+//ExtentsComment is the start of the comment WriteRecording writes
+//to record the extents of the recording surface, which the script
+//does not otherwise preserve.
+//It is followed by "unbounded" or the x, y, width, and height of
+//the extents.
+const ExtentsComment = "recording extents "
+
+//WriteRecording writes the operations recorded on rs to w as a script
+//in mode, so that they may be read back into a recording surface
+//by cairo/script/interpreter.
+func WriteRecording(w io.Writer, rs recording.Surface, mode mode) error {
+	d, err := New(w, mode)
+	if err != nil {
+		d.Close()
+		return err
+	}
+	d.Comment(ExtentsComment + extents(rs.Extents()))
+	err = d.FromRecordingSurface(rs)
+	if cerr := d.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func extents(r cairo.Rectangle) string {
+	if r.Empty() {
+		return "unbounded"
+	}
+	return fmt.Sprintf("%g %g %g %g", r.Min.X, r.Min.Y, r.Dx(), r.Dy())
+}
@@ -0,0 +1,340 @@
+package trace
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+)
+
+type tokenType int
+
+const (
+	tValue tokenType = iota //a float64, String, Name, or Const
+	tExec
+	tOpen  //[, {, or <<
+	tClose //], }, or >>
+)
+
+type token struct {
+	typ  tokenType
+	val  Value
+	text string
+	line int
+}
+
+//SyntaxError is returned when a trace cannot be parsed.
+type SyntaxError struct {
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("trace: line %d: %s", e.Line, e.Msg)
+}
+
+type lexer struct {
+	r    *bufio.Reader
+	line int
+}
+
+func newLexer(r io.Reader) *lexer {
+	return &lexer{
+		r:    bufio.NewReader(r),
+		line: 1,
+	}
+}
+
+func (l *lexer) errorf(format string, v ...interface{}) error {
+	return &SyntaxError{Line: l.line, Msg: fmt.Sprintf(format, v...)}
+}
+
+func (l *lexer) read() (byte, error) {
+	b, err := l.r.ReadByte()
+	if b == '\n' && err == nil {
+		l.line++
+	}
+	return b, err
+}
+
+func (l *lexer) unread(b byte) {
+	l.r.UnreadByte()
+	if b == '\n' {
+		l.line--
+	}
+}
+
+//peek returns the next byte without consuming it, or 0 at EOF.
+func (l *lexer) peek() byte {
+	bs, err := l.r.Peek(1)
+	if err != nil {
+		return 0
+	}
+	return bs[0]
+}
+
+func isSpace(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\r', '\f', 0:
+		return true
+	}
+	return false
+}
+
+func isDelim(b byte) bool {
+	switch b {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+//next returns the next token, or io.EOF.
+func (l *lexer) next() (token, error) {
+	for {
+		b, err := l.read()
+		if err != nil {
+			return token{}, err
+		}
+		switch {
+		case isSpace(b):
+			continue
+		case b == '%':
+			if _, err := l.r.ReadString('\n'); err == nil {
+				l.line++
+			}
+			continue
+		}
+
+		line := l.line
+		t, err := l.token(b)
+		if err == io.EOF {
+			err = l.errorf("unexpected end of trace")
+		}
+		t.line = line
+		return t, err
+	}
+}
+
+func (l *lexer) token(b byte) (token, error) {
+	switch b {
+	case '[', '{':
+		return token{typ: tOpen, text: string(b)}, nil
+	case ']', '}':
+		return token{typ: tClose, text: string(b)}, nil
+	case '>':
+		if c, _ := l.read(); c != '>' {
+			return token{}, l.errorf("unexpected >")
+		}
+		return token{typ: tClose, text: ">>"}, nil
+	case '<':
+		switch l.peek() {
+		case '<':
+			l.read()
+			return token{typ: tOpen, text: "<<"}, nil
+		case '~':
+			l.read()
+			return l.base85(false)
+		case '|':
+			l.read()
+			return l.base85(true)
+		}
+		return l.hex()
+	case '(':
+		return l.str()
+	case ')':
+		return token{}, l.errorf("unexpected )")
+	case '/':
+		if l.peek() == '/' {
+			l.read()
+			name, err := l.word(0)
+			return token{typ: tValue, val: Const(name)}, err
+		}
+		name, err := l.word(0)
+		return token{typ: tValue, val: Name(name)}, err
+	}
+
+	w, err := l.word(b)
+	if err != nil {
+		return token{}, err
+	}
+	if f, err := strconv.ParseFloat(w, 64); err == nil {
+		return token{typ: tValue, val: f}, nil
+	}
+	return token{typ: tExec, text: w}, nil
+}
+
+//word reads until whitespace or a delimiter, beginning with first
+//if it is not 0.
+func (l *lexer) word(first byte) (string, error) {
+	var buf bytes.Buffer
+	if first != 0 {
+		buf.WriteByte(first)
+	}
+	for {
+		b, err := l.read()
+		if err == io.EOF {
+			return buf.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if isSpace(b) || isDelim(b) {
+			l.unread(b)
+			return buf.String(), nil
+		}
+		buf.WriteByte(b)
+	}
+}
+
+//str reads a string after its opening parenthesis.
+func (l *lexer) str() (token, error) {
+	var buf bytes.Buffer
+	depth := 1
+	for {
+		b, err := l.read()
+		if err != nil {
+			return token{}, err
+		}
+		switch b {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return token{typ: tValue, val: String(buf.Bytes())}, nil
+			}
+		case '\\':
+			var skip bool
+			if b, skip, err = l.escape(); err != nil {
+				return token{}, err
+			}
+			if skip {
+				continue
+			}
+		}
+		buf.WriteByte(b)
+	}
+}
+
+//escape reads the rest of an escape sequence in a string.
+//If skip is true, the sequence is an escaped newline, which is ignored.
+func (l *lexer) escape() (b byte, skip bool, err error) {
+	if b, err = l.read(); err != nil {
+		return 0, false, err
+	}
+	switch b {
+	case '\n':
+		return 0, true, nil
+	case 'n':
+		return '\n', false, nil
+	case 'r':
+		return '\r', false, nil
+	case 't':
+		return '\t', false, nil
+	case 'b':
+		return '\b', false, nil
+	case 'f':
+		return '\f', false, nil
+	}
+	if b < '0' || b > '7' {
+		return b, false, nil
+	}
+	n := int(b - '0')
+	for i := 0; i < 2; i++ {
+		c := l.peek()
+		if c < '0' || c > '7' {
+			break
+		}
+		l.read()
+		n = n*8 + int(c-'0')
+	}
+	return byte(n), false, nil
+}
+
+//hex reads a hexadecimal string after its opening <.
+func (l *lexer) hex() (token, error) {
+	var buf bytes.Buffer
+	for {
+		b, err := l.read()
+		if err != nil {
+			return token{}, err
+		}
+		if b == '>' {
+			break
+		}
+		if !isSpace(b) {
+			buf.WriteByte(b)
+		}
+	}
+	if buf.Len()%2 == 1 {
+		buf.WriteByte('0')
+	}
+	data, err := hex.DecodeString(buf.String())
+	if err != nil {
+		return token{}, l.errorf("invalid hex string: %v", err)
+	}
+	return token{typ: tValue, val: String(data)}, nil
+}
+
+//base85 reads an ASCII85 string after its opening <~,
+//or a compressed ASCII85 string after its opening <| if deflate is set.
+//
+//Libcairo writes large strings, such as the data of images and fonts,
+//compressed: the ASCII85 data is the length of the string,
+//as a 4 byte big-endian integer, followed by the zlib compressed string.
+func (l *lexer) base85(deflate bool) (token, error) {
+	var buf bytes.Buffer
+	for {
+		b, err := l.read()
+		if err != nil {
+			return token{}, err
+		}
+		if b == '~' {
+			if c, _ := l.read(); c != '>' {
+				return token{}, l.errorf("unterminated base85 string")
+			}
+			break
+		}
+		buf.WriteByte(b)
+	}
+	data := make([]byte, 4*buf.Len()+4)
+	n, _, err := ascii85.Decode(data, buf.Bytes(), true)
+	if err != nil {
+		return token{}, l.errorf("invalid base85 string: %v", err)
+	}
+	data = data[:n]
+	if deflate {
+		if data, err = inflate(data); err != nil {
+			return token{}, l.errorf("invalid compressed string: %v", err)
+		}
+	}
+	return token{typ: tValue, val: String(data)}, nil
+}
+
+//inflate decompresses the data of a compressed string.
+func inflate(data []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, errors.New("missing length")
+	}
+	n := binary.BigEndian.Uint32(data)
+	r, err := zlib.NewReader(bytes.NewReader(data[4:]))
+	if err != nil {
+		return nil, err
+	}
+	out, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if uint32(len(out)) != n {
+		return nil, fmt.Errorf("got %d bytes, want %d", len(out), n)
+	}
+	return out, nil
+}
@@ -0,0 +1,55 @@
+package trace
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+)
+
+//String returns o in the syntax of a script, with its arguments before its
+//name.
+//
+//Strings longer than 64 bytes, such as image data, are elided,
+//so the result is not always a valid script.
+func (o Op) String() string {
+	var buf bytes.Buffer
+	for _, a := range o.Args {
+		format(&buf, a)
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(o.Name)
+	return buf.String()
+}
+
+//Indent reads the trace from r and writes its operations to w,
+//one per line, indented two spaces for each level of the save stack.
+//
+//See Op.String for the format of each line.
+//The result of an operation such as pop-group is not repeated
+//in the arguments of the next operation.
+func Indent(w io.Writer, r io.Reader) error {
+	bw := bufio.NewWriter(w)
+	p := NewParser(r)
+	prev := ""
+	for {
+		o, err := p.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			bw.Flush()
+			return err
+		}
+		if len(o.Args) > 0 && results[prev] {
+			if c, ok := o.Args[0].(Call); ok && c.Name == prev && c.Args == nil {
+				o.Args = o.Args[1:]
+			}
+		}
+		prev = o.Name
+		bw.WriteString(strings.Repeat("  ", o.Depth))
+		bw.WriteString(o.String())
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
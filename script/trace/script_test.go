@@ -0,0 +1,150 @@
+package trace_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/script"
+	"github.com/jimmyfrasche/cairo/script/trace"
+)
+
+//record returns the trace of draw on a 100×100 script surface.
+func record(t *testing.T, draw func(c *cairo.Context)) []byte {
+	var buf bytes.Buffer
+	d, err := script.New(&buf, script.ASCII)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := d.NewSurface(cairo.ContentColorAlpha, 100, 100)
+	if err != nil {
+		d.Close()
+		t.Fatal(err)
+	}
+	c, err := cairo.New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	draw(c)
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	//closing the device finishes it, writing the rest of the trace
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+//longString reports whether v is or contains a String of at least n bytes.
+func longString(v trace.Value, n int) bool {
+	var vs []trace.Value
+	switch v := v.(type) {
+	case trace.String:
+		return len(v) >= n
+	case trace.Array:
+		vs = v
+	case trace.Proc:
+		vs = v
+	case trace.Dict:
+		vs = v
+	case trace.Call:
+		vs = v.Args
+	}
+	for _, v := range vs {
+		if longString(v, n) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestParseScript(t *testing.T) {
+	if !cairo.Features().Has(cairo.FeatureScript) {
+		t.Skip("libcairo does not support script surfaces")
+	}
+
+	//an image that does not compress to nothing
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			img.Set(x, y, color.RGBA{uint8(16 * x), uint8(16 * y), uint8(x * y), 0xff})
+		}
+	}
+	src, err := cairo.FromImage(img, cairo.ZP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	out := record(t, func(c *cairo.Context) {
+		c.SetSourceColor(cairo.Red).
+			MoveTo(cairo.Pt(10, 10)).
+			LineTo(cairo.Pt(20, 20)).
+			Stroke()
+		c.PushGroup().
+			SetSourceColor(cairo.Blue).
+			Rectangle(cairo.RectWH(0, 0, 10, 10)).
+			Fill()
+		c.PopGroupToSource()
+		c.Paint()
+		if err := c.SetSourceSurface(src, cairo.Pt(50, 50)); err != nil {
+			t.Error(err)
+		}
+		c.Paint()
+		c.SelectFont("sans-serif", cairo.SlantNormal, cairo.WeightNormal).
+			SetFontSize(12).
+			SetSourceColor(cairo.Black).
+			MoveTo(cairo.Pt(10, 90)).
+			ShowText("Hello")
+	})
+
+	ops, err := trace.Parse(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("%v in\n%s", err, out)
+	}
+
+	//the drawing operations are in the order drawn
+	want := []trace.Kind{trace.Stroke, trace.Fill, trace.Paint, trace.Paint, trace.ShowGlyphs}
+	var got []trace.Kind
+	//the data of the image, which is compressed, is decoded,
+	//though it may be written as RGB24 as it is opaque
+	size := 3 * 16 * 16
+	var data bool
+	for _, o := range ops {
+		for _, a := range o.Args {
+			data = data || longString(a, size)
+		}
+		switch o.Kind {
+		case trace.Stroke, trace.Fill, trace.Paint, trace.ShowGlyphs:
+			got = append(got, o.Kind)
+		case trace.SetSource:
+			//the source is always an argument, even a popped group
+			if len(o.Args) == 0 {
+				t.Errorf("line %d: set-source without a source", o.Line)
+			}
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got drawing operations %v, want %v, in\n%s", got, want, out)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got drawing operations %v, want %v, in\n%s", got, want, out)
+			break
+		}
+	}
+
+	if !data {
+		t.Errorf("no string holds the %d bytes of the image, in\n%s", size, out)
+	}
+
+	if n := len(ops); n > 0 && ops[n-1].Depth != 0 {
+		t.Errorf("trace ends at depth %d", ops[n-1].Depth)
+	}
+}
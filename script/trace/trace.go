@@ -0,0 +1,295 @@
+//Package trace parses the ASCII traces written by cairo/script
+//into a stream of operations.
+//
+//A trace is a program in CairoScript, a PostScript-like language
+//with an operator for each libcairo call.
+//The Parser does not execute the trace.
+//Instead, it returns each operator with the operands written before it:
+//	1 0 0 rgb set-source
+//	n 10 10 m 20 20 l
+//	stroke+
+//is parsed as
+//	Op{Name: "set-source", Kind: SetSource, Args: []Value{Call{Name: "rgb", Args: []Value{1.0, 0.0, 0.0}}}}
+//	Op{Name: "n", Kind: Path}
+//	Op{Name: "m", Kind: Path, Args: []Value{10.0, 10.0}}
+//	Op{Name: "l", Kind: Path, Args: []Value{20.0, 20.0}}
+//	Op{Name: "stroke+", Kind: Stroke}
+//
+//Operators that create a value, such as rgb, are not returned as operations,
+//but as a Call in the arguments of the operation that uses the value.
+//The pop-group operator both restores the state of the context and creates
+//a pattern, so it is returned as an operation and is also a Call in the
+//arguments of the next operation:
+//	pop-group set-source
+//is parsed as
+//	Op{Name: "pop-group", Kind: Restore}
+//	Op{Name: "set-source", Kind: SetSource, Args: []Value{Call{Name: "pop-group"}}}
+//
+//Each operation records the depth of the save stack it was executed at,
+//so that what a widget drew can be read in context.
+//Indent writes a trace with one operation per line, indented by depth:
+//	1 0 0 rgb set-source
+//	save
+//	  n
+//	  10 10 m
+//	  20 20 l
+//	  stroke+
+//	restore
+//
+//Binary traces, written with script.Binary, cannot be parsed.
+package trace
+
+import (
+	"io"
+)
+
+//Kind is a class of operation.
+type Kind int
+
+//The kinds of operations.
+const (
+	//Other is any operation not otherwise classified.
+	Other Kind = iota
+	//SetSource sets the source, as by set-source.
+	SetSource
+	//Path adds to or clears the current path, as by n, m, or l.
+	Path
+	//Fill fills the current path, as by fill or fill+.
+	Fill
+	//Stroke strokes the current path, as by stroke or stroke+.
+	Stroke
+	//Paint paints the source, as by paint or paint-with-alpha.
+	Paint
+	//Mask paints the source through a mask, as by mask.
+	Mask
+	//Clip changes the clip, as by clip, clip+, or reset-clip.
+	Clip
+	//ShowGlyphs shows text or glyphs, as by show-glyphs.
+	ShowGlyphs
+	//Save pushes the state of the context, as by save or push-group.
+	Save
+	//Restore pops the state of the context, as by restore or pop-group.
+	Restore
+)
+
+var kindNames = []string{
+	Other:      "Other",
+	SetSource:  "SetSource",
+	Path:       "Path",
+	Fill:       "Fill",
+	Stroke:     "Stroke",
+	Paint:      "Paint",
+	Mask:       "Mask",
+	Clip:       "Clip",
+	ShowGlyphs: "ShowGlyphs",
+	Save:       "Save",
+	Restore:    "Restore",
+}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "unknown kind"
+	}
+	return kindNames[k]
+}
+
+var kinds = map[string]Kind{
+	"set-source":       SetSource,
+	"set-source-rgb":   SetSource,
+	"set-source-rgba":  SetSource,
+	"n":                Path,
+	"m":                Path,
+	"l":                Path,
+	"c":                Path,
+	"h":                Path,
+	"M":                Path,
+	"L":                Path,
+	"C":                Path,
+	"rectangle":        Path,
+	"arc":              Path,
+	"arc-":             Path,
+	"glyph-path":       Path,
+	"text-path":        Path,
+	"fill":             Fill,
+	"fill+":            Fill,
+	"stroke":           Stroke,
+	"stroke+":          Stroke,
+	"paint":            Paint,
+	"paint-with-alpha": Paint,
+	"mask":             Mask,
+	"clip":             Clip,
+	"clip+":            Clip,
+	"reset-clip":       Clip,
+	"show-glyphs":      ShowGlyphs,
+	"show-text-glyphs": ShowGlyphs,
+	"show-text":        ShowGlyphs,
+	"save":             Save,
+	"push-group":       Save,
+	"restore":          Restore,
+	"pop-group":        Restore,
+}
+
+//constructors are the operators that create a value
+//for a later operation.
+var constructors = map[string]bool{
+	"rgb":            true,
+	"rgba":           true,
+	"linear":         true,
+	"radial":         true,
+	"mesh":           true,
+	"pattern":        true,
+	"image":          true,
+	"matrix":         true,
+	"surface":        true,
+	"add-color-stop": true,
+}
+
+//results are the operations that also create a value
+//for a later operation.
+var results = map[string]bool{
+	"pop-group": true,
+}
+
+//Op is an operation in a trace.
+type Op struct {
+	//Name is the name of the operator, such as "fill+".
+	Name string
+	//Kind is the class of the operator.
+	Kind Kind
+	//Args are the operands written since the previous operation.
+	Args []Value
+	//Depth is the depth of the save stack when the operation is
+	//executed.
+	//Saves are at the depth they save from,
+	//and restores at the depth they restore to,
+	//so that each is at the same depth as its match.
+	Depth int
+	//Line is the line of the trace the operator is on, from 1.
+	Line int
+}
+
+//Parser reads operations from a trace.
+type Parser struct {
+	l     *lexer
+	args  []Value
+	depth int
+}
+
+//NewParser returns a Parser that reads a trace from r.
+func NewParser(r io.Reader) *Parser {
+	return &Parser{l: newLexer(r)}
+}
+
+//Next returns the next operation in the trace.
+//
+//At the end of the trace, it returns io.EOF.
+//If the trace is malformed, it returns a *SyntaxError.
+func (p *Parser) Next() (Op, error) {
+	for {
+		t, err := p.l.next()
+		if err != nil {
+			return Op{}, err
+		}
+		switch t.typ {
+		case tValue:
+			p.args = append(p.args, t.val)
+		case tOpen:
+			v, err := p.container(t)
+			if err != nil {
+				return Op{}, err
+			}
+			p.args = append(p.args, v)
+		case tClose:
+			return Op{}, &SyntaxError{Line: t.line, Msg: "unexpected " + t.text}
+		case tExec:
+			if constructors[t.text] {
+				p.args = []Value{Call{Name: t.text, Args: p.args}}
+				continue
+			}
+			o := p.op(t)
+			if results[t.text] {
+				p.args = []Value{Call{Name: t.text}}
+			}
+			return o, nil
+		}
+	}
+}
+
+func (p *Parser) op(t token) Op {
+	o := Op{
+		Name: t.text,
+		Kind: kinds[t.text],
+		Args: p.args,
+		Line: t.line,
+	}
+	p.args = nil
+
+	switch o.Kind {
+	case Save:
+		o.Depth = p.depth
+		p.depth++
+	case Restore:
+		if p.depth > 0 {
+			p.depth--
+		}
+		o.Depth = p.depth
+	default:
+		o.Depth = p.depth
+	}
+	return o
+}
+
+//container reads the values of an Array, Proc, or Dict
+//after the token that opens it.
+func (p *Parser) container(open token) (Value, error) {
+	closer := map[string]string{"[": "]", "{": "}", "<<": ">>"}[open.text]
+	var vs []Value
+	for {
+		t, err := p.l.next()
+		if err == io.EOF {
+			err = &SyntaxError{Line: open.line, Msg: "unclosed " + open.text}
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t.typ {
+		case tValue:
+			vs = append(vs, t.val)
+		case tExec:
+			vs = append(vs, Exec(t.text))
+		case tOpen:
+			v, err := p.container(t)
+			if err != nil {
+				return nil, err
+			}
+			vs = append(vs, v)
+		case tClose:
+			if t.text != closer {
+				return nil, &SyntaxError{Line: t.line, Msg: "unexpected " + t.text + " in " + open.text}
+			}
+			switch open.text {
+			case "[":
+				return Array(vs), nil
+			case "{":
+				return Proc(vs), nil
+			}
+			return Dict(vs), nil
+		}
+	}
+}
+
+//Parse returns all the operations in the trace read from r.
+func Parse(r io.Reader) ([]Op, error) {
+	var ops []Op
+	p := NewParser(r)
+	for {
+		o, err := p.Next()
+		if err == io.EOF {
+			return ops, nil
+		}
+		if err != nil {
+			return ops, err
+		}
+		ops = append(ops, o)
+	}
+}
@@ -0,0 +1,152 @@
+package trace
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/binary"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const script = `%!CairoScript
+<< /content //COLOR_ALPHA /width 100 /height 50 >> surface context
+dup /c exch def
+1 0 0 rgb set-source
+save
+  n 10 10 m 20 20 l
+  [ 2 1 ] 0 set-dash
+  stroke+
+  //COLOR_ALPHA push-group
+    0 0 10 10 rectangle fill
+  pop-group set-source
+restore
+(a \(b\)\n\101) <4142> <~9jqo^~> pop pop pop
+`
+
+func TestParse(t *testing.T) {
+	ops, err := Parse(strings.NewReader(script))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Op{
+		{Name: "context", Args: []Value{Call{Name: "surface", Args: []Value{
+			Dict{Name("content"), Const("COLOR_ALPHA"), Name("width"), 100.0, Name("height"), 50.0},
+		}}}, Line: 2},
+		{Name: "dup", Line: 3},
+		{Name: "exch", Args: []Value{Name("c")}, Line: 3},
+		{Name: "def", Line: 3},
+		{Name: "set-source", Kind: SetSource, Args: []Value{Call{Name: "rgb", Args: []Value{1.0, 0.0, 0.0}}}, Line: 4},
+		{Name: "save", Kind: Save, Line: 5},
+		{Name: "n", Kind: Path, Depth: 1, Line: 6},
+		{Name: "m", Kind: Path, Args: []Value{10.0, 10.0}, Depth: 1, Line: 6},
+		{Name: "l", Kind: Path, Args: []Value{20.0, 20.0}, Depth: 1, Line: 6},
+		{Name: "set-dash", Args: []Value{Array{2.0, 1.0}, 0.0}, Depth: 1, Line: 7},
+		{Name: "stroke+", Kind: Stroke, Depth: 1, Line: 8},
+		{Name: "push-group", Kind: Save, Args: []Value{Const("COLOR_ALPHA")}, Depth: 1, Line: 9},
+		{Name: "rectangle", Kind: Path, Args: []Value{0.0, 0.0, 10.0, 10.0}, Depth: 2, Line: 10},
+		{Name: "fill", Kind: Fill, Depth: 2, Line: 10},
+		{Name: "pop-group", Kind: Restore, Depth: 1, Line: 11},
+		{Name: "set-source", Kind: SetSource, Args: []Value{Call{Name: "pop-group"}}, Depth: 1, Line: 11},
+		{Name: "restore", Kind: Restore, Line: 12},
+		{Name: "pop", Args: []Value{String("a (b)\nA"), String("AB"), String("Man ")}, Line: 13},
+		{Name: "pop", Line: 13},
+		{Name: "pop", Line: 13},
+	}
+	if len(ops) != len(want) {
+		t.Fatalf("got %d ops, want %d:\n%v", len(ops), len(want), ops)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(ops[i], want[i]) {
+			t.Errorf("op %d:\ngot  %#v\nwant %#v", i, ops[i], want[i])
+		}
+	}
+}
+
+func TestIndent(t *testing.T) {
+	const in = "1 0 0 rgb set-source save n 10 10 m 20 20 l stroke+ restore push-group paint pop-group set-source\n"
+	const want = `1 0 0 rgb set-source
+save
+  n
+  10 10 m
+  20 20 l
+  stroke+
+restore
+push-group
+  paint
+pop-group
+set-source
+`
+	var buf bytes.Buffer
+	if err := Indent(&buf, strings.NewReader(in)); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestOpString(t *testing.T) {
+	o := Op{Name: "x", Args: []Value{
+		Name("n"), String("a)\x01"), String(strings.Repeat("z", 100)),
+		Proc{Exec("dup"), 1.5}, Dict{Name("k"), Const("V")},
+	}}
+	want := `/n (a\)\001) (<100 bytes>) { dup 1.5 } << /k //V >> x`
+	if s := o.String(); s != want {
+		t.Errorf("got %s, want %s", s, want)
+	}
+}
+
+//compressed returns s as a compressed string, as written by libcairo.
+func compressed(s string) string {
+	var z bytes.Buffer
+	binary.Write(&z, binary.BigEndian, uint32(len(s)))
+	w := zlib.NewWriter(&z)
+	w.Write([]byte(s))
+	w.Close()
+	out := make([]byte, ascii85.MaxEncodedLen(z.Len()))
+	return "<|" + string(out[:ascii85.Encode(out, z.Bytes())]) + "~>"
+}
+
+func TestCompressedString(t *testing.T) {
+	data := strings.Repeat("\x00\xff\x80\x01", 64)
+	//long strings are split across lines
+	c := compressed(data)
+	c = c[:len(c)/2] + "\n" + c[len(c)/2:]
+	ops, err := Parse(strings.NewReader(c + " pop"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 1 || len(ops[0].Args) != 1 {
+		t.Fatalf("got %v", ops)
+	}
+	if got := ops[0].Args[0]; !reflect.DeepEqual(got, String(data)) {
+		t.Errorf("got %v, want %d bytes", got, len(data))
+	}
+	if ops[0].Line != 2 {
+		t.Errorf("got line %d, want 2", ops[0].Line)
+	}
+}
+
+func TestSyntaxError(t *testing.T) {
+	for _, s := range []string{
+		"[ 1 2 }",
+		"1 ]",
+		"\n\n(unterminated",
+		"<< /a 1",
+		"<zz>",
+		"<|9jqo^~>",
+		compressed("data")[:8] + "~>",
+	} {
+		_, err := Parse(strings.NewReader(s))
+		if _, ok := err.(*SyntaxError); !ok {
+			t.Errorf("%q: got %v, want *SyntaxError", s, err)
+		}
+	}
+	_, err := NewParser(strings.NewReader("")).Next()
+	if err != io.EOF {
+		t.Errorf("empty trace: got %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,125 @@
+package trace
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+//Value is an operand in a trace.
+//
+//It is one of float64, String, Name, Const, Exec, Array, Proc, Dict, or Call.
+type Value interface{}
+
+//String is a string or binary data, such as image data.
+//Strings written in hexadecimal, ASCII85, or compressed are decoded.
+type String []byte
+
+//Name is a literal name, written /name.
+type Name string
+
+//Const is the value of a name defined by the interpreter,
+//written //NAME, such as //COLOR_ALPHA.
+type Const string
+
+//Exec is an operator that was not executed, because it appears
+//in an Array or Proc.
+type Exec string
+
+//Array is an array, written [ … ].
+type Array []Value
+
+//Proc is a procedure, written { … }.
+type Proc []Value
+
+//Dict is a dictionary, written << … >>, as alternating keys and values
+//in the order written.
+type Dict []Value
+
+//Get returns the value of key in d, or nil if there is none.
+func (d Dict) Get(key Name) Value {
+	for i := 0; i+1 < len(d); i += 2 {
+		if k, ok := d[i].(Name); ok && k == key {
+			return d[i+1]
+		}
+	}
+	return nil
+}
+
+//Call is the result of an operator that creates a value,
+//such as rgb or linear, with the operands it was called with.
+//
+//For example,
+//	1 0 0 rgb set-source
+//is a set-source Op whose only argument is
+//	Call{Name: "rgb", Args: []Value{1.0, 0.0, 0.0}}
+type Call struct {
+	Name string
+	Args []Value
+}
+
+//maxString is the length of a String after which it is elided by format.
+const maxString = 64
+
+//format writes v to buf in the syntax of a script,
+//except that long strings are elided.
+func format(buf *bytes.Buffer, v Value) {
+	switch v := v.(type) {
+	case float64:
+		buf.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	case String:
+		if len(v) > maxString {
+			fmt.Fprintf(buf, "(<%d bytes>)", len(v))
+			return
+		}
+		buf.WriteByte('(')
+		for _, b := range v {
+			switch b {
+			case '(', ')', '\\':
+				buf.WriteByte('\\')
+				buf.WriteByte(b)
+			case '\n':
+				buf.WriteString(`\n`)
+			default:
+				if b < ' ' || b > '~' {
+					fmt.Fprintf(buf, `\%03o`, b)
+				} else {
+					buf.WriteByte(b)
+				}
+			}
+		}
+		buf.WriteByte(')')
+	case Name:
+		buf.WriteByte('/')
+		buf.WriteString(string(v))
+	case Const:
+		buf.WriteString("//")
+		buf.WriteString(string(v))
+	case Exec:
+		buf.WriteString(string(v))
+	case Array:
+		list(buf, "[", []Value(v), "]")
+	case Proc:
+		list(buf, "{", []Value(v), "}")
+	case Dict:
+		list(buf, "<<", []Value(v), ">>")
+	case Call:
+		for _, a := range v.Args {
+			format(buf, a)
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(v.Name)
+	default:
+		fmt.Fprint(buf, v)
+	}
+}
+
+func list(buf *bytes.Buffer, open string, vs []Value, close string) {
+	buf.WriteString(open)
+	for _, v := range vs {
+		buf.WriteByte(' ')
+		format(buf, v)
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(close)
+}
@@ -0,0 +1,29 @@
+//Declarations of the libcairo script functions used by this package.
+//They are weak, so that this package links against a libcairo compiled
+//without the script backend, and New can report that it is not supported.
+#include <cairo/cairo.h>
+
+#if CAIRO_HAS_SCRIPT_SURFACE
+#include <cairo/cairo-script.h>
+#else
+typedef enum {
+	CAIRO_SCRIPT_MODE_ASCII,
+	CAIRO_SCRIPT_MODE_BINARY
+} cairo_script_mode_t;
+
+cairo_device_t *cairo_script_create_for_stream(cairo_write_func_t write_func, void *closure);
+void cairo_script_write_comment(cairo_device_t *script, const char *comment, int len);
+void cairo_script_set_mode(cairo_device_t *script, cairo_script_mode_t mode);
+cairo_script_mode_t cairo_script_get_mode(cairo_device_t *script);
+cairo_surface_t *cairo_script_surface_create(cairo_device_t *script, cairo_content_t content, double width, double height);
+cairo_surface_t *cairo_script_surface_create_for_target(cairo_device_t *script, cairo_surface_t *target);
+cairo_status_t cairo_script_from_recording_surface(cairo_device_t *script, cairo_surface_t *recording_surface);
+#endif
+
+#pragma weak cairo_script_create_for_stream
+#pragma weak cairo_script_write_comment
+#pragma weak cairo_script_set_mode
+#pragma weak cairo_script_get_mode
+#pragma weak cairo_script_surface_create
+#pragma weak cairo_script_surface_create_for_target
+#pragma weak cairo_script_from_recording_surface
@@ -1,7 +1,7 @@
 package cairo
 
 //#cgo pkg-config: cairo
-//#include <cairo/cairo.h>
+//#include "weak.h"
 import "C"
 
 import (
@@ -22,16 +22,25 @@ var csurftogosurf = map[surfaceType]func(*C.cairo_surface_t) (Surface, error){
 //function during init, otherwise users will get random not implemented
 //panics for your surface.
 func XtensionRegisterRawToSurface(t surfaceType, f func(*C.cairo_surface_t) (Surface, error)) {
+	regmux.Lock()
+	defer regmux.Unlock()
 	csurftogosurf[t] = f
 }
 
+func surfaceConverter(t surfaceType) (func(*C.cairo_surface_t) (Surface, error), bool) {
+	regmux.RLock()
+	defer regmux.RUnlock()
+	f, ok := csurftogosurf[t]
+	return f, ok
+}
+
 //XtensionRevivifySurface recreates a Go Surface of the proper type
 //from a C surface.
 //
 //This is for extension writers only.
 func XtensionRevivifySurface(s *C.cairo_surface_t) (S Surface, err error) {
 	t := surfaceType(C.cairo_surface_get_type(s))
-	f, ok := csurftogosurf[t]
+	f, ok := surfaceConverter(t)
 	if !ok {
 		panic("No C → Go surface converter registered for " + t.String())
 	}
@@ -68,6 +77,9 @@ type Surface interface {
 	Err() error
 	Close() error
 	Flush() error
+	Finish() error
+	MarkDirty() error
+	MarkDirtyRectangle(r image.Rectangle) error
 
 	Content() Content
 	Device() (Device, error)
@@ -76,6 +88,8 @@ type Surface interface {
 
 	SetDeviceOffset(Point)
 	DeviceOffset() Point
+	SetDeviceScale(Point) error
+	DeviceScale() Point
 
 	Type() surfaceType
 
@@ -179,6 +193,8 @@ func (e *XtensionSurface) XtensionRaw() *C.cairo_surface_t {
 //described by r is mapped.
 //
 //Note that r is an image.Rectangle and not a cairo.Rectangle.
+//It is in the pixels of the surface's backing store,
+//so it is not affected by the device scale.
 //
 //It is the callers responsibility to all Close on the returned surface
 //in order to upload the content of the mapped image to this surface and
@@ -195,8 +211,14 @@ func (e *XtensionSurface) XtensionRaw() *C.cairo_surface_t {
 //Changing the device transform of either surface before the image surface
 //is unmapped is undefined.
 //
+//If libcairo does not support FeatureMapImage,
+//a *NotSupportedError is returned.
+//
 //Originally cairo_surface_map_to_image.
 func (e *XtensionSurface) MapImage(r image.Rectangle) (MappedImageSurface, error) {
+	if err := XtensionRequire("Surface.MapImage", FeatureMapImage); err != nil {
+		return MappedImageSurface{}, err
+	}
 	var rect C.cairo_rectangle_int_t
 	rect.x, rect.y = C.int(r.Min.X), C.int(r.Min.Y)
 	rect.width, rect.height = C.int(r.Dx()), C.int(r.Dy())
@@ -212,24 +234,59 @@ func (e *XtensionSurface) MapImage(r image.Rectangle) (MappedImageSurface, error
 //
 //Originally cairo_surface_status.
 func (e *XtensionSurface) Err() error {
+	return e.err("")
+}
+
+//err reports the current error state of e as reported by op.
+func (e *XtensionSurface) err(op string) error {
 	if e.s == nil {
 		return ErrInvalidLibcairoHandle
 	}
-	return toerrIded(C.cairo_surface_status(e.s), e)
+	return toerrIded(C.cairo_surface_status(e.s), op, "surface", e.s, e)
 }
 
 //Close frees the resources used by this surface.
 //
+//If this was the last reference to the surface, it is finished
+//as if by Finish, and any error writing the surface is returned.
+//
 //Originally cairo_surface_destroy.
 func (e *XtensionSurface) Close() error {
-	if e.s == nil {
+	if e == nil || e.s == nil {
 		return nil
 	}
-	err := e.Err()
+	W := writerFor(e.id())
+	err := e.err("Surface.Close")
 	C.cairo_surface_destroy(e.s)
 	e.s = nil
 	runtime.SetFinalizer(e, nil)
-	return err
+	return writeErr(W, err)
+}
+
+//Finish finishes the surface and drops all references to external
+//resources.
+//For example, a PDF, PostScript, or SVG surface completes its document
+//and writes any remaining output.
+//
+//Unlike Close, Finish takes effect even if there are other references
+//to the surface, such as a Context or a pattern using it as a source.
+//Close must still be called to free the surface.
+//
+//After Finish, the only valid operations on the surface are Flush, Finish,
+//Err, and Close.
+//Drawing to a finished surface is an error, ErrSurfaceFinished.
+//
+//If the surface writes to an io.Writer, the first error returned
+//by the writer is returned.
+//
+//Originally cairo_surface_finish.
+func (e *XtensionSurface) Finish() error {
+	if e.s == nil {
+		return ErrInvalidLibcairoHandle
+	}
+	W := writerFor(e.id())
+	C.cairo_surface_finish(e.s)
+	return writeErr(W, e.err("Surface.Finish"))
 }
 
 //Flush performs any pending drawing and restores any temporary modifcations
@@ -238,7 +295,34 @@ func (e *XtensionSurface) Close() error {
 //Originally cairo_surface_flush.
 func (e *XtensionSurface) Flush() error {
 	C.cairo_surface_flush(e.s)
-	return e.Err()
+	return e.err("Surface.Flush")
+}
+
+//MarkDirty tells cairo that drawing has been done to the surface
+//using means other than cairo, and that cairo should reread any cached areas.
+//
+//Flush must be called before such drawing is done.
+//
+//Originally cairo_surface_mark_dirty.
+func (e *XtensionSurface) MarkDirty() error {
+	C.cairo_surface_mark_dirty(e.s)
+	return e.err("Surface.MarkDirty")
+}
+
+//MarkDirtyRectangle is like MarkDirty, but drawing has been done only
+//to the region described by r, so that cairo can retain cached contents
+//for other parts of the surface.
+//
+//As with MapImage, r is an image.Rectangle in the pixels of the surface's
+//backing store: it is not affected by the device scale.
+//It is, however, relative to the device offset.
+//
+//Any cached clip set on the surface is reset.
+//
+//Originally cairo_surface_mark_dirty_rectangle.
+func (e *XtensionSurface) MarkDirtyRectangle(r image.Rectangle) error {
+	C.cairo_surface_mark_dirty_rectangle(e.s, C.int(r.Min.X), C.int(r.Min.Y), C.int(r.Dx()), C.int(r.Dy()))
+	return e.err("Surface.MarkDirtyRectangle")
 }
 
 //Type reports the type of this surface.
@@ -278,9 +362,9 @@ func (e *XtensionSurface) HasShowTextGlyphs() bool {
 //
 //Originally cairo_surface_get_font_options.
 func (e *XtensionSurface) FontOptions() *FontOptions {
-	f := &C.cairo_font_options_t{}
-	C.cairo_surface_get_font_options(e.s, f)
-	return initFontOptions(f)
+	f := NewFontOptions()
+	C.cairo_surface_get_font_options(e.s, f.fo)
+	return f
 }
 
 //SetDeviceOffset sets the device offset of this surface.
@@ -312,13 +396,62 @@ func (e *XtensionSurface) DeviceOffset() (vector Point) {
 	return cPt(x, y)
 }
 
-//CreateSimilar is documented in the Surface interface.
+//SetDeviceScale sets the device scale of this surface.
+//
+//The device scale multiplies the device coordinates determined by the
+//coordinate transform matrix when drawing to a surface.
+//
+//The main use case for this method is to support high DPI displays:
+//with a device scale of (2, 2), drawing code written in terms of logical
+//units renders at twice the resolution, without being aware of it.
+//Unlike changing the coordinate transform, queries such as DeviceToUser
+//expose the scale, and it is preserved across Save and Restore.
+//
+//Note that the scale affects drawing to the surface as well
+//as using the surface in a source pattern.
+//A surface with a device scale of (2, 2) used as a source is drawn at half
+//its size in pixels.
+//
+//Neither component of scale may be 0.
+//
+//If libcairo does not support FeatureDeviceScale,
+//a *NotSupportedError is returned.
+//
+//Originally cairo_surface_set_device_scale.
+func (e *XtensionSurface) SetDeviceScale(scale Point) error {
+	if err := XtensionRequire("Surface.SetDeviceScale", FeatureDeviceScale); err != nil {
+		return err
+	}
+	C.cairo_surface_set_device_scale(e.s, C.double(scale.X), C.double(scale.Y))
+	return e.err("Surface.SetDeviceScale")
+}
+
+//DeviceScale reports the device scale set by SetDeviceScale.
+//
+//The default is (1, 1), which is always reported if libcairo does not
+//support FeatureDeviceScale.
+//
+//Originally cairo_surface_get_device_scale.
+func (e *XtensionSurface) DeviceScale() (scale Point) {
+	if !features.Has(FeatureDeviceScale) {
+		return Pt(1, 1)
+	}
+	var x, y C.double
+	C.cairo_surface_get_device_scale(e.s, &x, &y)
+	return cPt(x, y)
+}
+
+//CreateSimilar creates a new surface that is as compatible as possible
 //with e.
 //For example, the new surface will have the same fallback resolution and font
 //options as e.
 //Generally, the new surface will also use the same backend as e, unless that
 //is not possible for some reason.
 //
+//The width and height of the new surface are in the logical units of e:
+//the new surface has the same device scale as e, so that its size in pixels
+//is w and h multiplied by the device scale of e.
+//
 //Initially the contents of the returned surface are all 0 (transparent if contents
 //have transparency, black otherwise.)
 //
@@ -326,18 +459,27 @@ func (e *XtensionSurface) DeviceOffset() (vector Point) {
 func (e *XtensionSurface) CreateSimilar(c Content, w, h int) (Surface, error) {
 	s := C.cairo_surface_create_similar(e.s, c.c(), C.int(w), C.int(h))
 	o := NewXtensionSurface(s)
-	return o, o.Err()
+	return o, o.err("Surface.CreateSimilar")
 }
 
 //CreateSimilarImage creates a new surface that is as compatible as possible
 //for uploading to and using in conjunction with existing surface.
 //However, this surface can still be used like any normal image surface.
 //
+//Unlike CreateSimilar, w and h are in pixels and the device scale of e is
+//not inherited: the returned surface always has a device scale of (1, 1).
+//
 //Initially the contents of the returned surface are all 0 (transparent if contents
 //have transparency, black otherwise.)
 //
+//If libcairo does not support FeatureMapImage,
+//a *NotSupportedError is returned.
+//
 //Originally cairo_surface_create_similar_image.
 func (e *XtensionSurface) CreateSimilarImage(f Format, w, h int) (ImageSurface, error) {
+	if err := XtensionRequire("Surface.CreateSimilarImage", FeatureMapImage); err != nil {
+		return ImageSurface{}, err
+	}
 	s := C.cairo_surface_create_similar_image(e.s, f.c(), C.int(w), C.int(h))
 	stride := int(C.cairo_image_surface_get_stride(s))
 	o := ImageSurface{
@@ -347,7 +489,7 @@ func (e *XtensionSurface) CreateSimilarImage(f Format, w, h int) (ImageSurface,
 		height:          h,
 		stride:          stride,
 	}
-	return o, o.Err()
+	return o, o.err("Surface.CreateSimilarImage")
 }
 
 //CreateSubsurface creates a window into e defined by r.
@@ -372,7 +514,7 @@ func (e *XtensionSurface) CreateSubsurface(r Rectangle) (s Subsurface, err error
 	y1 := C.double(r.Dy())
 	ss := C.cairo_surface_create_for_rectangle(e.s, x0, y0, x1, y1)
 	o := Subsurface{NewXtensionPagedVectorSurface(ss)}
-	return o, o.Err()
+	return o, o.err("Surface.CreateSubsurface")
 }
 
 func setFallbackResolution(s *C.cairo_surface_t, xppi, yppi float64) {
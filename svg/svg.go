@@ -1,14 +1,13 @@
 //Package svg implements the SVG backend for libcairo rendering.
 //
-//Libcairo must be compiled with
+//If libcairo is not compiled with
 //	CAIRO_HAS_SVG_SURFACE
-//in addition to the requirements of cairo.
+//this package still builds, but New returns a *cairo.NotSupportedError.
 package svg
 
 //#cgo pkg-config: cairo
 //#include <stdlib.h>
-//#include <cairo/cairo.h>
-//#include <cairo/cairo-svg.h>
+//#include "weak.h"
 import "C"
 
 import (
@@ -34,6 +33,9 @@ type Surface struct {
 //
 //Originally cairo_svg_surface_create_for_stream.
 func New(w io.Writer, width, height float64) (Surface, error) {
+	if err := cairo.XtensionRequire("svg.New", cairo.FeatureSVG); err != nil {
+		return Surface{}, err
+	}
 	wp := cairo.XtensionWrapWriter(w)
 	svg := C.cairo_svg_surface_create_for_stream(cairo.XtensionCairoWriteFuncT, wp, C.double(width), C.double(height))
 	s := Surface{
@@ -1,11 +1,14 @@
 package svg
 
 //#cgo pkg-config: cairo
-//#include <cairo/cairo.h>
-//#include <cairo/cairo-svg.h>
+//#include "weak.h"
 import "C"
 
-import "unsafe"
+import (
+	"unsafe"
+
+	"github.com/jimmyfrasche/cairo"
+)
 
 type version int
 
@@ -25,6 +28,9 @@ func (v version) c() C.cairo_svg_version_t {
 }
 
 func (v version) String() string {
+	if !cairo.Features().Has(cairo.FeatureSVG) {
+		return "unknown SVG version"
+	}
 	V := C.cairo_svg_version_to_string(C.cairo_svg_version_t(v))
 	if V == nil {
 		return "unknown SVG version"
@@ -34,8 +40,14 @@ func (v version) String() string {
 
 //Versions returns the SVG versions that libcairo supports.
 //
+//If libcairo does not support SVG, Versions returns nil.
+//
 //Originally cairo_svg_get_versions.
 func Versions() (versions []version) {
+	if !cairo.Features().Has(cairo.FeatureSVG) {
+		return nil
+	}
+
 	var vs *C.cairo_svg_version_t
 	var N C.int
 
@@ -0,0 +1,23 @@
+//Declarations of the libcairo SVG functions used by this package.
+//They are weak, so that this package links against a libcairo compiled
+//without the SVG backend, and New can report that it is not supported.
+#include <cairo/cairo.h>
+
+#if CAIRO_HAS_SVG_SURFACE
+#include <cairo/cairo-svg.h>
+#else
+typedef enum _cairo_svg_version {
+	CAIRO_SVG_VERSION_1_1,
+	CAIRO_SVG_VERSION_1_2
+} cairo_svg_version_t;
+
+cairo_surface_t *cairo_svg_surface_create_for_stream(cairo_write_func_t write_func, void *closure, double width_in_points, double height_in_points);
+void cairo_svg_surface_restrict_to_version(cairo_surface_t *surface, cairo_svg_version_t version);
+void cairo_svg_get_versions(cairo_svg_version_t const **versions, int *num_versions);
+const char *cairo_svg_version_to_string(cairo_svg_version_t version);
+#endif
+
+#pragma weak cairo_svg_surface_create_for_stream
+#pragma weak cairo_svg_surface_restrict_to_version
+#pragma weak cairo_svg_get_versions
+#pragma weak cairo_svg_version_to_string
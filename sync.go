@@ -0,0 +1,77 @@
+package cairo
+
+import "sync"
+
+//SyncContext is a Context that may be shared by multiple goroutines.
+//
+//Calls to Do are serialized, so that only one goroutine uses the Context
+//at a time.
+//The state of the Context, such as the current path and source,
+//is shared by every call;
+//use Context.SaveRestore in f to keep changes to the state from
+//affecting other calls.
+//
+//SyncContext only serializes the use of its own Context.
+//Other Contexts drawing on the same surface must not be used at the same
+//time.
+type SyncContext struct {
+	mu sync.Mutex
+	c  *Context
+}
+
+//NewSyncContext creates a SyncContext that draws on target.
+func NewSyncContext(target Surface) (*SyncContext, error) {
+	c, err := New(target)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	return Sync(c), nil
+}
+
+//Sync returns a SyncContext that serializes access to c.
+//
+//After calling Sync, c must only be used through the SyncContext.
+func Sync(c *Context) *SyncContext {
+	return &SyncContext{c: c}
+}
+
+//Do calls f with the Context of s, while no other call to a method of s
+//is running.
+//
+//The Context must not be retained after f returns.
+//
+//Do returns the error returned by f or, if f returns nil,
+//any error on the Context.
+func (s *SyncContext) Do(f func(c *Context) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.c == nil {
+		return ErrInvalidLibcairoHandle
+	}
+	if err := f(s.c); err != nil {
+		return err
+	}
+	return s.c.Err()
+}
+
+//Err reports the current error state of the Context of s.
+func (s *SyncContext) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.c == nil {
+		return ErrInvalidLibcairoHandle
+	}
+	return s.c.Err()
+}
+
+//Close waits for any call to Do to return and closes the Context of s.
+//
+//Calls to Do after Close return ErrInvalidLibcairoHandle.
+func (s *SyncContext) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.c.Close()
+	s.c = nil
+	return err
+}
@@ -0,0 +1,134 @@
+package cairo
+
+import (
+	"image/color"
+	"sync"
+	"testing"
+)
+
+var opaqueRed = color.RGBA{R: 0xff, A: 0xff}
+
+func TestRegistryConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			XtensionRegisterRawToSurface(SurfaceTypeImage, cNewImageSurface)
+		}()
+		go func() {
+			defer wg.Done()
+			if _, ok := surfaceConverter(SurfaceTypeImage); !ok {
+				t.Error("image surface converter not registered")
+			}
+			fontConverter(FontTypeToy)
+			deviceConverter(DeviceTypeScript)
+		}()
+	}
+	wg.Wait()
+}
+
+//Each goroutine draws on its own surface,
+//using a pattern and scaled font shared by all of them.
+func TestConcurrentImageSurfaces(t *testing.T) {
+	gradient := NewLinearGradient(Pt(0, 0), Pt(16, 0),
+		ColorStop{0, Red},
+		ColorStop{1, Red},
+	)
+	defer gradient.Close()
+	sf, err := NewScaledFont(
+		NewToyFont("", SlantNormal, WeightNormal),
+		NewScaleMatrix(Pt(12, 12)),
+		NewIdentityMatrix(),
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sf.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, err := NewImageSurface(FormatARGB32, 16, 16)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer s.Close()
+			c, err := New(s)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			c.SetSource(gradient).
+				Paint().
+				SetScaledFont(sf).
+				MoveTo(Pt(0, 12)).
+				ShowText("go")
+			if err := c.Close(); err != nil {
+				t.Error(err)
+				return
+			}
+			img, err := s.ToImage(Pt(1, 1))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if got := img.RGBAAt(0, 0); got != opaqueRed {
+				t.Errorf("got %v, want %v", got, opaqueRed)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSyncContext(t *testing.T) {
+	const n = 64
+	s, err := NewImageSurface(FormatARGB32, n, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	sc, err := NewSyncContext(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(x float64) {
+			defer wg.Done()
+			err := sc.Do(func(c *Context) error {
+				c.SetSourceColor(Red).
+					Rectangle(RectWH(x, 0, 1, 1)).
+					Fill()
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}(float64(i))
+	}
+	wg.Wait()
+
+	if err := sc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.Do(func(*Context) error { return nil }); err != ErrInvalidLibcairoHandle {
+		t.Errorf("Do after Close: got %v, want %v", err, ErrInvalidLibcairoHandle)
+	}
+
+	img, err := s.ToImage(Pt(1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x := 0; x < n; x++ {
+		if got := img.RGBAAt(x, 0); got != opaqueRed {
+			t.Errorf("pixel %d: got %v, want %v", x, got, opaqueRed)
+		}
+	}
+}
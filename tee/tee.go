@@ -1,14 +1,13 @@
 //Package tee implements a surface that multiplexes all operations performed
 //on it to the one or more underlying surfaces.
 //
-//Libcairo must be compiled with
+//If libcairo is not compiled with
 //	CAIRO_HAS_TEE_SURFACE
-//in addition to the requirements of cairo.
+//this package still builds, but New returns a *cairo.NotSupportedError.
 package tee
 
 //#cgo pkg-config: cairo
-//#include <cairo/cairo.h>
-//#include <cairo/cairo-tee.h>
+//#include "weak.h"
 import "C"
 
 import (
@@ -18,9 +17,21 @@ import (
 //Surface is a tee surface.
 //
 //Surface is a paged vector surface.
-//
+//The paged and vector operations, such as ShowPage, are performed on each
+//underlying surface in turn.
 //If any of the underlying surface are not paged or vector backed, those
-//operations will be no-ops.
+//operations will be no-ops on that surface.
+//
+//Each underlying surface is drawn on with its own device offset and scale,
+//so that, for example, a single pass can draw a full size PDF and a
+//thumbnail image:
+//	s, err := tee.New(pdfSurface)
+//	if err != nil {
+//		return err
+//	}
+//	if err := s.AddTransformed(thumb, cairo.ZP, cairo.Pt(.1, .1)); err != nil {
+//		return err
+//	}
 type Surface struct {
 	cairo.XtensionPagedVectorSurface
 }
@@ -29,6 +40,9 @@ type Surface struct {
 //
 //Originally cairo_tee_surface_create.
 func New(masterSurface cairo.Surface, surfaces ...cairo.Surface) (Surface, error) {
+	if err := cairo.XtensionRequire("tee.New", cairo.FeatureTee); err != nil {
+		return Surface{}, err
+	}
 	m := C.cairo_tee_surface_create(masterSurface.XtensionRaw())
 	for _, s := range surfaces {
 		C.cairo_tee_surface_add(m, s.XtensionRaw())
@@ -46,7 +60,51 @@ func init() {
 	cairo.XtensionRegisterRawToSurface(cairo.SurfaceTypeTee, cNew)
 }
 
+//raw returns the ith surface of s, or nil if there is none.
+func (s Surface) raw(i int) *C.cairo_surface_t {
+	sir := C.cairo_tee_surface_index(s.XtensionRaw(), C.uint(i))
+	if C.cairo_surface_status(sir) != C.CAIRO_STATUS_SUCCESS {
+		return nil
+	}
+	return sir
+}
+
+//Len reports the number of surfaces in s, including the master surface.
+func (s Surface) Len() int {
+	n := 0
+	for s.raw(n) != nil {
+		n++
+	}
+	return n
+}
+
+//Master returns the master surface of s, the surface s was created with.
+//
+//Master is shorthand for Index(0).
+func (s Surface) Master() (cairo.Surface, error) {
+	return s.Index(0)
+}
+
+//Surfaces returns the surfaces of s, beginning with the master surface.
+//
+//The returned error is set if there's an error on any of the returned
+//surfaces.
+func (s Surface) Surfaces() ([]cairo.Surface, error) {
+	var ss []cairo.Surface
+	for i := 0; ; i++ {
+		si, err := s.Index(i)
+		if err != nil {
+			return ss, err
+		}
+		if si == nil {
+			return ss, nil
+		}
+		ss = append(ss, si)
+	}
+}
+
 //Index returns the ith surface of this tee.
+//The master surface is at index 0.
 //
 //The returned error is set if there's an error on the returned surface.
 //If the index does not exist, Index returns (nil, nil).
@@ -88,3 +146,80 @@ func (s Surface) Add(as ...cairo.Surface) error {
 	}
 	return s.Err()
 }
+
+//AddTransformed adds a to s, drawn with the device offset and scale given.
+//
+//A zero scale is treated as Pt(1, 1).
+//The device offset and scale are set on a, so they also apply when drawing
+//on a directly.
+//See cairo.XtensionSurface.SetDeviceOffset and SetDeviceScale.
+func (s Surface) AddTransformed(a cairo.Surface, offset, scale cairo.Point) error {
+	if scale == cairo.ZP {
+		scale = cairo.Pt(1, 1)
+	}
+	if err := a.SetDeviceScale(scale); err != nil {
+		return err
+	}
+	a.SetDeviceOffset(offset)
+	return s.Add(a)
+}
+
+//each calls f on each surface of s.
+func (s Surface) each(f func(*C.cairo_surface_t)) {
+	for i := 0; ; i++ {
+		si := s.raw(i)
+		if si == nil {
+			return
+		}
+		f(si)
+	}
+}
+
+//ShowPage emits the current page of each surface of s, as if by calling
+//ShowPage on each.
+//
+//Libcairo does not pass pages through a tee surface, so calling
+//Context.ShowPage on a Context drawing on s does not reach the surfaces
+//of s; use this method instead.
+//
+//Originally cairo_surface_show_page.
+func (s Surface) ShowPage() {
+	s.each(func(si *C.cairo_surface_t) {
+		C.cairo_surface_show_page(si)
+	})
+}
+
+//CopyPage emits the current page of each surface of s, retaining its
+//contents, as if by calling CopyPage on each.
+//
+//As with ShowPage, use this method rather than Context.CopyPage.
+//
+//Originally cairo_surface_copy_page.
+func (s Surface) CopyPage() {
+	s.each(func(si *C.cairo_surface_t) {
+		C.cairo_surface_copy_page(si)
+	})
+}
+
+//SetFallbackResolution sets the fallback resolution of each surface of s.
+//
+//Originally cairo_surface_set_fallback_resolution.
+func (s Surface) SetFallbackResolution(xppi, yppi float64) {
+	s.each(func(si *C.cairo_surface_t) {
+		C.cairo_surface_set_fallback_resolution(si, C.double(xppi), C.double(yppi))
+	})
+}
+
+//FallbackResolution reports the fallback resolution of the master surface
+//of s.
+//
+//Originally cairo_surface_get_fallback_resolution.
+func (s Surface) FallbackResolution() (xppi, yppi float64) {
+	si := s.raw(0)
+	if si == nil {
+		return 0, 0
+	}
+	var x, y C.double
+	C.cairo_surface_get_fallback_resolution(si, &x, &y)
+	return float64(x), float64(y)
+}
@@ -0,0 +1,89 @@
+package tee
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/jimmyfrasche/cairo"
+	"github.com/jimmyfrasche/cairo/pdf"
+)
+
+func TestSurface(t *testing.T) {
+	if !cairo.Features().Has(cairo.FeatureTee | cairo.FeaturePDF) {
+		t.Skip("libcairo does not support tee and PDF surfaces")
+	}
+
+	var buf bytes.Buffer
+	doc, err := pdf.New(&buf, 40, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+	thumb, err := cairo.NewImageSurface(cairo.FormatARGB32, 10, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer thumb.Close()
+
+	s, err := New(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if err := s.AddTransformed(thumb, cairo.ZP, cairo.Pt(.25, .25)); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := s.Len(); n != 2 {
+		t.Errorf("Len: got %d, want 2", n)
+	}
+	if m, err := s.Master(); err != nil || m.XtensionRaw() != doc.XtensionRaw() {
+		t.Errorf("Master: got %v, %v, want the PDF surface", m, err)
+	}
+	ss, err := s.Surfaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ss) != 2 || ss[1].XtensionRaw() != thumb.XtensionRaw() {
+		t.Errorf("Surfaces: got %v", ss)
+	}
+
+	for i := 0; i < 2; i++ {
+		c, err := cairo.New(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.
+			SetSourceColor(cairo.Red).
+			Rectangle(cairo.RectWH(20, 20, 20, 20)).
+			Fill()
+		if err := c.Close(); err != nil {
+			t.Fatal(err)
+		}
+		s.ShowPage()
+	}
+
+	//the 40×40 drawing is scaled to the 10×10 thumbnail,
+	//so only its bottom right quarter is red.
+	img, err := thumb.ToImage(cairo.ZP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := img.RGBAAt(7, 7); c.R != 0xff || c.A != 0xff {
+		t.Errorf("thumbnail (7, 7): got %v, want red", c)
+	}
+	if c := img.RGBAAt(2, 2); c.A != 0 {
+		t.Errorf("thumbnail (2, 2): got %v, want transparent", c)
+	}
+
+	//s holds a reference to doc, so closing doc would not finish it,
+	//and the PDF would not yet be written.
+	if err := doc.Finish(); err != nil {
+		t.Fatal(err)
+	}
+	pages := regexp.MustCompile(`/Type\s*/Page\b`).FindAll(buf.Bytes(), -1)
+	if len(pages) != 2 {
+		t.Errorf("got %d PDF pages, want 2", len(pages))
+	}
+}
@@ -0,0 +1,18 @@
+//Declarations of the libcairo tee functions used by this package.
+//They are weak, so that this package links against a libcairo compiled
+//without the tee surface, and New can report that it is not supported.
+#include <cairo/cairo.h>
+
+#if CAIRO_HAS_TEE_SURFACE
+#include <cairo/cairo-tee.h>
+#else
+cairo_surface_t *cairo_tee_surface_create(cairo_surface_t *master);
+void cairo_tee_surface_add(cairo_surface_t *surface, cairo_surface_t *target);
+void cairo_tee_surface_remove(cairo_surface_t *surface, cairo_surface_t *target);
+cairo_surface_t *cairo_tee_surface_index(cairo_surface_t *surface, unsigned int index);
+#endif
+
+#pragma weak cairo_tee_surface_create
+#pragma weak cairo_tee_surface_add
+#pragma weak cairo_tee_surface_remove
+#pragma weak cairo_tee_surface_index
@@ -0,0 +1,260 @@
+package text
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+//lbClass is a Unicode line breaking class, as defined by UAX #14.
+//Only the classes that affect the rules implemented here are distinguished.
+type lbClass int
+
+const (
+	lbAL lbClass = iota //alphabetic, and anything not otherwise classified
+	lbBK                //mandatory break
+	lbCR                //carriage return
+	lbLF                //line feed
+	lbNL                //next line
+	lbSP                //space
+	lbZW                //zero width space
+	lbWJ                //word joiner
+	lbGL                //non-breaking glue
+	lbCM                //combining mark
+	lbOP                //opening punctuation
+	lbCL                //closing punctuation
+	lbEX                //exclamation and interrogation
+	lbIS                //infix numeric separator
+	lbSY                //symbols allowing break after
+	lbQU                //quotation
+	lbBA                //break after
+	lbHY                //hyphen
+	lbNS                //nonstarter
+	lbNU                //numeric
+	lbID                //ideographic
+)
+
+var nonstarters = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x3005, 0x3005, 1}, //々
+		{0x303b, 0x303c, 1},
+		{0x309b, 0x309e, 1},
+		{0x30a0, 0x30a0, 1},
+		{0x30fb, 0x30fe, 1},
+	},
+}
+
+func class(r rune) lbClass {
+	switch r {
+	case '\n':
+		return lbLF
+	case '\r':
+		return lbCR
+	case '\v', '\f', 0x2028, 0x2029:
+		return lbBK
+	case 0x85:
+		return lbNL
+	case ' ':
+		return lbSP
+	case 0x200b:
+		return lbZW
+	case 0x2060, 0xfeff:
+		return lbWJ
+	case 0xa0, 0x202f, 0x2007, 0x2011, 0x034f:
+		return lbGL
+	case '\t', 0xad, 0x2010, 0x2012, 0x2013, '|', 0x1680,
+		0x2000, 0x2001, 0x2002, 0x2003, 0x2004, 0x2005, 0x2006,
+		0x2008, 0x2009, 0x200a, 0x205f:
+		return lbBA
+	case '-':
+		return lbHY
+	case '!', '?', 0x203c, 0x2047, 0x2048, 0x2049, 0xff01, 0xff1f:
+		return lbEX
+	case ',', '.', ':', ';', 0x037e, 0x0589:
+		return lbIS
+	case '/':
+		return lbSY
+	case '"', '\'', 0xab, 0xbb, 0x2018, 0x2019, 0x201c, 0x201d, 0x2039, 0x203a:
+		return lbQU
+	case 0x3001, 0x3002, 0xff0c, 0xff0e:
+		return lbCL
+	}
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return lbCM
+	case unicode.Is(unicode.Ps, r):
+		return lbOP
+	case unicode.Is(unicode.Pe, r):
+		return lbCL
+	case unicode.Is(unicode.Nd, r):
+		return lbNU
+	case unicode.Is(nonstarters, r):
+		return lbNS
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+		return lbID
+	}
+	return lbAL
+}
+
+//breakKind is the kind of line break opportunity.
+type breakKind int
+
+const (
+	noBreak breakKind = iota
+	allowBreak
+	mustBreak
+)
+
+//lineBreaks returns, for each byte offset of s, the kind of line break
+//opportunity before that byte.
+//The returned slice has length len(s)+1; the final entry is always mustBreak,
+//and the first is always noBreak.
+//Entries for bytes that do not start a rune are noBreak.
+//
+//This implements the pair rules of the Unicode line breaking algorithm,
+//UAX #14, LB2–LB31, for the classes distinguished by class.
+//Classes are derived from the tables in package unicode rather than
+//the full Line_Break property, so rules specific to the classes
+//not distinguished are not applied.
+func lineBreaks(s string) []breakKind {
+	out := make([]breakKind, len(s)+1)
+	out[len(s)] = mustBreak
+	if s == "" {
+		return out
+	}
+
+	//prev is the class before the current position, after applying LB9,
+	//and beforeSP is the class before any intervening spaces.
+	var prev, beforeSP lbClass
+	first := true
+	for i, r := range s {
+		cur := class(r)
+		if first {
+			first = false
+			if cur == lbCM {
+				cur = lbAL //LB10
+			}
+			prev, beforeSP = cur, cur
+			continue
+		}
+		out[i] = pairBreak(prev, beforeSP, cur)
+
+		switch {
+		case cur == lbCM && out[i] == noBreak && prev != lbSP:
+			//LB9: X CM* → X, so prev is unchanged
+			continue
+		case cur == lbCM:
+			cur = lbAL //LB10
+		}
+		if cur != lbSP {
+			beforeSP = cur
+		}
+		prev = cur
+	}
+	return out
+}
+
+//pairBreak returns the kind of break between a character of class prev and
+//one of class cur, where beforeSP is the class of the last character
+//that is not a space.
+func pairBreak(prev, beforeSP, cur lbClass) breakKind {
+	switch {
+	//LB4, LB5
+	case prev == lbBK, prev == lbNL, prev == lbLF:
+		return mustBreak
+	case prev == lbCR:
+		if cur == lbLF {
+			return noBreak
+		}
+		return mustBreak
+	//LB6
+	case cur == lbBK, cur == lbCR, cur == lbLF, cur == lbNL:
+		return noBreak
+	//LB7
+	case cur == lbSP, cur == lbZW:
+		return noBreak
+	//LB8
+	case beforeSP == lbZW:
+		return allowBreak
+	//LB9, handled in lineBreaks, and LB10
+	case cur == lbCM && prev != lbSP:
+		return noBreak
+	//LB11
+	case prev == lbWJ, cur == lbWJ:
+		return noBreak
+	//LB12
+	case prev == lbGL:
+		return noBreak
+	//LB12a
+	case cur == lbGL && prev != lbSP && prev != lbBA && prev != lbHY:
+		return noBreak
+	//LB13
+	case cur == lbCL, cur == lbEX, cur == lbIS, cur == lbSY:
+		return noBreak
+	//LB14
+	case beforeSP == lbOP:
+		return noBreak
+	//LB15
+	case beforeSP == lbQU && cur == lbOP:
+		return noBreak
+	//LB18
+	case prev == lbSP:
+		return allowBreak
+	//LB19
+	case prev == lbQU, cur == lbQU:
+		return noBreak
+	//LB21
+	case cur == lbBA, cur == lbHY, cur == lbNS:
+		return noBreak
+	//LB23, LB25, LB28, LB29
+	case (prev == lbAL || prev == lbNU || prev == lbIS) && (cur == lbAL || cur == lbNU):
+		return noBreak
+	case prev == lbNU && cur == lbSY, prev == lbSY && cur == lbNU:
+		return noBreak
+	//LB25
+	case prev == lbHY && cur == lbNU, prev == lbCL && cur == lbNU:
+		return noBreak
+	//LB30
+	case (prev == lbAL || prev == lbNU) && cur == lbOP:
+		return noBreak
+	case prev == lbCL && (cur == lbAL || cur == lbNU):
+		return noBreak
+	}
+	//LB31
+	return allowBreak
+}
+
+//isSpace reports whether r is whitespace that may be dropped at the end
+//of a line.
+func isSpace(r rune) bool {
+	switch class(r) {
+	case lbSP, lbBK, lbCR, lbLF, lbNL, lbZW:
+		return true
+	}
+	return r == '\t'
+}
+
+//endsInHardBreak reports whether the last rune of s is a mandatory break.
+func endsInHardBreak(s string) bool {
+	r, n := utf8.DecodeLastRuneInString(s)
+	if n == 0 {
+		return false
+	}
+	switch class(r) {
+	case lbBK, lbCR, lbLF, lbNL:
+		return true
+	}
+	return false
+}
+
+//trimTrailingSpace returns s without trailing whitespace and line
+//terminators.
+func trimTrailingSpace(s string) string {
+	for len(s) > 0 {
+		r, n := utf8.DecodeLastRuneInString(s)
+		if !isSpace(r) {
+			break
+		}
+		s = s[:len(s)-n]
+	}
+	return s
+}
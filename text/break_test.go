@@ -0,0 +1,54 @@
+package text
+
+import (
+	"reflect"
+	"testing"
+)
+
+//breakPoints returns the text between break opportunities,
+//marking hard breaks with a trailing "!".
+func breakPoints(s string) (out []string) {
+	bs := lineBreaks(s)
+	start := 0
+	for i := 1; i < len(bs); i++ {
+		switch bs[i] {
+		case allowBreak:
+			out = append(out, s[start:i])
+			start = i
+		case mustBreak:
+			out = append(out, s[start:i]+"!")
+			start = i
+		}
+	}
+	return out
+}
+
+func TestLineBreaks(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"word", []string{"word!"}},
+		{"two words", []string{"two ", "words!"}},
+		{"many   spaces", []string{"many   ", "spaces!"}},
+		{"hard\nbreak", []string{"hard\n!", "break!"}},
+		{"crlf\r\nbreak", []string{"crlf\r\n!", "break!"}},
+		{"well-known", []string{"well-", "known!"}},
+		{"-5 degrees", []string{"-5 ", "degrees!"}},
+		{"end. Next", []string{"end. ", "Next!"}},
+		{"wait !", []string{"wait !!"}},
+		{"(open) close", []string{"(open) ", "close!"}},
+		{"( spaced", []string{"( spaced!"}},
+		{"no break", []string{"no break!"}},
+		{"zero​width", []string{"zero​", "width!"}},
+		{"1,000.5/2", []string{"1,000.5/2!"}},
+		{"漢字かな", []string{"漢", "字", "か", "な!"}},
+		{"é x", []string{"é ", "x!"}},
+		{"say \"hi\" now", []string{"say ", "\"hi\" ", "now!"}},
+	} {
+		if got := breakPoints(tc.in); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: got %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,408 @@
+//Package text lays out paragraphs of text using a cairo.ScaledFont.
+//
+//Text is broken into lines that fit a given width according to the Unicode
+//line breaking algorithm, aligned, and shaped into glyph runs that can be
+//drawn with Context.ShowTextGlyphs, so that the text remains searchable
+//and selectable in output formats that support it, such as PDF.
+//
+//Shaping is performed with ScaledFont.TextToGlyphs and so is limited to
+//what libcairo provides: there is no complex script shaping or
+//bidirectional reordering.
+package text
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//Alignments specify how lines are positioned horizontally in a Layout.
+const (
+	//AlignLeft aligns lines to the left edge of the box.
+	AlignLeft alignment = iota
+	//AlignRight aligns lines to the right edge of the box.
+	AlignRight
+	//AlignCenter centers lines in the box.
+	AlignCenter
+	//AlignJustify stretches the spaces in each line so that it fills the box.
+	//The last line of each paragraph, and any line with no spaces,
+	//is aligned left.
+	AlignJustify
+)
+
+type alignment int
+
+func (a alignment) String() string {
+	switch a {
+	case AlignLeft:
+		return "AlignLeft"
+	case AlignRight:
+		return "AlignRight"
+	case AlignCenter:
+		return "AlignCenter"
+	case AlignJustify:
+		return "AlignJustify"
+	}
+	return "unknown alignment"
+}
+
+//DefaultEllipsis is used when Options.Ellipsis is empty.
+const DefaultEllipsis = "…"
+
+//Options control how text is laid out.
+type Options struct {
+	//Width is the width of the box the text is laid out in.
+	//If Width is not positive, lines are only broken at hard breaks
+	//and aligned within the width of the longest line.
+	Width float64
+	//Align is the horizontal alignment of each line.
+	Align alignment
+	//LineSpacing is a multiple of the font's recommended distance between
+	//baselines, FontExtents.Height.
+	//If LineSpacing is not positive, 1 is used.
+	LineSpacing float64
+	//MaxLines is the maximum number of lines.
+	//If the text requires more lines, the last line is ellipsized.
+	//If MaxLines is not positive, there is no limit.
+	MaxLines int
+	//Ellipsis is appended to a line that has been ellipsized.
+	//If empty, DefaultEllipsis is used.
+	Ellipsis string
+}
+
+//Run is a sequence of shaped glyphs and the text they represent.
+//
+//The fields of a Run may be passed directly to Context.ShowTextGlyphs.
+type Run struct {
+	//Text is the text represented by the glyphs.
+	Text string
+	//Glyphs are the positioned glyphs, in user space.
+	Glyphs []cairo.Glyph
+	//Clusters map the bytes of Text to Glyphs.
+	Clusters []cairo.TextCluster
+	//Flags are the text cluster flags of Clusters.
+	Flags cairo.TextClusterFlags
+}
+
+//Line is a single line of a Layout.
+type Line struct {
+	Run
+	//Origin is the point on the baseline where the line starts.
+	Origin cairo.Point
+	//Width is the advance of the line, including any space added
+	//for justification.
+	Width float64
+	//Ellipsized is set if text was removed from the end of this line.
+	Ellipsized bool
+}
+
+//Layout is a paragraph of text broken into lines.
+//
+//The coordinates of a Layout are relative to the top left corner of
+//its box.
+type Layout struct {
+	//Lines are the lines of the layout, top to bottom.
+	Lines []Line
+	//Size is the width and height of the box containing the lines.
+	Size cairo.Point
+	//Extents are the extents of the font used.
+	Extents cairo.FontExtents
+	//Truncated is set if there were more lines than Options.MaxLines.
+	Truncated bool
+}
+
+//shaper measures and shapes text.
+//It is satisfied by *cairo.ScaledFont.
+type shaper interface {
+	Extents() cairo.FontExtents
+	TextExtents(string) cairo.TextExtents
+	TextToGlyphs(cairo.Point, string) ([]cairo.Glyph, []cairo.TextCluster, cairo.TextClusterFlags, error)
+}
+
+//New lays out s with font according to opts.
+//
+//Hard breaks, such as "\n", always start a new line,
+//so s ending in a hard break has an empty last line.
+func New(s string, font *cairo.ScaledFont, opts Options) (*Layout, error) {
+	if font == nil {
+		return nil, errors.New("nil ScaledFont")
+	}
+	if err := font.Err(); err != nil {
+		return nil, err
+	}
+	return layout(s, font, opts)
+}
+
+//NewFromFont lays out s with font at size, in user space units,
+//according to opts.
+//
+//See New for details.
+func NewFromFont(s string, font cairo.Font, size float64, opts Options) (*Layout, error) {
+	sf, err := cairo.NewScaledFont(font, cairo.NewScaleMatrix(cairo.Pt(size, size)), cairo.NewIdentityMatrix(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sf.Close()
+	return layout(s, sf, opts)
+}
+
+//Draw draws l with its top left corner at topLeft.
+//
+//The scaled font used to create l must be set on c.
+func (l *Layout) Draw(c *cairo.Context, topLeft cairo.Point) {
+	for _, ln := range l.Lines {
+		gs := make([]cairo.Glyph, len(ln.Glyphs))
+		for i, g := range ln.Glyphs {
+			g.Point = g.Point.Add(topLeft)
+			gs[i] = g
+		}
+		c.ShowTextGlyphs(ln.Text, gs, ln.Clusters, ln.Flags)
+	}
+}
+
+//segment is the text between two break opportunities.
+type segment struct {
+	text  string
+	width float64 //advance of text
+	trim  float64 //advance of text without trailing space
+	hard  bool    //segment ends with a hard break
+}
+
+//line is a line of segments before shaping.
+type line struct {
+	text       string
+	width      float64
+	hard       bool //last line of a paragraph
+	ellipsized bool
+}
+
+func layout(s string, font shaper, opts Options) (*Layout, error) {
+	if opts.LineSpacing <= 0 {
+		opts.LineSpacing = 1
+	}
+	if opts.Ellipsis == "" {
+		opts.Ellipsis = DefaultEllipsis
+	}
+	advance := func(s string) float64 {
+		return font.TextExtents(s).AdvanceX
+	}
+
+	//split into segments at each break opportunity
+	var segs []segment
+	breaks := lineBreaks(s)
+	start := 0
+	for i := 1; i <= len(s); i++ {
+		if breaks[i] == noBreak {
+			continue
+		}
+		t := s[start:i]
+		segs = append(segs, segment{
+			text:  t,
+			width: advance(t),
+			trim:  advance(trimTrailingSpace(t)),
+			hard:  breaks[i] == mustBreak,
+		})
+		start = i
+	}
+
+	//greedily fill lines
+	var lines []line
+	var cur []segment
+	flush := func(hard bool) {
+		var b strings.Builder
+		for _, sg := range cur {
+			b.WriteString(sg.text)
+		}
+		t := trimTrailingSpace(b.String())
+		lines = append(lines, line{text: t, width: advance(t), hard: hard})
+		cur = cur[:0]
+	}
+	var width float64 //width of cur, including trailing space
+	for _, sg := range segs {
+		if opts.Width > 0 && len(cur) > 0 && width+sg.trim > opts.Width {
+			flush(false)
+			width = 0
+		}
+		if opts.Width > 0 && len(cur) == 0 && sg.trim > opts.Width {
+			//no break opportunity fits, so break anywhere
+			pieces := splitToFit(sg, opts.Width, advance)
+			for _, piece := range pieces[:len(pieces)-1] {
+				cur = append(cur, piece)
+				flush(false)
+			}
+			sg = pieces[len(pieces)-1]
+		}
+		cur = append(cur, sg)
+		width += sg.width
+		if sg.hard {
+			flush(true)
+			width = 0
+		}
+	}
+	if len(cur) > 0 {
+		flush(true)
+	}
+	//a hard break at the end of s starts an empty last line
+	if endsInHardBreak(s) {
+		flush(true)
+	}
+
+	L := &Layout{Extents: font.Extents()}
+	if opts.MaxLines > 0 && len(lines) > opts.MaxLines {
+		lines = lines[:opts.MaxLines]
+		last := &lines[len(lines)-1]
+		last.text = ellipsize(last.text, opts.Width, opts.Ellipsis, advance)
+		last.width = advance(last.text)
+		last.hard, last.ellipsized = true, true
+		L.Truncated = true
+	}
+
+	box := opts.Width
+	if box <= 0 {
+		for _, ln := range lines {
+			if ln.width > box {
+				box = ln.width
+			}
+		}
+	}
+
+	lineHeight := L.Extents.Height * opts.LineSpacing
+	for i, ln := range lines {
+		y := L.Extents.Ascent + float64(i)*lineHeight
+		var x float64
+		switch opts.Align {
+		case AlignRight:
+			x = box - ln.width
+		case AlignCenter:
+			x = (box - ln.width) / 2
+		}
+		origin := cairo.Pt(x, y)
+		glyphs, clusters, flags, err := font.TextToGlyphs(origin, ln.text)
+		if err != nil {
+			return nil, err
+		}
+		out := Line{
+			Run: Run{
+				Text:     ln.text,
+				Glyphs:   glyphs,
+				Clusters: clusters,
+				Flags:    flags,
+			},
+			Origin:     origin,
+			Width:      ln.width,
+			Ellipsized: ln.ellipsized,
+		}
+		if opts.Align == AlignJustify && !ln.hard {
+			out.justify(box - ln.width)
+		}
+		L.Lines = append(L.Lines, out)
+	}
+
+	L.Size = cairo.Pt(box, 0)
+	if n := len(L.Lines); n > 0 {
+		L.Size.Y = float64(n-1)*lineHeight + L.Extents.Ascent + L.Extents.Descent
+	}
+	return L, nil
+}
+
+//splitToFit breaks sg into pieces that each fit in width,
+//breaking between runes.
+//Each piece has at least one rune.
+//Only the last piece inherits sg.hard.
+func splitToFit(sg segment, width float64, advance func(string) float64) (out []segment) {
+	s := sg.text
+	for len(s) > 0 {
+		end := 0
+		for i, r := range s {
+			n := i + utf8.RuneLen(r)
+			if end > 0 && advance(trimTrailingSpace(s[:n])) > width {
+				break
+			}
+			end = n
+		}
+		//keep combining marks with their base
+		for end < len(s) {
+			r, n := utf8.DecodeRuneInString(s[end:])
+			if !unicode.In(r, unicode.Mn, unicode.Me) {
+				break
+			}
+			end += n
+		}
+		t := s[:end]
+		out = append(out, segment{
+			text:  t,
+			width: advance(t),
+			trim:  advance(trimTrailingSpace(t)),
+		})
+		s = s[end:]
+	}
+	out[len(out)-1].hard = sg.hard
+	return out
+}
+
+//ellipsize removes runes from the end of s until s followed by ellipsis
+//fits in width, then returns s followed by ellipsis.
+//If width is not positive, the ellipsis is simply appended.
+func ellipsize(s string, width float64, ellipsis string, advance func(string) float64) string {
+	s = trimTrailingSpace(s)
+	if width > 0 {
+		for len(s) > 0 && advance(s+ellipsis) > width {
+			_, n := utf8.DecodeLastRuneInString(s)
+			s = trimTrailingSpace(s[:len(s)-n])
+		}
+	}
+	return s + ellipsis
+}
+
+//justify distributes extra space evenly among the spaces in l.
+func (l *Line) justify(extra float64) {
+	if extra <= 0 || len(l.Clusters) == 0 {
+		return
+	}
+
+	//find the glyph range and whether it is a space for each cluster,
+	//in logical order.
+	type span struct {
+		g0, g1 int
+		space  bool
+	}
+	spans := make([]span, 0, len(l.Clusters))
+	b, g := 0, 0
+	if l.Flags&cairo.TextClusterBackward != 0 {
+		g = len(l.Glyphs)
+	}
+	spaces := 0
+	for _, c := range l.Clusters {
+		t := l.Text[b : b+c.RuneLength]
+		sp := strings.TrimFunc(t, unicode.IsSpace) == "" && t != ""
+		if sp {
+			spaces++
+		}
+		if l.Flags&cairo.TextClusterBackward != 0 {
+			spans = append(spans, span{g - c.NumGlyphs, g, sp})
+			g -= c.NumGlyphs
+		} else {
+			spans = append(spans, span{g, g + c.NumGlyphs, sp})
+			g += c.NumGlyphs
+		}
+		b += c.RuneLength
+	}
+	if spaces == 0 {
+		return
+	}
+
+	per := extra / float64(spaces)
+	var shift float64
+	for _, s := range spans {
+		for i := s.g0; i < s.g1; i++ {
+			l.Glyphs[i].Point.X += shift
+		}
+		if s.space {
+			shift += per
+		}
+	}
+	l.Width += extra
+}
@@ -0,0 +1,159 @@
+package text
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/jimmyfrasche/cairo"
+)
+
+//mono is a monospaced shaper where every rune has an advance of 1.
+type mono struct{}
+
+func (mono) Extents() cairo.FontExtents {
+	return cairo.FontExtents{Ascent: .8, Descent: .2, Height: 1.2}
+}
+
+func (mono) TextExtents(s string) cairo.TextExtents {
+	return cairo.TextExtents{AdvanceX: float64(utf8.RuneCountInString(s))}
+}
+
+func (mono) TextToGlyphs(o cairo.Point, s string) (gs []cairo.Glyph, cs []cairo.TextCluster, _ cairo.TextClusterFlags, _ error) {
+	for _, r := range s {
+		gs = append(gs, cairo.Glyph{Index: uint64(r), Point: o})
+		cs = append(cs, cairo.TextCluster{RuneLength: utf8.RuneLen(r), NumGlyphs: 1})
+		o.X++
+	}
+	return gs, cs, 0, nil
+}
+
+func lineTexts(l *Layout) (out []string) {
+	for _, ln := range l.Lines {
+		out = append(out, ln.Text)
+	}
+	return out
+}
+
+func TestLayout(t *testing.T) {
+	const s = "the quick brown fox jumps over the lazy dog"
+	l, err := layout(s, mono{}, Options{Width: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"the quick", "brown fox", "jumps over", "the lazy", "dog"}
+	if got := lineTexts(l); !equal(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if l.Size != cairo.Pt(10, 4*1.2+1) {
+		t.Errorf("size %v", l.Size)
+	}
+	if o := l.Lines[1].Origin; o != cairo.Pt(0, .8+1.2) {
+		t.Errorf("second line origin %v", o)
+	}
+}
+
+func TestLayoutAlign(t *testing.T) {
+	const s = "aa b cc"
+	for _, tc := range []struct {
+		align alignment
+		x     []float64 //x of each glyph on the first line
+		last  float64   //x of the last line
+	}{
+		{AlignLeft, []float64{0, 1, 2, 3}, 0},
+		{AlignRight, []float64{2, 3, 4, 5}, 4},
+		{AlignCenter, []float64{1, 2, 3, 4}, 2},
+		{AlignJustify, []float64{0, 1, 2, 5}, 0},
+	} {
+		l, err := layout(s, mono{}, Options{Width: 6, Align: tc.align})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, g := range l.Lines[0].Glyphs {
+			if g.Point.X != tc.x[i] {
+				t.Errorf("%v: glyph %d at %g, want %g", tc.align, i, g.Point.X, tc.x[i])
+			}
+		}
+		if x := l.Lines[1].Origin.X; x != tc.last {
+			t.Errorf("%v: last line at %g, want %g", tc.align, x, tc.last)
+		}
+	}
+}
+
+func TestLayoutLongWord(t *testing.T) {
+	l, err := layout("a abcdefgh b", mono{}, Options{Width: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	//"gh" and "b" do not fit together in 3
+	want := []string{"a", "abc", "def", "gh", "b"}
+	if got := lineTexts(l); !equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestLayoutEllipsize(t *testing.T) {
+	l, err := layout("one two three four", mono{}, Options{Width: 8, MaxLines: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lineTexts(l); !equal(got, []string{"one two…"}) {
+		t.Errorf("got %q", got)
+	}
+	if !l.Truncated || !l.Lines[0].Ellipsized {
+		t.Error("not marked as truncated")
+	}
+
+	l, err = layout("one two three", mono{}, Options{Width: 7, MaxLines: 1, Ellipsis: "..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lineTexts(l); !equal(got, []string{"one..."}) {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestLayoutNoWidth(t *testing.T) {
+	l, err := layout("short\nlonger line", mono{}, Options{Align: AlignRight})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lineTexts(l); !equal(got, []string{"short", "longer line"}) {
+		t.Errorf("got %q", got)
+	}
+	if l.Size.X != 11 || l.Lines[0].Origin.X != 6 {
+		t.Errorf("size %v, first origin %v", l.Size, l.Lines[0].Origin)
+	}
+}
+
+func TestLayoutTrailingBreak(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want []string
+	}{
+		{"a", []string{"a"}},
+		{"a\n", []string{"a", ""}},
+		{"a\r\n", []string{"a", ""}},
+		{"a\n\n", []string{"a", "", ""}},
+		{"\n", []string{"", ""}},
+	} {
+		l, err := layout(tc.in, mono{}, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := lineTexts(l); !equal(got, tc.want) {
+			t.Errorf("%q: got %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
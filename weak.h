@@ -0,0 +1,23 @@
+//Declarations of the libcairo functions used by this package that were
+//added in libcairo 1.12 or 1.14.
+//They are weak, so that a program linked against an older libcairo
+//still runs, and the methods using them can report that they are not supported.
+#include <cairo/cairo.h>
+
+#pragma weak cairo_pattern_create_mesh
+#pragma weak cairo_mesh_pattern_begin_patch
+#pragma weak cairo_mesh_pattern_end_patch
+#pragma weak cairo_mesh_pattern_move_to
+#pragma weak cairo_mesh_pattern_line_to
+#pragma weak cairo_mesh_pattern_curve_to
+#pragma weak cairo_mesh_pattern_set_control_point
+#pragma weak cairo_mesh_pattern_set_corner_color_rgba
+#pragma weak cairo_mesh_pattern_get_patch_count
+#pragma weak cairo_mesh_pattern_get_path
+#pragma weak cairo_mesh_pattern_get_control_point
+#pragma weak cairo_mesh_pattern_get_corner_color_rgba
+#pragma weak cairo_surface_create_similar_image
+#pragma weak cairo_surface_map_to_image
+#pragma weak cairo_surface_unmap_image
+#pragma weak cairo_surface_set_device_scale
+#pragma weak cairo_surface_get_device_scale